@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+const asyncAPISchemasRef = "#/components/schemas/%s"
+const asyncAPIMessagesRef = "#/components/messages/"
+
+/**
+Generate an AsyncAPI 2.6.0 document out of the channels in the gen file.
+Every class and enum becomes a component schema, so the channel messages can reference them.
+*/
+type asyncAPILanguageSerializer struct {
+}
+
+func newAsyncAPILanguageSerializer() *asyncAPILanguageSerializer {
+	return &asyncAPILanguageSerializer{}
+}
+
+func (a *asyncAPILanguageSerializer) getType() languageType {
+	return LanguageTypeAsyncAPI
+}
+
+func (a *asyncAPILanguageSerializer) getTypeName() string {
+	return "asyncapi"
+}
+
+func (a *asyncAPILanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+
+	title := serializerInfo.packageName
+	if title == "" {
+		title = "Models"
+	}
+
+	channels := newOrderedMap()
+	messages := newOrderedMap()
+
+	for _, c := range collectChannels(objects) {
+		if _, ok := serializerInfo.classes[c.payloadType]; !ok {
+			return nil, errors.New(fmt.Sprintf(
+				"channel %s payload %s isn't a declared class", c.topic, c.payloadType))
+		}
+
+		typeName := topicTypeName(c.topic)
+		messageRef := newOrderedMap().set("$ref", asyncAPIMessagesRef+typeName)
+
+		// Publish is the operation of the clients which send messages to the application,
+		// so the application handles them. Subscribe is the opposite.
+		channels.set(c.topic, newOrderedMap().
+			set("publish", newOrderedMap().
+				set("operationId", "handle"+typeName).
+				set("message", messageRef)).
+			set("subscribe", newOrderedMap().
+				set("operationId", "publish"+typeName).
+				set("message", messageRef)))
+
+		messages.set(typeName, a.serializeMessage(c, serializerInfo))
+	}
+
+	schemas := newOrderedMap()
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			schemas.set(schemaName(o.name), classSchema(o, asyncAPISchemasRef, serializerInfo))
+		case *enum:
+			schemas.set(schemaName(o.name), enumSchema(o))
+		}
+	}
+
+	document := newOrderedMap().
+		set("asyncapi", "2.6.0").
+		set("info", newOrderedMap().
+			set("title", title).
+			set("version", "1.0.0")).
+		set("defaultContentType", "application/json").
+		set("channels", channels).
+		set("components", newOrderedMap().
+			set("messages", messages).
+			set("schemas", schemas))
+
+	return []*generatedCode{newGeneratedCode("asyncapi.yaml", serializeYAML(document))}, nil
+}
+
+/**
+Serialize the message of a channel, with its headers and the Kafka key binding.
+*/
+func (a *asyncAPILanguageSerializer) serializeMessage(c *channel, serializerInfo *serializerInfo) *orderedMap {
+	result := newOrderedMap().
+		set("name", topicTypeName(c.topic)).
+		set("payload", typeSchema(c.payloadType, asyncAPISchemasRef, serializerInfo))
+
+	if len(c.headers) > 0 {
+		properties := newOrderedMap()
+		for _, header := range c.headers {
+			properties.set(header.name, typeSchema(header.headerType, asyncAPISchemasRef, serializerInfo))
+		}
+
+		result.set("headers", newOrderedMap().
+			set("type", "object").
+			set("properties", properties))
+	}
+
+	if c.keyField != "" {
+		keySchema := newOrderedMap().set("type", "string")
+		if member := findDataMember(serializerInfo.classes[c.payloadType], c.keyField); member != nil {
+			keySchema = typeSchema(member.memberType, asyncAPISchemasRef, serializerInfo)
+		}
+
+		result.set("bindings", newOrderedMap().
+			set("kafka", newOrderedMap().
+				set("key", keySchema).
+				set("bindingVersion", "0.4.0")))
+	}
+
+	return result
+}
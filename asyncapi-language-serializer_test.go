@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_asyncAPILanguageSerializer_getType(t *testing.T) {
+	if got := newAsyncAPILanguageSerializer().getType(); got != LanguageTypeAsyncAPI {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeAsyncAPI)
+	}
+}
+
+func Test_asyncAPILanguageSerializer_getTypeName(t *testing.T) {
+	if got := newAsyncAPILanguageSerializer().getTypeName(); got != "asyncapi" {
+		t.Errorf("getTypeName() = %v, want %v", got, "asyncapi")
+	}
+}
+
+func Test_asyncAPILanguageSerializer_generateCode(t *testing.T) {
+	channels, info := getTestChannels()
+
+	status := newEnum("status")
+	_ = status.addValue("active", "1", nil)
+
+	objects := []middleware{info.classes["orderCreated"], status}
+	for _, c := range channels {
+		objects = append(objects, c)
+	}
+
+	got, err := newAsyncAPILanguageSerializer().generateCode(objects, &serializerInfo{packageName: "shop"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 1 || got[0].fileName != "asyncapi.yaml" {
+		t.Errorf("generateCode() should create a single asyncapi.yaml file, got %v", got)
+		return
+	}
+
+	expectedParts := []string{
+		"asyncapi: \"2.6.0\"\ninfo:\n  title: shop\n",
+		"  orders.created:\n    publish:\n      operationId: handleOrdersCreated\n" +
+			"      message:\n        $ref: \"#/components/messages/OrdersCreated\"\n" +
+			"    subscribe:\n      operationId: publishOrdersCreated\n",
+		"    OrdersCreated:\n      name: OrdersCreated\n      payload:\n        $ref: \"#/components/schemas/OrderCreated\"\n",
+		"      headers:\n        type: object\n        properties:\n          traceId:\n            type: string\n",
+		"      bindings:\n        kafka:\n          key:\n            type: integer\n",
+		"    Status:\n      type: integer\n",
+		"    OrderCreated:\n      type: object\n",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got[0].code, part) {
+			t.Errorf("generateCode() code doesn't contain %v.\ncode: %v", part, got[0].code)
+		}
+	}
+
+	unknownPayload := newChannel("orders.created", "status", nil)
+	if _, err := newAsyncAPILanguageSerializer().generateCode([]middleware{status, unknownPayload}, &serializerInfo{}); err == nil {
+		t.Errorf("generateCode() expected an error for a channel without class payload")
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+Generate an Avro schema (.avsc) for every class and enum, with the package name as the namespace.
+Avro must define a named type before it's referenced, so every schema defines the records and enums
+it uses inline the first time, and references them by name afterwards.
+*/
+type avroLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newAvroLanguageSerializer() *avroLanguageSerializer {
+	result := &avroLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "boolean"
+	result.typesMap["int"] = "int"
+	result.typesMap["string"] = "string"
+	result.typesMap["double"] = "double"
+	result.typesMap["float"] = "float"
+	result.typesMap["char"] = "string"
+	result.typesMap["byte"] = "int"
+
+	return result
+}
+
+func (a *avroLanguageSerializer) getType() languageType {
+	return LanguageTypeAvro
+}
+
+func (a *avroLanguageSerializer) getTypeName() string {
+	return "avro"
+}
+
+func (a *avroLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, and services and channels aren't generated for Avro
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		schema := a.typeSchema(middlewareName(object), serializerInfo, make(map[string]bool)).(*orderedMap)
+
+		if serializerInfo.packageName != "" {
+			// The namespace comes right after the type and the name, and the nested types inherit it
+			withNamespace := newOrderedMap()
+			for i, key := range schema.keys {
+				withNamespace.set(key, schema.values[key])
+
+				if i == 1 {
+					withNamespace.set("namespace", serializerInfo.packageName)
+				}
+			}
+
+			schema = withNamespace
+		}
+
+		fileName := fmt.Sprintf("%s.avsc", a.typeName(middlewareName(object), serializerInfo))
+		result = append(result, newGeneratedCode(fileName, serializeJSON(schema)+"\n"))
+	}
+
+	return result, nil
+}
+
+/**
+Get the Avro name of a record or an enum, taking the name annotation into account.
+*/
+func (a *avroLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(typeName, LanguageTypeAvro, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(typeName)
+}
+
+/**
+Build the Avro schema of a gen file type. Records and enums are defined the first time
+they are used, and the defined parameter keeps their names, so the next uses only reference them.
+*/
+func (a *avroLanguageSerializer) typeSchema(typeName string, serializerInfo *serializerInfo, defined map[string]bool) interface{} {
+	if isList, listType := isList(typeName); isList {
+		return newOrderedMap().
+			set("type", "array").
+			set("items", a.typeSchema(listType, serializerInfo, defined))
+	}
+
+	// Avro map keys are always strings, like JSON object keys
+	if isMap, _, mapValueType := isMap(typeName); isMap {
+		return newOrderedMap().
+			set("type", "map").
+			set("values", a.typeSchema(mapValueType, serializerInfo, defined))
+	}
+
+	if typeName == "date" {
+		return newOrderedMap().
+			set("type", "long").
+			set("logicalType", "timestamp-millis")
+	}
+
+	if primitiveType, isPrimitive := a.typesMap[typeName]; isPrimitive {
+		return primitiveType
+	}
+
+	// Extern types are hand-written records, which are registered with their full names
+	if externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeAvro); isExtern {
+		return externName
+	}
+
+	name := a.typeName(typeName, serializerInfo)
+	if defined[name] {
+		return name
+	}
+
+	defined[name] = true
+
+	if e, isEnum := serializerInfo.enums[typeName]; isEnum {
+		return a.serializeEnum(e, name)
+	}
+
+	if c, isClass := serializerInfo.classes[typeName]; isClass {
+		return a.serializeClass(c, name, serializerInfo, defined)
+	}
+
+	return name
+}
+
+func (a *avroLanguageSerializer) serializeClass(class *class, name string, serializerInfo *serializerInfo, defined map[string]bool) *orderedMap {
+	fields := make([]interface{}, 0)
+
+	for _, member := range class.dataMembers {
+		fieldName := toCamelCase(member.name)
+		if overrideName, ok := findLanguageAnnotation(member.annotations, LanguageTypeAvro, "name"); ok {
+			fieldName = overrideName
+		}
+
+		var fieldType interface{}
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeAvro, "type"); ok {
+			fieldType = overrideType
+		} else {
+			fieldType = a.typeSchema(member.memberType, serializerInfo, defined)
+		}
+
+		fields = append(fields, newOrderedMap().
+			set("name", fieldName).
+			set("type", fieldType))
+	}
+
+	return newOrderedMap().
+		set("type", "record").
+		set("name", name).
+		set("fields", fields)
+}
+
+/**
+Serialize an enum. Avro enums are written by their symbols, so the integer values are only documented.
+*/
+func (a *avroLanguageSerializer) serializeEnum(enum *enum, name string) *orderedMap {
+	symbols := make([]interface{}, 0)
+	values := make([]string, 0)
+
+	for _, value := range enum.enumValues {
+		symbol := strings.ToUpper(toSnakeCase(value.name))
+		if overrideName, ok := findLanguageAnnotation(value.annotations, LanguageTypeAvro, "name"); ok {
+			symbol = overrideName
+		}
+
+		symbols = append(symbols, symbol)
+		values = append(values, fmt.Sprintf("%s = %v", symbol, value.value))
+	}
+
+	return newOrderedMap().
+		set("type", "enum").
+		set("name", name).
+		set("doc", "JSON values: "+strings.Join(values, ", ")).
+		set("symbols", symbols)
+}
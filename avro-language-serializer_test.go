@@ -0,0 +1,157 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_avroLanguageSerializer_getType(t *testing.T) {
+	if got := newAvroLanguageSerializer().getType(); got != LanguageTypeAvro {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeAvro)
+	}
+}
+
+func Test_avroLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newAvroLanguageSerializer().getTypeName(); got != "avro" {
+		t.Errorf("getTypeName() = %v, want %v", got, "avro")
+	}
+}
+
+func Test_avroLanguageSerializer_generateCode(t *testing.T) {
+	got, err := newAvroLanguageSerializer().generateCode(getTestSchemaObjects(), &serializerInfo{packageName: "com.acme.shop"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 3 {
+		t.Errorf("generateCode() generated %v files. expected 3", len(got))
+		return
+	}
+
+	fileNames := []string{got[0].fileName, got[1].fileName, got[2].fileName}
+	if !reflect.DeepEqual(fileNames, []string{"Order.avsc", "OrderItem.avsc", "OrderStatus.avsc"}) {
+		t.Errorf("generateCode() file names = %v", fileNames)
+	}
+
+	want := "{\n" +
+		"  \"type\": \"enum\",\n" +
+		"  \"name\": \"OrderStatus\",\n" +
+		"  \"namespace\": \"com.acme.shop\",\n" +
+		"  \"doc\": \"JSON values: ACTIVE = 1\",\n" +
+		"  \"symbols\": [\n    \"ACTIVE\"\n  ]\n" +
+		"}\n"
+
+	if got[2].code != want {
+		t.Errorf("generateCode() got = %v, want %v", got[2].code, want)
+	}
+
+	if !strings.HasPrefix(got[0].code, "{\n  \"type\": \"record\",\n  \"name\": \"Order\",\n  \"namespace\": \"com.acme.shop\",\n") {
+		t.Errorf("generateCode() didn't add the namespace. code: %v", got[0].code)
+	}
+
+	got, err = newAvroLanguageSerializer().generateCode(getTestSchemaObjects(), &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if strings.Contains(got[0].code, "namespace") {
+		t.Errorf("generateCode() added a namespace without a package. code: %v", got[0].code)
+	}
+}
+
+func Test_avroLanguageSerializer_typeSchema(t *testing.T) {
+	status := newEnum("status")
+	_ = status.addValue("active", "1", nil)
+
+	node := newClass("node")
+	_ = node.addValue("children", "list<node>", nil)
+	_ = node.addValue("status", "status", nil)
+	_ = node.addValue("lastStatus", "status", nil)
+
+	info := &serializerInfo{
+		externTypes: getTestExternTypes(),
+		classes:     collectClasses([]middleware{node}),
+		enums:       collectEnums([]middleware{status}),
+	}
+	info.externTypes["Money"].languageNames[LanguageTypeAvro] = "com.acme.Money"
+
+	statusSchema := newOrderedMap().
+		set("type", "enum").
+		set("name", "Status").
+		set("doc", "JSON values: ACTIVE = 1").
+		set("symbols", []interface{}{"ACTIVE"})
+
+	tests := []struct {
+		name     string
+		typeName string
+		want     interface{}
+	}{
+		{name: "Primitive", typeName: "byte", want: "int"},
+		{
+			name:     "Date",
+			typeName: "date",
+			want:     newOrderedMap().set("type", "long").set("logicalType", "timestamp-millis"),
+		},
+		{
+			name:     "List",
+			typeName: "list<string>",
+			want:     newOrderedMap().set("type", "array").set("items", "string"),
+		},
+		{
+			name:     "Map",
+			typeName: "map<int,double>",
+			want:     newOrderedMap().set("type", "map").set("values", "double"),
+		},
+		{name: "Extern type", typeName: "Money", want: "com.acme.Money"},
+		{name: "Enum", typeName: "status", want: statusSchema},
+		{
+			name:     "Recursive record",
+			typeName: "node",
+			want: newOrderedMap().
+				set("type", "record").
+				set("name", "Node").
+				set("fields", []interface{}{
+					newOrderedMap().set("name", "children").set("type", newOrderedMap().set("type", "array").set("items", "Node")),
+					newOrderedMap().set("name", "status").set("type", statusSchema),
+					newOrderedMap().set("name", "lastStatus").set("type", "Status"),
+				}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newAvroLanguageSerializer().typeSchema(tt.typeName, info, make(map[string]bool))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("typeSchema() = %v, want %v", serializeJSON(got), serializeJSON(tt.want))
+			}
+		})
+	}
+}
+
+func Test_avroLanguageSerializer_serializeClass(t *testing.T) {
+	event := &class{
+		name: "event",
+		dataMembers: []*dataMember{
+			{
+				memberType: "string",
+				name:       "payload",
+				annotations: []*annotation{
+					{namespace: "avro", name: "type", arguments: []string{"bytes"}},
+					{namespace: "avro", name: "name", arguments: []string{"body"}},
+				},
+			},
+		},
+	}
+
+	want := newOrderedMap().
+		set("type", "record").
+		set("name", "UserEvent").
+		set("fields", []interface{}{newOrderedMap().set("name", "body").set("type", "bytes")})
+
+	got := newAvroLanguageSerializer().serializeClass(event, "UserEvent", &serializerInfo{}, make(map[string]bool))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("serializeClass() = %v, want %v", serializeJSON(got), serializeJSON(want))
+	}
+}
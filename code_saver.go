@@ -4,13 +4,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
 func saveGeneratedCode(generatedCode *generatedCode, languageName string, generateDate string) error {
 	folderPath := fmt.Sprintf("%s/%s", languageName, generateDate)
 	filePath := fmt.Sprintf("%s/%s", folderPath, generatedCode.fileName)
 
-	err := os.MkdirAll(folderPath, os.ModePerm)
+	// File names can contain directories, like Java packages
+	err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,487 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var cppKeywords = []string{
+	"alignas", "alignof", "and", "asm", "auto", "bool", "break", "case", "catch", "char", "class", "const",
+	"constexpr", "continue", "decltype", "default", "delete", "do", "double", "else", "enum", "explicit",
+	"export", "extern", "false", "float", "for", "friend", "goto", "if", "inline", "int", "long", "mutable",
+	"namespace", "new", "noexcept", "not", "nullptr", "operator", "or", "private", "protected", "public",
+	"register", "return", "short", "signed", "sizeof", "static", "struct", "switch", "template", "this",
+	"throw", "true", "try", "typedef", "typeid", "typename", "union", "unsigned", "using", "virtual",
+	"void", "volatile", "while", "xor",
+}
+
+/**
+Generate headers with structs and enum classes, and the to_json and from_json functions of nlohmann::json.
+Every class and enum gets its own header, and models.hpp includes all of them.
+*/
+type cppLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newCppLanguageSerializer() *cppLanguageSerializer {
+	result := &cppLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "int32_t"
+	result.typesMap["string"] = "std::string"
+	result.typesMap["double"] = "double"
+	result.typesMap["float"] = "float"
+	result.typesMap["char"] = "std::string"
+	result.typesMap["byte"] = "uint8_t"
+	result.typesMap["date"] = "std::string"
+
+	return result
+}
+
+func (c *cppLanguageSerializer) getType() languageType {
+	return LanguageTypeCpp
+}
+
+func (c *cppLanguageSerializer) getTypeName() string {
+	return "cpp"
+}
+
+func (c *cppLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	names := make([]string, 0)
+	generated := make(map[string]bool)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for C++
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		names = append(names, middlewareName(object))
+		generated[middlewareName(object)] = true
+	}
+
+	dependencies := make(map[string][]string)
+	for _, name := range names {
+		if class, ok := serializerInfo.classes[name]; ok {
+			dependencies[name] = c.classDependencies(class, generated)
+		}
+	}
+
+	// A header must be included after the headers of the types it uses
+	sortedNames, err := sortByDependencies(names, dependencies)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("can't generate headers that include each other: %v", err))
+	}
+
+	positions := make(map[string]int)
+	for i, name := range sortedNames {
+		positions[name] = i
+	}
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			includes := make([]string, 0)
+			for _, dependency := range dependencies[o.name] {
+				if dependency != o.name {
+					includes = append(includes, dependency)
+				}
+			}
+
+			sort.SliceStable(includes, func(i, j int) bool {
+				return positions[includes[i]] < positions[includes[j]]
+			})
+
+			serialized, err := c.serializeClass(o, includes, serializerInfo)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, serialized)
+		case *enum:
+			result = append(result, c.serializeEnum(o, serializerInfo))
+		}
+	}
+
+	return append(result, c.serializeModels(sortedNames)), nil
+}
+
+/**
+Get the classes and enums a class uses, which their headers must be included first.
+*/
+func (c *cppLanguageSerializer) classDependencies(class *class, generated map[string]bool) []string {
+	result := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeCpp, "type"); ok {
+			continue
+		}
+
+		for _, typeName := range elementTypes(member.memberType) {
+			if generated[typeName] {
+				result = appendUnique(result, typeName)
+			}
+		}
+	}
+
+	return result
+}
+
+func (c *cppLanguageSerializer) serializeDeclaration() string {
+	return "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n" +
+		"#pragma once\n\n"
+}
+
+/**
+Serialize the includes of a header. The standard headers are sorted, then nlohmann::json,
+then the hand-written headers and the generated headers, which are already in the order they depend on each other.
+*/
+func (c *cppLanguageSerializer) serializeIncludes(standardIncludes []string, includes []string) string {
+	sort.Strings(standardIncludes)
+
+	result := ""
+	for _, include := range standardIncludes {
+		result += fmt.Sprintf("#include <%s>\n", include)
+	}
+
+	if len(standardIncludes) > 0 {
+		result += "\n"
+	}
+
+	result += "#include <nlohmann/json.hpp>\n\n"
+
+	for _, include := range includes {
+		result += fmt.Sprintf("#include \"%s\"\n", include)
+	}
+
+	if len(includes) > 0 {
+		result += "\n"
+	}
+
+	return result
+}
+
+/**
+Wrap the code of a header in the namespace of the package, like "namespace acme::models" for "acme.models".
+*/
+func (c *cppLanguageSerializer) serializeNamespace(code string, serializerInfo *serializerInfo) string {
+	if serializerInfo.packageName == "" {
+		return code
+	}
+
+	namespace := strings.Replace(serializerInfo.packageName, ".", "::", -1)
+
+	return fmt.Sprintf("namespace %s {\n\n%s\n}  // namespace %s\n", namespace, code, namespace)
+}
+
+/**
+Get the header file name of a class or enum, like "order_item.hpp".
+*/
+func (c *cppLanguageSerializer) headerName(name string) string {
+	return fmt.Sprintf("%s.hpp", toSnakeCase(name))
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a C++ type.
+Return the standard header and the hand-written header the type needs, or empty strings if it doesn't need them.
+*/
+func (c *cppLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, string, bool) {
+	if primitiveType, isPrimitive := c.typesMap[typeName]; isPrimitive {
+		switch primitiveType {
+		case "int32_t", "uint8_t":
+			return primitiveType, "cstdint", "", true
+		case "std::string":
+			return primitiveType, "string", "", true
+		}
+
+		return primitiveType, "", "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeCpp)
+	if !isExtern {
+		return "", "", "", false
+	}
+
+	// "acme/money.hpp#acme::Money" includes "acme/money.hpp" and is used as "acme::Money"
+	header, externType, found := strings.Cut(externName, "#")
+	if !found {
+		return externName, "", "", true
+	}
+
+	return externType, "", header, true
+}
+
+/**
+Serialize the C++ type of a gen file type, with std::vector for lists and std::unordered_map for maps.
+Return the standard headers and the hand-written headers the type needs.
+*/
+func (c *cppLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) (string, []string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, standardIncludes, includes := c.typeName(listType, serializerInfo)
+
+		return fmt.Sprintf("std::vector<%s>", itemType), appendUnique(standardIncludes, "vector"), includes
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, standardIncludes, includes := c.typeName(mapKeyType, serializerInfo)
+		valueType, valueStandardIncludes, valueIncludes := c.typeName(mapValueType, serializerInfo)
+
+		for _, include := range valueStandardIncludes {
+			standardIncludes = appendUnique(standardIncludes, include)
+		}
+
+		for _, include := range valueIncludes {
+			includes = appendUnique(includes, include)
+		}
+
+		return fmt.Sprintf("std::unordered_map<%s, %s>", keyType, valueType),
+			appendUnique(standardIncludes, "unordered_map"), includes
+	}
+
+	if knownType, standardInclude, include, isKnown := c.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, standardInclude), appendImport([]string{}, include)
+	}
+
+	// The headers of the generated types are included by their dependencies order
+	return c.className(typeName, serializerInfo), []string{}, []string{}
+}
+
+/**
+Get the C++ type name of a class or an enum, taking the name annotation into account.
+*/
+func (c *cppLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeCpp, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Keywords get an underscore suffix, like "class_".
+*/
+func (c *cppLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeCpp, "name"); ok {
+		return name
+	}
+
+	name := toCamelCase(member.name)
+	for _, keyword := range cppKeywords {
+		if name == keyword {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+/**
+Check a data member can be compiled and written as JSON.
+A struct can contain itself only in a vector, and nlohmann::json writes only maps with string keys as JSON objects,
+so the generated code converts int and byte keys, and doesn't support other keys.
+*/
+func (c *cppLanguageSerializer) validateMember(class *class, member *dataMember, serializerInfo *serializerInfo) error {
+	if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeCpp, "type"); ok {
+		return nil
+	}
+
+	if isRecursiveMember(class, member, serializerInfo.classes) {
+		return errors.New(fmt.Sprintf("%s.%s contains %s by value, which C++ can't compile. use a list instead",
+			class.name, member.name, class.name))
+	}
+
+	isMap, mapKeyType, mapValueType := isMap(member.memberType)
+	if !isMap {
+		return nil
+	}
+
+	if mapValueType == class.name {
+		return errors.New(fmt.Sprintf("%s.%s is a map of %s, which C++ can't compile. use a list instead",
+			class.name, member.name, class.name))
+	}
+
+	if mapKeyType != "string" && mapKeyType != "int" && mapKeyType != "byte" {
+		return errors.New(fmt.Sprintf("nlohmann::json can't write %s map keys as JSON object keys, which %s.%s uses",
+			mapKeyType, class.name, member.name))
+	}
+
+	return nil
+}
+
+func (c *cppLanguageSerializer) serializeClass(class *class, includes []string, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeCpp, "name"); ok {
+		className = name
+	}
+
+	standardIncludes := make([]string, 0)
+	externIncludes := findLanguageImports(class.annotations, LanguageTypeCpp)
+
+	fields := ""
+	toJSON := ""
+	fromJSON := ""
+
+	for _, member := range class.dataMembers {
+		if err := c.validateMember(class, member, serializerInfo); err != nil {
+			return nil, err
+		}
+
+		for _, include := range findLanguageImports(member.annotations, LanguageTypeCpp) {
+			externIncludes = appendUnique(externIncludes, include)
+		}
+
+		memberType, memberStandardIncludes, memberIncludes := c.typeName(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeCpp, "type"); ok {
+			memberType, memberStandardIncludes, memberIncludes = overrideType, []string{}, []string{}
+		}
+
+		for _, include := range memberStandardIncludes {
+			standardIncludes = appendUnique(standardIncludes, include)
+		}
+
+		for _, include := range memberIncludes {
+			externIncludes = appendUnique(externIncludes, include)
+		}
+
+		fieldName := c.fieldName(member)
+		jsonName := toCamelCase(member.name)
+
+		fields += fmt.Sprintf("    %s %s{};\n", memberType, fieldName)
+
+		_, isOverridden := findLanguageAnnotation(member.annotations, LanguageTypeCpp, "type")
+		isMap, mapKeyType, mapValueType := isMap(member.memberType)
+
+		if !isMap || mapKeyType == "string" || isOverridden {
+			toJSON += fmt.Sprintf("    j[\"%s\"] = value.%s;\n", jsonName, fieldName)
+			fromJSON += fmt.Sprintf("    j.at(\"%s\").get_to(value.%s);\n", jsonName, fieldName)
+
+			continue
+		}
+
+		// JSON object keys are strings, so number keys are converted
+		keyType, _, _ := c.typeName(mapKeyType, serializerInfo)
+		valueType, _, _ := c.typeName(mapValueType, serializerInfo)
+
+		toJSON += fmt.Sprintf("    j[\"%s\"] = nlohmann::json::object();\n", jsonName)
+		toJSON += fmt.Sprintf("    for (const auto& [key, item] : value.%s) {\n", fieldName)
+		toJSON += fmt.Sprintf("        j[\"%s\"][std::to_string(key)] = item;\n", jsonName)
+		toJSON += "    }\n"
+
+		fromJSON += fmt.Sprintf("    value.%s.clear();\n", fieldName)
+		fromJSON += fmt.Sprintf("    for (const auto& item : j.at(\"%s\").items()) {\n", jsonName)
+		fromJSON += fmt.Sprintf("        value.%s.emplace(static_cast<%s>(std::stoi(item.key())), item.value().get<%s>());\n",
+			fieldName, keyType, valueType)
+		fromJSON += "    }\n"
+
+		standardIncludes = appendUnique(standardIncludes, "string")
+	}
+
+	serializedCode := ""
+
+	// An empty struct doesn't use the parameters, so they aren't named
+	if len(class.dataMembers) == 0 {
+		serializedCode += fmt.Sprintf("struct %s {};\n\n", className)
+		serializedCode += fmt.Sprintf("inline void to_json(nlohmann::json& j, const %s&) {\n", className)
+		serializedCode += "    j = nlohmann::json::object();\n"
+		serializedCode += "}\n\n"
+		serializedCode += fmt.Sprintf("inline void from_json(const nlohmann::json&, %s&) {}\n", className)
+	} else {
+		serializedCode += fmt.Sprintf("struct %s {\n%s};\n\n", className, fields)
+
+		serializedCode += fmt.Sprintf("inline void to_json(nlohmann::json& j, const %s& value) {\n", className)
+		serializedCode += "    j = nlohmann::json::object();\n"
+		serializedCode += toJSON
+		serializedCode += "}\n\n"
+
+		serializedCode += fmt.Sprintf("inline void from_json(const nlohmann::json& j, %s& value) {\n", className)
+		serializedCode += fromJSON
+		serializedCode += "}\n"
+	}
+
+	for _, include := range includes {
+		externIncludes = append(externIncludes, c.headerName(include))
+	}
+
+	return newGeneratedCode(c.headerName(class.name),
+		c.serializeDeclaration()+c.serializeIncludes(standardIncludes, externIncludes)+
+			c.serializeNamespace(serializedCode, serializerInfo)), nil
+}
+
+func (c *cppLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) *generatedCode {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeCpp, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := fmt.Sprintf("enum class %s : int32_t {\n", enumName)
+	cases := ""
+	values := make(map[int]bool)
+
+	for _, value := range enum.enumValues {
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeCpp, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("    %s = %v,\n", valueName, value.value)
+
+		// Few names can have the same value, but a switch can't have the same case twice
+		if values[value.value] {
+			continue
+		}
+
+		values[value.value] = true
+		cases += fmt.Sprintf("    case %v:\n", value.value)
+	}
+
+	serializedCode += "};\n\n"
+
+	// The enums are written and read by their integer values
+	serializedCode += fmt.Sprintf("inline void to_json(nlohmann::json& j, const %s& value) {\n", enumName)
+	serializedCode += "    j = static_cast<int32_t>(value);\n"
+	serializedCode += "}\n\n"
+
+	serializedCode += fmt.Sprintf("inline void from_json(const nlohmann::json& j, %s& value) {\n", enumName)
+	serializedCode += "    const auto raw = j.get<int32_t>();\n"
+	serializedCode += "    switch (raw) {\n"
+	if cases != "" {
+		serializedCode += cases
+		serializedCode += fmt.Sprintf("        value = static_cast<%s>(raw);\n", enumName)
+		serializedCode += "        break;\n"
+	}
+	serializedCode += "    default:\n"
+	serializedCode += fmt.Sprintf("        throw std::invalid_argument(\"unknown %s value \" + std::to_string(raw));\n", enumName)
+	serializedCode += "    }\n"
+	serializedCode += "}\n"
+
+	return newGeneratedCode(c.headerName(enum.name),
+		c.serializeDeclaration()+c.serializeIncludes([]string{"cstdint", "stdexcept", "string"}, []string{})+
+			c.serializeNamespace(serializedCode, serializerInfo))
+}
+
+/**
+Serialize models.hpp, which includes the headers of all the models in the order they depend on each other.
+*/
+func (c *cppLanguageSerializer) serializeModels(sortedNames []string) *generatedCode {
+	serializedCode := c.serializeDeclaration()
+
+	for _, name := range sortedNames {
+		serializedCode += fmt.Sprintf("#include \"%s\"\n", c.headerName(name))
+	}
+
+	return newGeneratedCode("models.hpp", serializedCode)
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_cppLanguageSerializer_getType(t *testing.T) {
+	if got := newCppLanguageSerializer().getType(); got != LanguageTypeCpp {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeCpp)
+	}
+}
+
+func Test_cppLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newCppLanguageSerializer().getTypeName(); got != "cpp" {
+		t.Errorf("getTypeName() = %v, want %v", got, "cpp")
+	}
+}
+
+func Test_cppLanguageSerializer_generateCode(t *testing.T) {
+	order := newClass("order")
+	_ = order.addValue("items", "list<orderItem>", nil)
+	_ = order.addValue("customer", "customer", nil)
+
+	orderItem := newClass("orderItem")
+	_ = orderItem.addValue("status", "orderStatus", nil)
+
+	customer := newClass("customer")
+	_ = customer.addValue("name", "string", nil)
+
+	status := newEnum("orderStatus")
+	_ = status.addValue("active", "1", nil)
+
+	generatedCode, err := newCppLanguageSerializer().generateCode(
+		[]middleware{order, orderItem, customer, status}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	fileNames := make([]string, 0)
+	for _, code := range generatedCode {
+		fileNames = append(fileNames, code.fileName)
+	}
+
+	wantFileNames := []string{"order.hpp", "order_item.hpp", "customer.hpp", "order_status.hpp", "models.hpp"}
+	if !reflect.DeepEqual(fileNames, wantFileNames) {
+		t.Errorf("generateCode() file names = %v, want %v", fileNames, wantFileNames)
+		return
+	}
+
+	// The headers are included after the headers they depend on
+	if !strings.Contains(generatedCode[0].code, "#include \"order_item.hpp\"\n#include \"customer.hpp\"\n") {
+		t.Errorf("generateCode() order.hpp includes aren't sorted.\ncode: %v", generatedCode[0].code)
+	}
+
+	wantModels := "#include \"order_status.hpp\"\n" +
+		"#include \"order_item.hpp\"\n" +
+		"#include \"customer.hpp\"\n" +
+		"#include \"order.hpp\"\n"
+	if !strings.HasSuffix(generatedCode[4].code, wantModels) {
+		t.Errorf("generateCode() models.hpp = %v, want suffix %v", generatedCode[4].code, wantModels)
+	}
+
+	// Headers can't include each other
+	_ = customer.addValue("lastOrder", "list<order>", nil)
+	if _, err := newCppLanguageSerializer().generateCode([]middleware{order, orderItem, customer, status}, &serializerInfo{}); err == nil {
+		t.Errorf("generateCode() expected an error for headers that include each other")
+	}
+}
+
+func Test_cppLanguageSerializer_serializeClass(t *testing.T) {
+	parent := newClass("node")
+	_ = parent.addValue("parent", "node", nil)
+
+	type args struct {
+		class          *class
+		includes       []string
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "int", name: "id"},
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "map<int,orderStatus>", name: "statuses"},
+					},
+				},
+				includes:       []string{"orderStatus", "orderItem"},
+				serializerInfo: &serializerInfo{packageName: "acme.models"},
+			},
+			want: &generatedCode{
+				fileName: "order.hpp",
+				code: "#include <cstdint>\n" +
+					"#include <string>\n" +
+					"#include <unordered_map>\n" +
+					"#include <vector>\n\n" +
+					"#include <nlohmann/json.hpp>\n\n" +
+					"#include \"order_status.hpp\"\n" +
+					"#include \"order_item.hpp\"\n\n" +
+					"namespace acme::models {\n\n" +
+					"struct Order {\n" +
+					"    int32_t id{};\n" +
+					"    std::vector<OrderItem> items{};\n" +
+					"    std::unordered_map<int32_t, OrderStatus> statuses{};\n" +
+					"};\n\n" +
+					"inline void to_json(nlohmann::json& j, const Order& value) {\n" +
+					"    j = nlohmann::json::object();\n" +
+					"    j[\"id\"] = value.id;\n" +
+					"    j[\"items\"] = value.items;\n" +
+					"    j[\"statuses\"] = nlohmann::json::object();\n" +
+					"    for (const auto& [key, item] : value.statuses) {\n" +
+					"        j[\"statuses\"][std::to_string(key)] = item;\n" +
+					"    }\n" +
+					"}\n\n" +
+					"inline void from_json(const nlohmann::json& j, Order& value) {\n" +
+					"    j.at(\"id\").get_to(value.id);\n" +
+					"    j.at(\"items\").get_to(value.items);\n" +
+					"    value.statuses.clear();\n" +
+					"    for (const auto& item : j.at(\"statuses\").items()) {\n" +
+					"        value.statuses.emplace(static_cast<int32_t>(std::stoi(item.key())), item.value().get<OrderStatus>());\n" +
+					"    }\n" +
+					"}\n\n" +
+					"}  // namespace acme::models\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "test.hpp",
+				code: "#include <nlohmann/json.hpp>\n\n" +
+					"struct Test {};\n\n" +
+					"inline void to_json(nlohmann::json& j, const Test&) {\n" +
+					"    j = nlohmann::json::object();\n" +
+					"}\n\n" +
+					"inline void from_json(const nlohmann::json&, Test&) {}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides, keywords and extern types",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{memberType: "char", name: "class"},
+						{
+							memberType: "Money",
+							name:       "price",
+							annotations: []*annotation{
+								{namespace: "cpp", name: "name", arguments: []string{"cost"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "cpp", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeCpp: "acme/money.hpp#acme::Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "test.hpp",
+				code: "#include <string>\n\n" +
+					"#include <nlohmann/json.hpp>\n\n" +
+					"#include \"acme/money.hpp\"\n\n" +
+					"struct Renamed {\n" +
+					"    std::string class_{};\n" +
+					"    acme::Money cost{};\n" +
+					"};\n\n" +
+					"inline void to_json(nlohmann::json& j, const Renamed& value) {\n" +
+					"    j = nlohmann::json::object();\n" +
+					"    j[\"class\"] = value.class_;\n" +
+					"    j[\"price\"] = value.cost;\n" +
+					"}\n\n" +
+					"inline void from_json(const nlohmann::json& j, Renamed& value) {\n" +
+					"    j.at(\"class\").get_to(value.class_);\n" +
+					"    j.at(\"price\").get_to(value.cost);\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class contains itself",
+			args: args{
+				class:          parent,
+				serializerInfo: &serializerInfo{classes: map[string]*class{"node": parent}},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Unsupported map key",
+			args: args{
+				class: &class{
+					name:        "test",
+					dataMembers: []*dataMember{{memberType: "map<bool,string>", name: "flags"}},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				includes:       []string{"event", "kind"},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "holder.hpp",
+				code: "#include <nlohmann/json.hpp>\n\n" +
+					"#include \"event.hpp\"\n" +
+					"#include \"kind.hpp\"\n\n" +
+					"namespace bla {\n\n" +
+					"struct Holder {\n" +
+					"    Evt e{};\n" +
+					"    Category kind{};\n" +
+					"};\n\n" +
+					"inline void to_json(nlohmann::json& j, const Holder& value) {\n" +
+					"    j = nlohmann::json::object();\n" +
+					"    j[\"e\"] = value.e;\n" +
+					"    j[\"kind\"] = value.kind;\n" +
+					"}\n\n" +
+					"inline void from_json(const nlohmann::json& j, Holder& value) {\n" +
+					"    j.at(\"e\").get_to(value.e);\n" +
+					"    j.at(\"kind\").get_to(value.kind);\n" +
+					"}\n\n" +
+					"}  // namespace bla\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCppLanguageSerializer()
+			got, err := c.serializeClass(tt.args.class, tt.args.includes, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, c.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cppLanguageSerializer_serializeEnum(t *testing.T) {
+	testEnum := &enum{
+		name: "orderStatus",
+		enumValues: []*enumValue{
+			{name: "active", value: 5},
+			{name: "onHold", value: 8},
+			{name: "paused", value: 8},
+		},
+	}
+
+	want := &generatedCode{
+		fileName: "order_status.hpp",
+		code: "#include <cstdint>\n" +
+			"#include <stdexcept>\n" +
+			"#include <string>\n\n" +
+			"#include <nlohmann/json.hpp>\n\n" +
+			"enum class OrderStatus : int32_t {\n" +
+			"    Active = 5,\n" +
+			"    OnHold = 8,\n" +
+			"    Paused = 8,\n" +
+			"};\n\n" +
+			"inline void to_json(nlohmann::json& j, const OrderStatus& value) {\n" +
+			"    j = static_cast<int32_t>(value);\n" +
+			"}\n\n" +
+			"inline void from_json(const nlohmann::json& j, OrderStatus& value) {\n" +
+			"    const auto raw = j.get<int32_t>();\n" +
+			"    switch (raw) {\n" +
+			"    case 5:\n" +
+			"    case 8:\n" +
+			"        value = static_cast<OrderStatus>(raw);\n" +
+			"        break;\n" +
+			"    default:\n" +
+			"        throw std::invalid_argument(\"unknown OrderStatus value \" + std::to_string(raw));\n" +
+			"    }\n" +
+			"}\n",
+	}
+
+	c := newCppLanguageSerializer()
+	got := c.serializeEnum(testEnum, &serializerInfo{})
+	got.code = strings.Replace(got.code, c.serializeDeclaration(), "", -1)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("serializeEnum() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+Serialize all the channels to a single file with the topic constants, and typed publisher
+and subscriber interfaces for every channel. Channels with a key also get a function which
+extracts the key from the message.
+*/
+func (c *csharpLanguageSerializer) serializeChannels(channels []*channel, serializerInfo *serializerInfo) (*generatedCode, error) {
+	imports := []string{"System", "System.Threading.Tasks"}
+	constantsCode := "\tpublic static class Channels\n\t{\n"
+	channelsCode := ""
+
+	for _, ch := range channels {
+		typeName := topicTypeName(ch.topic)
+		payloadName := c.className(ch.payloadType, serializerInfo)
+
+		constantsCode += fmt.Sprintf("\t\tpublic const string %sTopic = \"%s\";\n", typeName, ch.topic)
+
+		if ch.keyField != "" {
+			propertyName := toFirstCharUpper(ch.keyField)
+			if member := findDataMember(serializerInfo.classes[ch.payloadType], ch.keyField); member != nil {
+				propertyName = c.propertyName(member)
+			}
+
+			constantsCode += fmt.Sprintf("\n\t\tpublic static string %sKey(%s message) => Convert.ToString(message.%s);\n\n",
+				typeName, payloadName, propertyName)
+		}
+
+		parameters := fmt.Sprintf("%s message", payloadName)
+
+		if len(ch.headers) > 0 {
+			parameters += fmt.Sprintf(", %sHeaders headers", typeName)
+			channelsCode += fmt.Sprintf("\n\tpublic class %sHeaders\n\t{\n", typeName)
+
+			for _, header := range ch.headers {
+				headerType := header.headerType
+				if knownType, imp, isKnown := c.mapType(header.headerType, serializerInfo); isKnown {
+					headerType = knownType
+					imports = appendImport(imports, imp)
+				}
+
+				channelsCode += fmt.Sprintf("\t\tpublic %s %s { get; set; }\n", headerType, toFirstCharUpper(header.name))
+			}
+
+			channelsCode += "\t}\n"
+		}
+
+		channelsCode += fmt.Sprintf("\n\tpublic interface I%sPublisher\n\t{\n\t\tTask Publish%sAsync(%s);\n\t}\n",
+			typeName, typeName, parameters)
+		channelsCode += fmt.Sprintf("\n\tpublic interface I%sSubscriber\n\t{\n\t\tTask Handle%sAsync(%s);\n\t}\n",
+			typeName, typeName, parameters)
+	}
+
+	constantsCode = strings.TrimRight(constantsCode, "\n") + "\n\t}\n"
+
+	return newGeneratedCode("channels.cs",
+		c.serializeDeclaration(imports, serializerInfo)+constantsCode+channelsCode+"}"), nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_csharpLanguageSerializer_serializeChannels(t *testing.T) {
+	c := newCsharpLanguageSerializer()
+	channels, info := getTestChannels()
+
+	got, err := c.serializeChannels(channels, info)
+	if err != nil {
+		t.Errorf("serializeChannels() error = %v", err)
+		return
+	}
+
+	if got.fileName != "channels.cs" {
+		t.Errorf("serializeChannels() fileName = %v, want %v", got.fileName, "channels.cs")
+	}
+
+	expectedParts := []string{
+		"namespace models",
+		"public const string OrdersCreatedTopic = \"orders.created\";",
+		"public static string OrdersCreatedKey(OrderCreated message) => Convert.ToString(message.OrderId);",
+		"public string TraceId { get; set; }",
+		"Task PublishOrdersCreatedAsync(OrderCreated message, OrdersCreatedHeaders headers);",
+		"Task HandleOrdersClosedAsync(OrderCreated message);",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeChannels() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+}
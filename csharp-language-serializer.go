@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -36,7 +37,17 @@ func (c *csharpLanguageSerializer) getTypeName() string {
 func (c *csharpLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
 	result := make([]*generatedCode, 0)
 
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
 	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Channels are serialized together in the end
+		if object.getType() == middlewareTypeExtern || object.getType() == middlewareTypeChannel {
+			continue
+		}
+
 		serialized, err := c.serializeMiddleware(object, serializerInfo)
 		if err != nil {
 			return nil, err
@@ -45,6 +56,15 @@ func (c *csharpLanguageSerializer) generateCode(objects []middleware, serializer
 		result = append(result, serialized)
 	}
 
+	if channels := collectChannels(objects); len(channels) > 0 {
+		serialized, err := c.serializeChannels(channels, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
 	return result, nil
 }
 
@@ -57,6 +77,10 @@ func (c *csharpLanguageSerializer) serializeMiddleware(middleware middleware, se
 		return c.serializeEnum(enum, serializerInfo)
 	}
 
+	if service, ok := middleware.(*service); ok {
+		return c.serializeService(service, serializerInfo)
+	}
+
 	return nil, errors.New("tried to serialize unknown middleware type")
 }
 
@@ -83,20 +107,89 @@ func (c *csharpLanguageSerializer) serializeDeclaration(imports []string, serial
 	return using + generatedMark + namespace
 }
 
+/**
+Map a gen file type which is a primitive or an extern type to a C# type.
+Return the namespace the type needs, or an empty string if it doesn't need one.
+*/
+func (c *csharpLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := c.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeCSharp)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "Acme.Money" is used as "Money" with the "Acme" namespace
+	dot := strings.LastIndex(externName, ".")
+	if dot == -1 {
+		return externName, "", true
+	}
+
+	return externName[dot+1:], externName[:dot], true
+}
+
+/**
+Get the C# name of a class or an enum, taking the name annotation into account.
+*/
+func (c *csharpLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeCSharp, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the property name of a data member, taking the name annotation into account.
+*/
+func (c *csharpLanguageSerializer) propertyName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeCSharp, "name"); ok {
+		return name
+	}
+
+	return toFirstCharUpper(member.name)
+}
+
 func (c *csharpLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
 	serializedCode := ""
 	fileName := fmt.Sprintf("%s.cs", toCamelCase(class.name))
 
-	serializedCode += fmt.Sprintf("\tpublic class %s\n\t{\n", toFirstCharUpper(class.name))
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeCSharp, "name"); ok {
+		className = name
+	}
+
+	serializedCode += fmt.Sprintf("\tpublic class %s\n\t{\n", className)
 
 	imports := []string{"Newtonsoft.Json"}
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypeCSharp) {
+		imports = appendUnique(imports, imp)
+	}
 
 	for _, member := range class.dataMembers {
+		propertyName := c.propertyName(member)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeCSharp) {
+			imports = appendUnique(imports, imp)
+		}
+
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeCSharp, "type"); ok {
+			serializedCode += fmt.Sprintf("\t\t[JsonProperty(PropertyName = \"%s\")]\n",
+				toCamelCase(member.name))
+			serializedCode += fmt.Sprintf("\t\tpublic %s %s { get; set; }\n",
+				overrideType, propertyName)
+
+			continue
+		}
+
 		if isList, listType := isList(member.memberType); isList {
-			if tsType, isPrimitive := c.typesMap[listType]; isPrimitive {
-				listType = tsType
+			if knownType, imp, isKnown := c.mapType(listType, serializerInfo); isKnown {
+				listType = knownType
+				imports = appendImport(imports, imp)
 			} else {
-				listType = toFirstCharUpper(listType)
+				listType = c.className(listType, serializerInfo)
 			}
 
 			imports = appendUnique(imports, "System.Collections.Generic")
@@ -104,23 +197,25 @@ func (c *csharpLanguageSerializer) serializeClass(class *class, serializerInfo *
 			serializedCode += fmt.Sprintf("\t\t[JsonProperty(PropertyName = \"%s\")]\n",
 				toCamelCase(member.name))
 			serializedCode += fmt.Sprintf("\t\tpublic List<%s> %s { get; set; }\n",
-				listType, toFirstCharUpper(member.name))
+				listType, propertyName)
 
 			continue
 		}
 
 		if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
-			if keyPrimitiveType, isPrimitive := c.typesMap[mapKeyType]; isPrimitive {
-				mapKeyType = keyPrimitiveType
+			if knownType, imp, isKnown := c.mapType(mapKeyType, serializerInfo); isKnown {
+				mapKeyType = knownType
+				imports = appendImport(imports, imp)
 			} else {
 				// Not suppose to happen, but it's user's problem
-				mapKeyType = toFirstCharUpper(mapKeyType)
+				mapKeyType = c.className(mapKeyType, serializerInfo)
 			}
 
-			if valuePrimitiveType, isPrimitive := c.typesMap[mapValueType]; isPrimitive {
-				mapValueType = valuePrimitiveType
+			if knownType, imp, isKnown := c.mapType(mapValueType, serializerInfo); isKnown {
+				mapValueType = knownType
+				imports = appendImport(imports, imp)
 			} else {
-				mapValueType = toFirstCharUpper(mapValueType)
+				mapValueType = c.className(mapValueType, serializerInfo)
 			}
 
 			imports = appendUnique(imports, "System.Collections.Generic")
@@ -128,16 +223,17 @@ func (c *csharpLanguageSerializer) serializeClass(class *class, serializerInfo *
 			serializedCode += fmt.Sprintf("\t\t[JsonProperty(PropertyName = \"%s\")]\n",
 				toCamelCase(member.name))
 			serializedCode += fmt.Sprintf("\t\tpublic Dictionary<%s, %s> %s { get; set; }\n",
-				mapKeyType, mapValueType, toFirstCharUpper(member.name))
+				mapKeyType, mapValueType, propertyName)
 
 			continue
 		}
 
-		if primitiveType, ok := c.typesMap[member.memberType]; ok {
+		if knownType, imp, isKnown := c.mapType(member.memberType, serializerInfo); isKnown {
+			imports = appendImport(imports, imp)
 			serializedCode += fmt.Sprintf("\t\t[JsonProperty(PropertyName = \"%s\")]\n",
 				toCamelCase(member.name))
 			serializedCode += fmt.Sprintf("\t\tpublic %s %s { get; set; }\n",
-				primitiveType, toFirstCharUpper(member.name))
+				knownType, propertyName)
 
 			continue
 		}
@@ -146,7 +242,7 @@ func (c *csharpLanguageSerializer) serializeClass(class *class, serializerInfo *
 		serializedCode += fmt.Sprintf("\t\t[JsonProperty(PropertyName = \"%s\")]\n",
 			toCamelCase(member.name))
 		serializedCode += fmt.Sprintf("\t\tpublic %s %s { get; set; }\n",
-			toFirstCharUpper(member.memberType), toFirstCharUpper(member.name))
+			c.className(member.memberType, serializerInfo), propertyName)
 	}
 
 	serializedCode += "\t}\n}"
@@ -158,11 +254,20 @@ func (c *csharpLanguageSerializer) serializeEnum(enum *enum, serializerInfo *ser
 	serializedCode := c.serializeDeclaration([]string{}, serializerInfo)
 	fileName := fmt.Sprintf("%s.cs", enum.name)
 
-	serializedCode += fmt.Sprintf("\tpublic enum %s\n\t{\n", toFirstCharUpper(enum.name))
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeCSharp, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode += fmt.Sprintf("\tpublic enum %s\n\t{\n", enumName)
 
 	for _, value := range enum.enumValues {
-		serializedCode += fmt.Sprintf("\t\t%s = %v,\n",
-			toFirstCharUpper(value.name), value.value)
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeCSharp, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("\t\t%s = %v,\n", valueName, value.value)
 	}
 
 	if len(enum.enumValues) > 1 {
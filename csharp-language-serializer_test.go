@@ -94,7 +94,9 @@ func Test_csharpLanguageSerializer_getTypeName(t *testing.T) {
 }
 
 func Test_csharpLanguageSerializer_serializeClass(t *testing.T) {
-	info := &serializerInfo{packageName: "main"}
+	info := getTestRenamedTypesInfo()
+	info.packageName = "main"
+	info.externTypes = getTestExternTypes()
 
 	type args struct {
 		class   *class
@@ -235,6 +237,96 @@ func Test_csharpLanguageSerializer_serializeClass(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+							annotations: []*annotation{
+								{namespace: "go", name: "type", arguments: []string{"time.Time"}},
+								{namespace: "go", name: "import", arguments: []string{"time"}},
+								{namespace: "kotlin", name: "type", arguments: []string{"Instant"}},
+								{namespace: "kotlin", name: "import", arguments: []string{"java.time.Instant"}},
+								{namespace: "ts", name: "type", arguments: []string{"string"}},
+								{namespace: "csharp", name: "type", arguments: []string{"DateTimeOffset"}},
+								{namespace: "csharp", name: "import", arguments: []string{"System"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "go", name: "name", arguments: []string{"ID"}},
+								{namespace: "csharp", name: "name", arguments: []string{"ID"}},
+							},
+						},
+					},
+				},
+				imports: []string{"Newtonsoft.Json", "System"},
+			},
+			want: &generatedCode{
+				fileName: "test.cs",
+				code: "\tpublic class Test\n\t{\n\t\t[JsonProperty(PropertyName = \"createdAt\")]\n" +
+					"\t\tpublic DateTimeOffset CreatedAt { get; set; }\n" +
+					"\t\t[JsonProperty(PropertyName = \"id\")]\n" +
+					"\t\tpublic string ID { get; set; }\n\t}\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+						{
+							memberType: "list<Money>",
+							name:       "history",
+						},
+					},
+				},
+				imports: []string{"Newtonsoft.Json", "Acme", "System.Collections.Generic"},
+			},
+			want: &generatedCode{
+				fileName: "test.cs",
+				code: "\tpublic class Test\n\t{\n\t\t[JsonProperty(PropertyName = \"price\")]\n" +
+					"\t\tpublic Money Price { get; set; }\n" +
+					"\t\t[JsonProperty(PropertyName = \"history\")]\n" +
+					"\t\tpublic List<Money> History { get; set; }\n\t}\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "list<event>", name: "es"},
+						{memberType: "map<kind,event>", name: "byKind"},
+					},
+				},
+				imports: []string{"Newtonsoft.Json", "System.Collections.Generic"},
+			},
+			want: &generatedCode{
+				fileName: "holder.cs",
+				code: "\tpublic class Holder\n\t{\n\t\t[JsonProperty(PropertyName = \"e\")]\n\t\tpublic Evt E { get; set; }\n" +
+					"\t\t[JsonProperty(PropertyName = \"es\")]\n" +
+					"\t\tpublic List<Evt> Es { get; set; }\n" +
+					"\t\t[JsonProperty(PropertyName = \"byKind\")]\n" +
+					"\t\tpublic Dictionary<Category, Evt> ByKind { get; set; }\n\t}\n}",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Class with struct",
 			args: args{
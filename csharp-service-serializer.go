@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+Serialize a service to a C# interface, a typed exception and a client wrapping HttpClient.
+The request and response types are the generated classes, serialized with Newtonsoft.JSON.
+*/
+func (c *csharpLanguageSerializer) serializeService(service *service, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.cs", toCamelCase(service.name))
+	serviceName := toFirstCharUpper(service.name)
+
+	imports := []string{"Newtonsoft.Json", "System", "System.Globalization", "System.Net.Http", "System.Text",
+		"System.Threading.Tasks"}
+
+	interfaceCode := fmt.Sprintf("\tpublic interface I%s\n\t{\n", serviceName)
+	clientCode := ""
+
+	for _, method := range service.methods {
+		signature := c.serializeMethodSignature(method, serializerInfo)
+		interfaceCode += fmt.Sprintf("\t\t%s;\n", signature)
+
+		body := "null"
+		if hasRequestBody(method) {
+			body = "request"
+		}
+
+		clientCode += fmt.Sprintf("\t\tpublic %s\n\t\t{\n\t\t\treturn SendAsync<%s>(\"%s\", %s, %s);\n\t\t}\n\n",
+			signature, c.className(method.responseType, serializerInfo), method.httpMethod,
+			c.serializePath(method, serializerInfo), body)
+	}
+
+	interfaceCode += "\t}\n\n"
+
+	serializedCode := c.serializeDeclaration(imports, serializerInfo) + interfaceCode +
+		fmt.Sprintf(csharpServiceExceptionTemplate, serviceName) +
+		fmt.Sprintf(csharpServiceClientTemplate, serviceName, clientCode)
+
+	return newGeneratedCode(fileName, serializedCode), nil
+}
+
+/**
+Serialize the method signature, like: Task<GetUserResponse> GetUserAsync(GetUserRequest request)
+*/
+func (c *csharpLanguageSerializer) serializeMethodSignature(method *serviceMethod, serializerInfo *serializerInfo) string {
+	parameters := ""
+	if method.requestType != "" {
+		parameters = fmt.Sprintf("%s request", c.className(method.requestType, serializerInfo))
+	}
+
+	return fmt.Sprintf("Task<%s> %sAsync(%s)", c.className(method.responseType, serializerInfo),
+		toFirstCharUpper(method.name), parameters)
+}
+
+/**
+Serialize the method path to an interpolated string. Path parameters are taken from the request,
+and the query parameters are written with the invariant culture, so numbers always use a dot.
+*/
+func (c *csharpLanguageSerializer) serializePath(method *serviceMethod, serializerInfo *serializerInfo) string {
+	result := "$\""
+
+	for _, part := range splitPath(method.path) {
+		if !part.isParameter {
+			result += strings.Replace(part.value, "\"", "\\\"", -1)
+			continue
+		}
+
+		propertyName := toFirstCharUpper(part.value)
+		if member := findDataMember(serializerInfo.classes[method.requestType], part.value); member != nil {
+			propertyName = c.propertyName(member)
+		}
+
+		result += fmt.Sprintf("{Uri.EscapeDataString(Convert.ToString(request.%s))}", propertyName)
+	}
+
+	for i, member := range queryMembers(method, serializerInfo.classes) {
+		separator := "&"
+		if i == 0 {
+			separator = "?"
+		}
+
+		result += fmt.Sprintf("%s%s={Uri.EscapeDataString(Convert.ToString(request.%s, CultureInfo.InvariantCulture))}",
+			separator, toCamelCase(member.name), c.propertyName(member))
+	}
+
+	return result + "\""
+}
+
+const csharpServiceExceptionTemplate = `	public class %[1]sException : Exception
+	{
+		public int StatusCode { get; }
+
+		public %[1]sException(int statusCode, string message) : base(message)
+		{
+			StatusCode = statusCode;
+		}
+	}
+
+`
+
+const csharpServiceClientTemplate = `	public class %[1]sClient : I%[1]s
+	{
+		private readonly HttpClient httpClient;
+
+		public %[1]sClient(HttpClient httpClient)
+		{
+			this.httpClient = httpClient;
+		}
+
+%[2]s		private async Task<T> SendAsync<T>(string method, string path, object body)
+		{
+			using (var message = new HttpRequestMessage(new HttpMethod(method), path))
+			{
+				if (body != null)
+				{
+					message.Content = new StringContent(JsonConvert.SerializeObject(body), Encoding.UTF8, "application/json");
+				}
+
+				using (var response = await httpClient.SendAsync(message))
+				{
+					var content = await response.Content.ReadAsStringAsync();
+					if (!response.IsSuccessStatusCode)
+					{
+						throw new %[1]sException((int)response.StatusCode, content);
+					}
+
+					return JsonConvert.DeserializeObject<T>(content);
+				}
+			}
+		}
+	}
+}`
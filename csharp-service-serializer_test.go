@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_csharpLanguageSerializer_serializeService(t *testing.T) {
+	g := newCsharpLanguageSerializer()
+	userService, info := getTestService()
+
+	got, err := g.serializeService(userService, info)
+	if err != nil {
+		t.Errorf("serializeService() error = %v", err)
+		return
+	}
+
+	if got.fileName != "userService.cs" {
+		t.Errorf("serializeService() fileName = %v, want %v", got.fileName, "userService.cs")
+	}
+
+	expectedParts := []string{
+		"using System.Globalization;\nusing System.Net.Http;\n",
+		"namespace models\n{\n",
+		"\tpublic interface IUserService\n\t{\n" +
+			"\t\tTask<User> GetUserAsync(GetUserRequest request);\n" +
+			"\t\tTask<User> CreateUserAsync(CreateUserRequest request);\n" +
+			"\t\tTask<User> PingAsync();\n\t}",
+		"\tpublic class UserServiceException : Exception\n",
+		"\tpublic class UserServiceClient : IUserService\n",
+		"return SendAsync<User>(\"GET\", $\"/users/{Uri.EscapeDataString(Convert.ToString(request.Id))}" +
+			"?verbose={Uri.EscapeDataString(Convert.ToString(request.Verbose, CultureInfo.InvariantCulture))}\", null);",
+		"return SendAsync<User>(\"POST\", $\"/users\", request);",
+		"\t\tprivate async Task<T> SendAsync<T>(string method, string path, object body)\n",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeService() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+}
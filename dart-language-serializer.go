@@ -0,0 +1,299 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var dartKeywords = []string{
+	"assert", "break", "case", "catch", "class", "const", "continue", "default", "do", "else", "enum",
+	"extends", "false", "final", "finally", "for", "if", "in", "is", "new", "null", "rethrow", "return",
+	"super", "switch", "this", "throw", "true", "try", "var", "void", "while", "with",
+}
+
+/**
+Generate json_serializable classes and enums for Dart and Flutter.
+Every class and enum is generated into its own library, and the classes need build_runner to generate
+their .g.dart parts. Dart libraries import each other by relative paths, so the package name is ignored.
+*/
+type dartLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newDartLanguageSerializer() *dartLanguageSerializer {
+	result := &dartLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "int"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "double"
+	result.typesMap["float"] = "double"
+	result.typesMap["char"] = "String"
+	result.typesMap["byte"] = "int"
+	result.typesMap["date"] = "DateTime"
+
+	return result
+}
+
+func (d *dartLanguageSerializer) getType() languageType {
+	return LanguageTypeDart
+}
+
+func (d *dartLanguageSerializer) getTypeName() string {
+	return "dart"
+}
+
+func (d *dartLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Dart
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := d.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result, nil
+}
+
+func (d *dartLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return d.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return d.serializeEnum(enum)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (d *dartLanguageSerializer) serializeDeclaration() string {
+	return "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+}
+
+/**
+Serialize the imports, with the package imports before the relative ones like the Dart style guide says.
+*/
+func (d *dartLanguageSerializer) serializeImports(imports []string) string {
+	packages := make([]string, 0)
+	relatives := make([]string, 0)
+
+	for _, imp := range imports {
+		if strings.Contains(imp, ":") {
+			packages = append(packages, imp)
+		} else {
+			relatives = append(relatives, imp)
+		}
+	}
+
+	sort.Strings(packages)
+	sort.Strings(relatives)
+
+	result := ""
+	for _, group := range [][]string{packages, relatives} {
+		if len(group) == 0 {
+			continue
+		}
+
+		for _, imp := range group {
+			result += fmt.Sprintf("import '%s';\n", imp)
+		}
+
+		result += "\n"
+	}
+
+	return result
+}
+
+/**
+Get the library file of a class or an enum, like "order_item.dart".
+*/
+func (d *dartLanguageSerializer) fileName(typeName string) string {
+	return fmt.Sprintf("%s.dart", toSnakeCase(typeName))
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Dart type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (d *dartLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := d.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeDart)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "package:money/money.dart#Money" is imported from "package:money/money.dart" and used as "Money"
+	if library, symbol, ok := strings.Cut(externName, "#"); ok {
+		return symbol, library, true
+	}
+
+	return externName, "", true
+}
+
+/**
+Serialize the Dart type of a gen file type, with List<T> for lists and Map<K, V> for maps.
+Return the imports the type needs.
+*/
+func (d *dartLanguageSerializer) memberType(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, imports := d.memberType(listType, serializerInfo)
+
+		return fmt.Sprintf("List<%s>", itemType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, imports := d.memberType(mapKeyType, serializerInfo)
+		valueType, valueImports := d.memberType(mapValueType, serializerInfo)
+
+		for _, imp := range valueImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		return fmt.Sprintf("Map<%s, %s>", keyType, valueType), imports
+	}
+
+	if knownType, imp, isKnown := d.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, imp)
+	}
+
+	return d.className(typeName, serializerInfo), []string{d.fileName(typeName)}
+}
+
+/**
+Get the Dart class name of a class or an enum, taking the name annotation into account.
+*/
+func (d *dartLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeDart, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Dart keywords get a _ suffix, and the JSON name stays the same.
+*/
+func (d *dartLanguageSerializer) memberName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeDart, "name"); ok {
+		return name
+	}
+
+	name := toCamelCase(member.name)
+	for _, keyword := range dartKeywords {
+		if name == keyword {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+func (d *dartLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := d.fileName(class.name)
+
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeDart, "name"); ok {
+		className = name
+	}
+
+	imports := []string{"package:json_annotation/json_annotation.dart"}
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypeDart) {
+		imports = appendUnique(imports, imp)
+	}
+
+	fields := ""
+	parameters := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		memberName := d.memberName(member)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeDart) {
+			imports = appendUnique(imports, imp)
+		}
+
+		memberType, memberImports := d.memberType(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeDart, "type"); ok {
+			memberType, memberImports = overrideType, []string{}
+		}
+
+		for _, imp := range memberImports {
+			// A library doesn't import itself
+			if imp != fileName {
+				imports = appendUnique(imports, imp)
+			}
+		}
+
+		fields += fmt.Sprintf("  @JsonKey(name: '%s')\n  final %s %s;\n\n", toCamelCase(member.name), memberType, memberName)
+		parameters = append(parameters, "required this."+memberName)
+	}
+
+	serializedCode := fmt.Sprintf("part '%s.g.dart';\n\n", toSnakeCase(class.name))
+	serializedCode += fmt.Sprintf("@JsonSerializable()\nclass %s {\n", className)
+	serializedCode += fields
+
+	if len(parameters) > 0 {
+		// A trailing comma keeps the parameters in their own lines when formatting
+		serializedCode += fmt.Sprintf("  %s({\n    %s,\n  });\n\n", className, strings.Join(parameters, ",\n    "))
+	} else {
+		serializedCode += fmt.Sprintf("  %s();\n\n", className)
+	}
+
+	serializedCode += fmt.Sprintf("  factory %s.fromJson(Map<String, dynamic> json) => _$%sFromJson(json);\n\n",
+		className, className)
+	serializedCode += fmt.Sprintf("  Map<String, dynamic> toJson() => _$%sToJson(this);\n}\n", className)
+
+	return newGeneratedCode(fileName, d.serializeDeclaration()+d.serializeImports(imports)+serializedCode), nil
+}
+
+func (d *dartLanguageSerializer) serializeEnum(enum *enum) (*generatedCode, error) {
+	if len(enum.enumValues) == 0 {
+		return nil, errors.New(fmt.Sprintf("Dart enums must have values, but enum %s is empty", enum.name))
+	}
+
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeDart, "name"); ok {
+		enumName = name
+	}
+
+	values := make([]string, 0)
+	for _, value := range enum.enumValues {
+		valueName := toCamelCase(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeDart, "name"); ok {
+			valueName = name
+		}
+
+		values = append(values, fmt.Sprintf("  %s(%v)", valueName, value.value))
+	}
+
+	// json_serializable writes and reads the enums by their value field
+	serializedCode := fmt.Sprintf("@JsonEnum(valueField: 'value')\nenum %s {\n", enumName)
+	serializedCode += strings.Join(values, ",\n") + ";\n\n"
+	serializedCode += fmt.Sprintf("  const %s(this.value);\n\n  final int value;\n}\n", enumName)
+
+	return newGeneratedCode(d.fileName(enum.name), d.serializeDeclaration()+
+		d.serializeImports([]string{"package:json_annotation/json_annotation.dart"})+serializedCode), nil
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_dartLanguageSerializer_generateCode(t *testing.T) {
+	d := newDartLanguageSerializer()
+
+	testClass := &class{
+		name: "orderItem",
+		dataMembers: []*dataMember{
+			{
+				memberType: "int",
+				name:       "a",
+			},
+		},
+	}
+
+	testEnum := &enum{
+		name: "orderStatus",
+		enumValues: []*enumValue{
+			{
+				name:  "a",
+				value: 5,
+			},
+		},
+	}
+
+	generatedCode, err := d.generateCode([]middleware{testClass, testEnum}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	if len(generatedCode) != 2 {
+		t.Errorf("generateCode() generated %v files. expected 2", len(generatedCode))
+		return
+	}
+
+	if generatedCode[0].fileName != "order_item.dart" || generatedCode[1].fileName != "order_status.dart" {
+		t.Errorf("generateCode() file names = %v, %v", generatedCode[0].fileName, generatedCode[1].fileName)
+	}
+}
+
+func Test_dartLanguageSerializer_getType(t *testing.T) {
+	if got := newDartLanguageSerializer().getType(); got != LanguageTypeDart {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeDart)
+	}
+}
+
+func Test_dartLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newDartLanguageSerializer().getTypeName(); got != "dart" {
+		t.Errorf("getTypeName() = %v, want %v", got, "dart")
+	}
+}
+
+func Test_dartLanguageSerializer_serializeClass(t *testing.T) {
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "orderItem",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+						},
+						{
+							memberType: "list<orderItem>",
+							name:       "children",
+						},
+						{
+							memberType: "map<string,orderStatus>",
+							name:       "statuses",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "order_item.dart",
+				code: "import 'package:json_annotation/json_annotation.dart';\n\n" +
+					"import 'order_status.dart';\n\n" +
+					"part 'order_item.g.dart';\n\n" +
+					"@JsonSerializable()\n" +
+					"class OrderItem {\n" +
+					"  @JsonKey(name: 'createdAt')\n  final DateTime createdAt;\n\n" +
+					"  @JsonKey(name: 'children')\n  final List<OrderItem> children;\n\n" +
+					"  @JsonKey(name: 'statuses')\n  final Map<String, OrderStatus> statuses;\n\n" +
+					"  OrderItem({\n" +
+					"    required this.createdAt,\n" +
+					"    required this.children,\n" +
+					"    required this.statuses,\n" +
+					"  });\n\n" +
+					"  factory OrderItem.fromJson(Map<String, dynamic> json) => _$OrderItemFromJson(json);\n\n" +
+					"  Map<String, dynamic> toJson() => _$OrderItemToJson(this);\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "test.dart",
+				code: "import 'package:json_annotation/json_annotation.dart';\n\n" +
+					"part 'test.g.dart';\n\n" +
+					"@JsonSerializable()\n" +
+					"class Test {\n" +
+					"  Test();\n\n" +
+					"  factory Test.fromJson(Map<String, dynamic> json) => _$TestFromJson(json);\n\n" +
+					"  Map<String, dynamic> toJson() => _$TestToJson(this);\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides, keywords and extern types",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "class",
+						},
+						{
+							memberType: "Money",
+							name:       "price",
+							annotations: []*annotation{
+								{namespace: "dart", name: "name", arguments: []string{"cost"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "dart", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeDart: "package:money/money.dart#Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "test.dart",
+				code: "import 'package:json_annotation/json_annotation.dart';\n" +
+					"import 'package:money/money.dart';\n\n" +
+					"part 'test.g.dart';\n\n" +
+					"@JsonSerializable()\n" +
+					"class Renamed {\n" +
+					"  @JsonKey(name: 'class')\n  final String class_;\n\n" +
+					"  @JsonKey(name: 'price')\n  final Money cost;\n\n" +
+					"  Renamed({\n" +
+					"    required this.class_,\n" +
+					"    required this.cost,\n" +
+					"  });\n\n" +
+					"  factory Renamed.fromJson(Map<String, dynamic> json) => _$RenamedFromJson(json);\n\n" +
+					"  Map<String, dynamic> toJson() => _$RenamedToJson(this);\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "holder.dart",
+				code: "import 'package:json_annotation/json_annotation.dart';\n\n" +
+					"import 'event.dart';\n" +
+					"import 'kind.dart';\n\n" +
+					"part 'holder.g.dart';\n\n" +
+					"@JsonSerializable()\n" +
+					"class Holder {\n" +
+					"  @JsonKey(name: 'e')\n  final Evt e;\n\n" +
+					"  @JsonKey(name: 'kind')\n  final Category kind;\n\n" +
+					"  Holder({\n" +
+					"    required this.e,\n" +
+					"    required this.kind,\n" +
+					"  });\n\n" +
+					"  factory Holder.fromJson(Map<String, dynamic> json) => _$HolderFromJson(json);\n\n" +
+					"  Map<String, dynamic> toJson() => _$HolderToJson(this);\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDartLanguageSerializer()
+			got, err := d.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, d.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dartLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{name: "active", value: 5},
+						{name: "onHold", value: 8},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order_status.dart",
+				code: "import 'package:json_annotation/json_annotation.dart';\n\n" +
+					"@JsonEnum(valueField: 'value')\n" +
+					"enum OrderStatus {\n" +
+					"  active(5),\n" +
+					"  onHold(8);\n\n" +
+					"  const OrderStatus(this.value);\n\n" +
+					"  final int value;\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Empty enum",
+			args:    args{enum: &enum{name: "test", enumValues: []*enumValue{}}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDartLanguageSerializer()
+			got, err := d.serializeEnum(tt.args.enum)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, d.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var plainYAMLRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_./$\-]*$`)
+var yamlKeywords = []string{"true", "false", "null", "yes", "no", "on", "off", "y", "n", "~"}
+
+/**
+Represent a JSON / YAML object which keeps its keys in insertion order,
+so generated documents stay the same between runs.
+The values can be strings, numbers, bools, []interface{} or other ordered maps.
+*/
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{
+		keys:   make([]string, 0),
+		values: make(map[string]interface{}),
+	}
+}
+
+/**
+Set a value, keeping the original position if the key already exists.
+Return the map itself, so few values can be set in a row.
+*/
+func (m *orderedMap) set(key string, value interface{}) *orderedMap {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+
+	m.values[key] = value
+
+	return m
+}
+
+func (m *orderedMap) get(key string) (interface{}, bool) {
+	value, ok := m.values[key]
+
+	return value, ok
+}
+
+/**
+Serialize a document value to JSON, indented with two spaces.
+*/
+func serializeJSON(value interface{}) string {
+	return writeJSON(value, "")
+}
+
+func writeJSON(value interface{}, indent string) string {
+	innerIndent := indent + "  "
+
+	switch v := value.(type) {
+	case *orderedMap:
+		if len(v.keys) == 0 {
+			return "{}"
+		}
+
+		result := "{\n"
+		for i, key := range v.keys {
+			result += fmt.Sprintf("%s%s: %s", innerIndent, jsonScalar(key), writeJSON(v.values[key], innerIndent))
+			if i < len(v.keys)-1 {
+				result += ","
+			}
+
+			result += "\n"
+		}
+
+		return result + indent + "}"
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]"
+		}
+
+		result := "[\n"
+		for i, item := range v {
+			result += innerIndent + writeJSON(item, innerIndent)
+			if i < len(v)-1 {
+				result += ","
+			}
+
+			result += "\n"
+		}
+
+		return result + indent + "]"
+	}
+
+	return jsonScalar(value)
+}
+
+/**
+Parse a JSON document into ordered maps, so it can be written back with its keys in the same order.
+Numbers are kept as json.Number, so they are written back exactly as they were.
+*/
+func parseJSON(content []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	value, err := readJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("expected a single JSON value")
+	}
+
+	return value, nil
+}
+
+func readJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, isDelim := token.(json.Delim)
+	if !isDelim {
+		return token, nil
+	}
+
+	if delim == '{' {
+		result := newOrderedMap()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := readJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			result.set(key.(string), value)
+		}
+
+		// Skip the closing }
+		_, err := decoder.Token()
+
+		return result, err
+	}
+
+	result := make([]interface{}, 0)
+	for decoder.More() {
+		value, err := readJSON(decoder)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	// Skip the closing ]
+	_, err = decoder.Token()
+
+	return result, err
+}
+
+func jsonScalar(value interface{}) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "null"
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+/**
+Serialize a document value to YAML, indented with two spaces.
+*/
+func serializeYAML(value interface{}) string {
+	switch v := value.(type) {
+	case *orderedMap:
+		if len(v.keys) == 0 {
+			return "{}\n"
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]\n"
+		}
+	default:
+		return yamlScalar(value) + "\n"
+	}
+
+	return writeYAML(value, "")
+}
+
+func writeYAML(value interface{}, indent string) string {
+	result := ""
+
+	switch v := value.(type) {
+	case *orderedMap:
+		for _, key := range v.keys {
+			result += indent + yamlScalar(key) + ":" + writeYAMLValue(v.values[key], indent+"  ")
+		}
+	case []interface{}:
+		for _, item := range v {
+			if isYAMLCollection(item) {
+				// The first line of the nested collection starts after the "- "
+				nested := writeYAML(item, indent+"  ")
+				result += indent + "- " + strings.TrimPrefix(nested, indent+"  ")
+				continue
+			}
+
+			result += indent + "- " + yamlScalar(item) + "\n"
+		}
+	}
+
+	return result
+}
+
+func writeYAMLValue(value interface{}, indent string) string {
+	if !isYAMLCollection(value) {
+		return " " + yamlScalar(value) + "\n"
+	}
+
+	return "\n" + writeYAML(value, indent)
+}
+
+/**
+Check if the value is a non-empty map or list, which is written in few lines.
+*/
+func isYAMLCollection(value interface{}) bool {
+	switch v := value.(type) {
+	case *orderedMap:
+		return len(v.keys) > 0
+	case []interface{}:
+		return len(v) > 0
+	}
+
+	return false
+}
+
+func yamlScalar(value interface{}) string {
+	switch v := value.(type) {
+	case *orderedMap:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	case string:
+		if plainYAMLRegex.MatchString(v) && !isYAMLKeyword(v) {
+			return v
+		}
+
+		return strconv.Quote(v)
+	}
+
+	return jsonScalar(value)
+}
+
+func isYAMLKeyword(value string) bool {
+	for _, keyword := range yamlKeywords {
+		if strings.EqualFold(keyword, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package main
+
+import "testing"
+
+func getTestDocument() *orderedMap {
+	return newOrderedMap().
+		set("name", "orders").
+		set("version", "1.0.0").
+		set("count", 2).
+		set("enabled", true).
+		set("tags", []interface{}{"a", "yes"}).
+		set("empty", newOrderedMap()).
+		set("items", []interface{}{
+			newOrderedMap().set("$ref", "#/a").set("nullable", false),
+		})
+}
+
+func Test_orderedMap_set(t *testing.T) {
+	m := newOrderedMap().set("b", 1).set("a", 2).set("b", 3)
+
+	if len(m.keys) != 2 || m.keys[0] != "b" || m.keys[1] != "a" {
+		t.Errorf("set() keys = %v, want [b a]", m.keys)
+	}
+
+	if value, ok := m.get("b"); !ok || value != 3 {
+		t.Errorf("get() = %v, %v, want %v, %v", value, ok, 3, true)
+	}
+}
+
+func Test_serializeJSON(t *testing.T) {
+	want := "{\n" +
+		"  \"name\": \"orders\",\n" +
+		"  \"version\": \"1.0.0\",\n" +
+		"  \"count\": 2,\n" +
+		"  \"enabled\": true,\n" +
+		"  \"tags\": [\n    \"a\",\n    \"yes\"\n  ],\n" +
+		"  \"empty\": {},\n" +
+		"  \"items\": [\n    {\n      \"$ref\": \"#/a\",\n      \"nullable\": false\n    }\n  ]\n" +
+		"}"
+
+	if got := serializeJSON(getTestDocument()); got != want {
+		t.Errorf("serializeJSON() = %v, want %v", got, want)
+	}
+}
+
+func Test_serializeYAML(t *testing.T) {
+	type args struct {
+		value interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Document",
+			args: args{value: getTestDocument()},
+			want: "name: orders\n" +
+				"version: \"1.0.0\"\n" +
+				"count: 2\n" +
+				"enabled: true\n" +
+				"tags:\n  - a\n  - \"yes\"\n" +
+				"empty: {}\n" +
+				"items:\n  - $ref: \"#/a\"\n    nullable: false\n",
+		},
+		{name: "Empty map", args: args{value: newOrderedMap()}, want: "{}\n"},
+		{name: "Scalar", args: args{value: "text with spaces"}, want: "\"text with spaces\"\n"},
+		{
+			name: "Nested lists",
+			args: args{value: []interface{}{[]interface{}{1, 2}}},
+			want: "- - 1\n  - 2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serializeYAML(tt.args.value); got != tt.want {
+				t.Errorf("serializeYAML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseJSON(t *testing.T) {
+	content := serializeJSON(getTestDocument())
+
+	parsed, err := parseJSON([]byte(content))
+	if err != nil {
+		t.Errorf("parseJSON() error = %v", err)
+		return
+	}
+
+	// Parsing and serializing again should keep the order of the keys and the numbers
+	if got := serializeJSON(parsed); got != content {
+		t.Errorf("parseJSON() = %v, want %v", got, content)
+	}
+
+	if _, err := parseJSON([]byte("{\"a\": 1} {}")); err == nil {
+		t.Errorf("parseJSON() expected an error for few values")
+	}
+
+	if _, err := parseJSON([]byte("{\"a\": ")); err == nil {
+		t.Errorf("parseJSON() expected an error for a broken document")
+	}
+}
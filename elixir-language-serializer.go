@@ -0,0 +1,516 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var elixirReservedNames = []string{
+	"after", "and", "catch", "do", "else", "end", "false", "fn", "in", "nil", "not", "or", "rescue",
+	"true", "when",
+}
+
+/**
+Generate Elixir struct modules with type specs, Jason encoders and new/1, and atom enums
+with integer conversion functions. Every class and enum is generated into its own file, like "order_item.ex".
+*/
+type elixirLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newElixirLanguageSerializer() *elixirLanguageSerializer {
+	result := &elixirLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "boolean()"
+	result.typesMap["int"] = "integer()"
+	result.typesMap["string"] = "String.t()"
+	result.typesMap["double"] = "float()"
+	result.typesMap["float"] = "float()"
+	result.typesMap["char"] = "String.t()"
+	result.typesMap["byte"] = "byte()"
+	result.typesMap["date"] = "DateTime.t()"
+
+	return result
+}
+
+func (e *elixirLanguageSerializer) getType() languageType {
+	return LanguageTypeElixir
+}
+
+func (e *elixirLanguageSerializer) getTypeName() string {
+	return "elixir"
+}
+
+func (e *elixirLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Elixir
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := e.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result, nil
+}
+
+func (e *elixirLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return e.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return e.serializeEnum(enum, serializerInfo)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (e *elixirLanguageSerializer) serializeDeclaration() string {
+	return "# **********************************\n" +
+		"#\tGenerated by ModelsGenerator\n#\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n# **********************************\n\n"
+}
+
+/**
+Get the prefix of the generated modules, like "Shop.Models" for the package "shop.models".
+*/
+func (e *elixirLanguageSerializer) modulePrefix(serializerInfo *serializerInfo) string {
+	if serializerInfo.packageName == "" {
+		return ""
+	}
+
+	parts := make([]string, 0)
+	for _, part := range strings.Split(serializerInfo.packageName, ".") {
+		parts = append(parts, toFirstCharUpper(snakeToCamelCase(part)))
+	}
+
+	return strings.Join(parts, ".")
+}
+
+/**
+Serialize the aliases of the generated modules a module uses, like "alias Shop.Models.{OrderItem, OrderStatus}".
+*/
+func (e *elixirLanguageSerializer) serializeAliases(modules []string, imports []string, serializerInfo *serializerInfo) string {
+	result := ""
+
+	sort.Strings(imports)
+	for _, imp := range imports {
+		result += fmt.Sprintf("  alias %s\n", imp)
+	}
+
+	prefix := e.modulePrefix(serializerInfo)
+	if prefix != "" && len(modules) > 0 {
+		sort.Strings(modules)
+
+		if len(modules) == 1 {
+			result += fmt.Sprintf("  alias %s.%s\n", prefix, modules[0])
+		} else {
+			result += fmt.Sprintf("  alias %s.{%s}\n", prefix, strings.Join(modules, ", "))
+		}
+	}
+
+	if result == "" {
+		return ""
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to an Elixir type spec.
+Extern types are modules, like "Decimal", and are used by their t() type.
+*/
+func (e *elixirLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, bool) {
+	if primitiveType, isPrimitive := e.typesMap[typeName]; isPrimitive {
+		return primitiveType, true
+	}
+
+	externName, isExtern := e.externModule(typeName, serializerInfo)
+	if !isExtern {
+		return "", false
+	}
+
+	return externName + ".t()", true
+}
+
+/**
+Get the module of an extern type. Extern types without an Elixir name are referenced by their gen name.
+*/
+func (e *elixirLanguageSerializer) externModule(typeName string, serializerInfo *serializerInfo) (string, bool) {
+	if _, isExtern := serializerInfo.externTypes[typeName]; !isExtern {
+		return "", false
+	}
+
+	if externName, ok := findExternType(serializerInfo, typeName, LanguageTypeElixir); ok {
+		return externName, true
+	}
+
+	return toFirstCharUpper(typeName), true
+}
+
+/**
+Get the module name of a class or enum, without the prefix of the package, taking the name annotation into account.
+*/
+func (e *elixirLanguageSerializer) moduleName(typeName string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(typeName, LanguageTypeElixir, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(typeName)
+}
+
+/**
+Serialize the type spec of a gen file type, with [T] for lists and %{K => V} for maps.
+The class of the module, selfType, is referenced as __MODULE__, since its module isn't aliased.
+Return the generated modules the type uses.
+*/
+func (e *elixirLanguageSerializer) typeSpec(typeName string, selfType string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, modules := e.typeSpec(listType, selfType, serializerInfo)
+
+		return fmt.Sprintf("[%s]", itemType), modules
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, modules := e.typeSpec(mapKeyType, selfType, serializerInfo)
+		valueType, valueModules := e.typeSpec(mapValueType, selfType, serializerInfo)
+
+		for _, module := range valueModules {
+			modules = appendUnique(modules, module)
+		}
+
+		return fmt.Sprintf("%%{%s => %s}", keyType, valueType), modules
+	}
+
+	if knownType, isKnown := e.mapType(typeName, serializerInfo); isKnown {
+		return knownType, []string{}
+	}
+
+	if typeName == selfType {
+		return "__MODULE__.t()", []string{}
+	}
+
+	module := e.moduleName(typeName, serializerInfo)
+
+	return module + ".t()", []string{module}
+}
+
+/**
+Check if a gen file type is an enum, which is the only kind of type that isn't a primitive, a class or an extern type.
+*/
+func (e *elixirLanguageSerializer) isEnum(typeName string, serializerInfo *serializerInfo) bool {
+	if _, isPrimitive := e.typesMap[typeName]; isPrimitive {
+		return false
+	}
+
+	if _, isClass := serializerInfo.classes[typeName]; isClass {
+		return false
+	}
+
+	_, isExtern := serializerInfo.externTypes[typeName]
+
+	return !isExtern
+}
+
+/**
+Serialize the expression that converts a decoded JSON value of a gen file type to its Elixir value.
+Classes and extern types are created with new/1, enums with from_integer/1, and dates are parsed.
+The class of the module, selfType, is created with __MODULE__.new/1.
+*/
+func (e *elixirLanguageSerializer) fromJSONValue(typeName string, selfType string, value string, serializerInfo *serializerInfo) string {
+	if isList, listType := isList(typeName); isList {
+		itemValue := e.fromJSONValue(listType, selfType, "item", serializerInfo)
+		if itemValue == "item" {
+			return value
+		}
+
+		return fmt.Sprintf("Enum.map(%s, fn item -> %s end)", value, itemValue)
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		// JSON object keys are strings, so number keys are parsed
+		keyValue := "key"
+		if mapKeyType == "int" || mapKeyType == "byte" {
+			keyValue = "String.to_integer(key)"
+		}
+
+		itemValue := e.fromJSONValue(mapValueType, selfType, "item", serializerInfo)
+		if keyValue == "key" && itemValue == "item" {
+			return value
+		}
+
+		return fmt.Sprintf("Map.new(%s, fn {key, item} -> {%s, %s} end)", value, keyValue, itemValue)
+	}
+
+	if typeName == "date" {
+		return fmt.Sprintf("parse_date(%s)", value)
+	}
+
+	if _, isPrimitive := e.typesMap[typeName]; isPrimitive {
+		return value
+	}
+
+	if externName, isExtern := e.externModule(typeName, serializerInfo); isExtern {
+		return fmt.Sprintf("%s.new(%s)", externName, value)
+	}
+
+	if e.isEnum(typeName, serializerInfo) {
+		return fmt.Sprintf("%s.from_integer(%s)", e.moduleName(typeName, serializerInfo), value)
+	}
+
+	if typeName == selfType {
+		return fmt.Sprintf("__MODULE__.new(%s)", value)
+	}
+
+	return fmt.Sprintf("%s.new(%s)", e.moduleName(typeName, serializerInfo), value)
+}
+
+/**
+Serialize the expression that converts an Elixir value of a gen file type to the value Jason writes.
+Jason writes structs and dates by themselves, so only enums are converted to their integer values.
+*/
+func (e *elixirLanguageSerializer) toJSONValue(typeName string, value string, serializerInfo *serializerInfo) string {
+	if isList, listType := isList(typeName); isList {
+		itemValue := e.toJSONValue(listType, "item", serializerInfo)
+		if itemValue == "item" {
+			return value
+		}
+
+		return fmt.Sprintf("Enum.map(%s, fn item -> %s end)", value, itemValue)
+	}
+
+	if isMap, _, mapValueType := isMap(typeName); isMap {
+		itemValue := e.toJSONValue(mapValueType, "item", serializerInfo)
+		if itemValue == "item" {
+			return value
+		}
+
+		return fmt.Sprintf("Map.new(%s, fn {key, item} -> {key, %s} end)", value, itemValue)
+	}
+
+	if e.isEnum(typeName, serializerInfo) {
+		return fmt.Sprintf("%s.to_integer(%s)", e.moduleName(typeName, serializerInfo), value)
+	}
+
+	return value
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Fields are snake case, and reserved words get an underscore suffix, like "end_".
+*/
+func (e *elixirLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeElixir, "name"); ok {
+		return name
+	}
+
+	name := toSnakeCase(member.name)
+	for _, reserved := range elixirReservedNames {
+		if name == reserved {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+/**
+Check the map keys of a data member, since JSON object keys are strings and only number keys are parsed back.
+*/
+func (e *elixirLanguageSerializer) validateMapKey(class *class, member *dataMember) error {
+	isMap, mapKeyType, _ := isMap(member.memberType)
+	if !isMap || mapKeyType == "string" || mapKeyType == "int" || mapKeyType == "byte" {
+		return nil
+	}
+
+	return errors.New(fmt.Sprintf("%s map keys can't be read from JSON object keys, which %s.%s uses",
+		mapKeyType, class.name, member.name))
+}
+
+func (e *elixirLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	moduleName := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeElixir, "name"); ok {
+		moduleName = name
+	}
+
+	imports := findLanguageImports(class.annotations, LanguageTypeElixir)
+	modules := make([]string, 0)
+
+	fieldNames := make([]string, 0)
+	typeSpecs := make([]string, 0)
+	fromJSONValues := make([]string, 0)
+	toJSONValues := make([]string, 0)
+
+	usesDates := false
+	isDerived := true
+
+	for _, member := range class.dataMembers {
+		if err := e.validateMapKey(class, member); err != nil {
+			return nil, err
+		}
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeElixir) {
+			imports = appendUnique(imports, imp)
+		}
+
+		fieldName := e.fieldName(member)
+		jsonName := toCamelCase(member.name)
+		jsonValue := fmt.Sprintf("json[\"%s\"]", jsonName)
+		fieldValue := "value." + fieldName
+
+		typeSpec, memberModules := e.typeSpec(member.memberType, class.name, serializerInfo)
+		fromJSONValue := e.fromJSONValue(member.memberType, class.name, jsonValue, serializerInfo)
+		toJSONValue := e.toJSONValue(member.memberType, fieldValue, serializerInfo)
+
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeElixir, "type"); ok {
+			// The overridden type is trusted to be the decoded JSON value
+			typeSpec, memberModules = overrideType, []string{}
+			fromJSONValue, toJSONValue = jsonValue, fieldValue
+		}
+
+		for _, module := range memberModules {
+			modules = appendUnique(modules, module)
+		}
+
+		if strings.Contains(fromJSONValue, "parse_date(") {
+			usesDates = true
+		}
+
+		// Jason writes the fields by their names, so only structs which fields are written as they are can derive the encoder
+		if fieldName != jsonName || toJSONValue != fieldValue {
+			isDerived = false
+		}
+
+		fieldNames = append(fieldNames, fieldName)
+		typeSpecs = append(typeSpecs, fmt.Sprintf("%s: %s", fieldName, typeSpec))
+		fromJSONValues = append(fromJSONValues, fmt.Sprintf("%s: %s", fieldName, fromJSONValue))
+		toJSONValues = append(toJSONValues, fmt.Sprintf("\"%s\" => %s", jsonName, toJSONValue))
+	}
+
+	fullName := moduleName
+	if prefix := e.modulePrefix(serializerInfo); prefix != "" {
+		fullName = prefix + "." + moduleName
+	}
+
+	serializedCode := fmt.Sprintf("defmodule %s do\n", fullName)
+	serializedCode += "  @moduledoc false\n\n"
+	serializedCode += e.serializeAliases(modules, imports, serializerInfo)
+
+	if len(class.dataMembers) == 0 {
+		serializedCode += "  @type t :: %__MODULE__{}\n\n"
+	} else {
+		serializedCode += fmt.Sprintf("  @type t :: %%__MODULE__{\n          %s\n        }\n\n", strings.Join(typeSpecs, ",\n          "))
+	}
+
+	if isDerived {
+		serializedCode += "  @derive Jason.Encoder\n"
+	}
+
+	atoms := make([]string, 0)
+	for _, fieldName := range fieldNames {
+		atoms = append(atoms, ":"+fieldName)
+	}
+
+	serializedCode += fmt.Sprintf("  defstruct [%s]\n\n", strings.Join(atoms, ", "))
+
+	serializedCode += "  @spec new(map()) :: t()\n"
+	if len(class.dataMembers) == 0 {
+		serializedCode += "  def new(%{} = _json), do: %__MODULE__{}\n"
+	} else {
+		serializedCode += "  def new(%{} = json) do\n"
+		serializedCode += "    %__MODULE__{\n"
+		serializedCode += fmt.Sprintf("      %s\n", strings.Join(fromJSONValues, ",\n      "))
+		serializedCode += "    }\n"
+		serializedCode += "  end\n"
+	}
+
+	if usesDates {
+		serializedCode += "\n  defp parse_date(value) do\n"
+		serializedCode += "    {:ok, date, _offset} = DateTime.from_iso8601(value)\n"
+		serializedCode += "    date\n"
+		serializedCode += "  end\n"
+	}
+
+	if !isDerived {
+		// The encoder writes the JSON names and the integer values of enums
+		serializedCode += "\n  defimpl Jason.Encoder do\n"
+		serializedCode += "    def encode(value, opts) do\n"
+		serializedCode += "      Jason.Encode.map(\n"
+		serializedCode += "        %{\n"
+		serializedCode += fmt.Sprintf("          %s\n", strings.Join(toJSONValues, ",\n          "))
+		serializedCode += "        },\n"
+		serializedCode += "        opts\n"
+		serializedCode += "      )\n"
+		serializedCode += "    end\n"
+		serializedCode += "  end\n"
+	}
+
+	serializedCode += "end\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.ex", toSnakeCase(class.name)), e.serializeDeclaration()+serializedCode), nil
+}
+
+func (e *elixirLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
+	moduleName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeElixir, "name"); ok {
+		moduleName = name
+	}
+
+	// The type of an enum is the union of its atoms, which can't be empty
+	if len(enum.enumValues) == 0 {
+		return nil, errors.New(fmt.Sprintf("enum %s has no values", enum.name))
+	}
+
+	if prefix := e.modulePrefix(serializerInfo); prefix != "" {
+		moduleName = prefix + "." + moduleName
+	}
+
+	atoms := make([]string, 0)
+	toInteger := ""
+	fromInteger := ""
+	values := make(map[int]bool)
+
+	for _, value := range enum.enumValues {
+		atom := ":" + toSnakeCase(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeElixir, "name"); ok {
+			atom = ":" + name
+		}
+
+		atoms = append(atoms, atom)
+		toInteger += fmt.Sprintf("  def to_integer(%s), do: %v\n", atom, value.value)
+
+		// Few atoms can have the same value, so the first one is read
+		if !values[value.value] {
+			values[value.value] = true
+			fromInteger += fmt.Sprintf("  def from_integer(%v), do: %s\n", value.value, atom)
+		}
+	}
+
+	serializedCode := fmt.Sprintf("defmodule %s do\n", moduleName)
+	serializedCode += "  @moduledoc false\n\n"
+	serializedCode += fmt.Sprintf("  @type t :: %s\n\n", strings.Join(atoms, " | "))
+	serializedCode += "  @spec to_integer(t()) :: integer()\n"
+	serializedCode += toInteger + "\n"
+	serializedCode += "  @spec from_integer(integer()) :: t()\n"
+	serializedCode += fromInteger
+	serializedCode += "end\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.ex", toSnakeCase(enum.name)), e.serializeDeclaration()+serializedCode), nil
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_elixirLanguageSerializer_getType(t *testing.T) {
+	if got := newElixirLanguageSerializer().getType(); got != LanguageTypeElixir {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeElixir)
+	}
+}
+
+func Test_elixirLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newElixirLanguageSerializer().getTypeName(); got != "elixir" {
+		t.Errorf("getTypeName() = %v, want %v", got, "elixir")
+	}
+}
+
+func Test_elixirLanguageSerializer_generateCode(t *testing.T) {
+	testClass := newClass("orderItem")
+	_ = testClass.addValue("price", "double", nil)
+
+	testEnum := newEnum("orderStatus")
+	_ = testEnum.addValue("active", "1", nil)
+
+	testService, _ := getTestService()
+
+	generatedCode, err := newElixirLanguageSerializer().generateCode(
+		[]middleware{testClass, testEnum, testService}, &serializerInfo{packageName: "shop.order_models"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(generatedCode) != 2 {
+		t.Errorf("generateCode() generated %v files. expected 2", len(generatedCode))
+		return
+	}
+
+	if generatedCode[0].fileName != "order_item.ex" || generatedCode[1].fileName != "order_status.ex" {
+		t.Errorf("generateCode() file names = %v, %v", generatedCode[0].fileName, generatedCode[1].fileName)
+	}
+
+	if !strings.Contains(generatedCode[0].code, "defmodule Shop.OrderModels.OrderItem do\n") {
+		t.Errorf("generateCode() module isn't in the package.\ncode: %v", generatedCode[0].code)
+	}
+}
+
+func Test_elixirLanguageSerializer_serializeClass(t *testing.T) {
+	orderItem := newClass("orderItem")
+
+	user := &class{
+		name: "user",
+		dataMembers: []*dataMember{
+			{memberType: "user", name: "parent"},
+			{memberType: "list<user>", name: "children"},
+			{memberType: "Money", name: "balance"},
+		},
+	}
+
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "int", name: "id"},
+						{memberType: "date", name: "createdAt"},
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "map<int,orderStatus>", name: "statuses"},
+					},
+				},
+				serializerInfo: &serializerInfo{
+					packageName: "shop",
+					classes:     map[string]*class{"orderItem": orderItem},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order.ex",
+				code: "defmodule Shop.Order do\n" +
+					"  @moduledoc false\n\n" +
+					"  alias Shop.{OrderItem, OrderStatus}\n\n" +
+					"  @type t :: %__MODULE__{\n" +
+					"          id: integer(),\n" +
+					"          created_at: DateTime.t(),\n" +
+					"          items: [OrderItem.t()],\n" +
+					"          statuses: %{integer() => OrderStatus.t()}\n" +
+					"        }\n\n" +
+					"  defstruct [:id, :created_at, :items, :statuses]\n\n" +
+					"  @spec new(map()) :: t()\n" +
+					"  def new(%{} = json) do\n" +
+					"    %__MODULE__{\n" +
+					"      id: json[\"id\"],\n" +
+					"      created_at: parse_date(json[\"createdAt\"]),\n" +
+					"      items: Enum.map(json[\"items\"], fn item -> OrderItem.new(item) end),\n" +
+					"      statuses: Map.new(json[\"statuses\"], fn {key, item} -> {String.to_integer(key), OrderStatus.from_integer(item)} end)\n" +
+					"    }\n" +
+					"  end\n\n" +
+					"  defp parse_date(value) do\n" +
+					"    {:ok, date, _offset} = DateTime.from_iso8601(value)\n" +
+					"    date\n" +
+					"  end\n\n" +
+					"  defimpl Jason.Encoder do\n" +
+					"    def encode(value, opts) do\n" +
+					"      Jason.Encode.map(\n" +
+					"        %{\n" +
+					"          \"id\" => value.id,\n" +
+					"          \"createdAt\" => value.created_at,\n" +
+					"          \"items\" => value.items,\n" +
+					"          \"statuses\" => Map.new(value.statuses, fn {key, item} -> {key, OrderStatus.to_integer(item)} end)\n" +
+					"        },\n" +
+					"        opts\n" +
+					"      )\n" +
+					"    end\n" +
+					"  end\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Derived encoder",
+			args: args{
+				class: &class{
+					name: "orderItem",
+					dataMembers: []*dataMember{
+						{memberType: "double", name: "price"},
+						{memberType: "list<string>", name: "tags"},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "order_item.ex",
+				code: "defmodule OrderItem do\n" +
+					"  @moduledoc false\n\n" +
+					"  @type t :: %__MODULE__{\n" +
+					"          price: float(),\n" +
+					"          tags: [String.t()]\n" +
+					"        }\n\n" +
+					"  @derive Jason.Encoder\n" +
+					"  defstruct [:price, :tags]\n\n" +
+					"  @spec new(map()) :: t()\n" +
+					"  def new(%{} = json) do\n" +
+					"    %__MODULE__{\n" +
+					"      price: json[\"price\"],\n" +
+					"      tags: json[\"tags\"]\n" +
+					"    }\n" +
+					"  end\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "test.ex",
+				code: "defmodule Test do\n" +
+					"  @moduledoc false\n\n" +
+					"  @type t :: %__MODULE__{}\n\n" +
+					"  @derive Jason.Encoder\n" +
+					"  defstruct []\n\n" +
+					"  @spec new(map()) :: t()\n" +
+					"  def new(%{} = _json), do: %__MODULE__{}\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides, reserved names and extern types",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{memberType: "string", name: "end"},
+						{
+							memberType: "Money",
+							name:       "price",
+							annotations: []*annotation{
+								{namespace: "elixir", name: "name", arguments: []string{"cost"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "elixir", name: "type", arguments: []string{"Ecto.UUID.t()"}},
+								{namespace: "elixir", name: "import", arguments: []string{"Ecto.UUID"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "elixir", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeElixir: "Acme.Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "test.ex",
+				code: "defmodule Renamed do\n" +
+					"  @moduledoc false\n\n" +
+					"  alias Ecto.UUID\n\n" +
+					"  @type t :: %__MODULE__{\n" +
+					"          end_: String.t(),\n" +
+					"          cost: Acme.Money.t(),\n" +
+					"          id: Ecto.UUID.t()\n" +
+					"        }\n\n" +
+					"  defstruct [:end_, :cost, :id]\n\n" +
+					"  @spec new(map()) :: t()\n" +
+					"  def new(%{} = json) do\n" +
+					"    %__MODULE__{\n" +
+					"      end_: json[\"end\"],\n" +
+					"      cost: Acme.Money.new(json[\"price\"]),\n" +
+					"      id: json[\"id\"]\n" +
+					"    }\n" +
+					"  end\n\n" +
+					"  defimpl Jason.Encoder do\n" +
+					"    def encode(value, opts) do\n" +
+					"      Jason.Encode.map(\n" +
+					"        %{\n" +
+					"          \"end\" => value.end_,\n" +
+					"          \"price\" => value.cost,\n" +
+					"          \"id\" => value.id\n" +
+					"        },\n" +
+					"        opts\n" +
+					"      )\n" +
+					"    end\n" +
+					"  end\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class that contains itself and an extern type without an Elixir name",
+			args: args{
+				class: user,
+				serializerInfo: &serializerInfo{
+					packageName: "shop",
+					classes:     map[string]*class{"user": user},
+					externTypes: map[string]*externType{
+						"Money": {name: "Money", languageNames: map[languageType]string{}},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "user.ex",
+				code: "defmodule Shop.User do\n" +
+					"  @moduledoc false\n\n" +
+					"  @type t :: %__MODULE__{\n" +
+					"          parent: __MODULE__.t(),\n" +
+					"          children: [__MODULE__.t()],\n" +
+					"          balance: Money.t()\n" +
+					"        }\n\n" +
+					"  @derive Jason.Encoder\n" +
+					"  defstruct [:parent, :children, :balance]\n\n" +
+					"  @spec new(map()) :: t()\n" +
+					"  def new(%{} = json) do\n" +
+					"    %__MODULE__{\n" +
+					"      parent: __MODULE__.new(json[\"parent\"]),\n" +
+					"      children: Enum.map(json[\"children\"], fn item -> __MODULE__.new(item) end),\n" +
+					"      balance: Money.new(json[\"balance\"])\n" +
+					"    }\n" +
+					"  end\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unsupported map keys",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{memberType: "map<date,int>", name: "counts"},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "holder.ex",
+				code: "defmodule Bla.Holder do\n" +
+					"  @moduledoc false\n\n" +
+					"  alias Bla.{Category, Evt}\n\n" +
+					"  @type t :: %__MODULE__{\n" +
+					"          e: Evt.t(),\n" +
+					"          kind: Category.t()\n" +
+					"        }\n\n" +
+					"  defstruct [:e, :kind]\n\n" +
+					"  @spec new(map()) :: t()\n" +
+					"  def new(%{} = json) do\n" +
+					"    %__MODULE__{\n" +
+					"      e: Evt.new(json[\"e\"]),\n" +
+					"      kind: Category.from_integer(json[\"kind\"])\n" +
+					"    }\n" +
+					"  end\n\n" +
+					"  defimpl Jason.Encoder do\n" +
+					"    def encode(value, opts) do\n" +
+					"      Jason.Encode.map(\n" +
+					"        %{\n" +
+					"          \"e\" => value.e,\n" +
+					"          \"kind\" => Category.to_integer(value.kind)\n" +
+					"        },\n" +
+					"        opts\n" +
+					"      )\n" +
+					"    end\n" +
+					"  end\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newElixirLanguageSerializer()
+			got, err := e.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, e.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_elixirLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{name: "active", value: 5},
+						{name: "onHold", value: 8},
+						{name: "paused", value: 8, annotations: []*annotation{
+							{namespace: "elixir", name: "name", arguments: []string{"stopped"}},
+						}},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order_status.ex",
+				code: "defmodule OrderStatus do\n" +
+					"  @moduledoc false\n\n" +
+					"  @type t :: :active | :on_hold | :stopped\n\n" +
+					"  @spec to_integer(t()) :: integer()\n" +
+					"  def to_integer(:active), do: 5\n" +
+					"  def to_integer(:on_hold), do: 8\n" +
+					"  def to_integer(:stopped), do: 8\n\n" +
+					"  @spec from_integer(integer()) :: t()\n" +
+					"  def from_integer(5), do: :active\n" +
+					"  def from_integer(8), do: :on_hold\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty enum",
+			args: args{
+				enum: &enum{name: "test", enumValues: []*enumValue{}},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newElixirLanguageSerializer()
+			got, err := e.serializeEnum(tt.args.enum, &serializerInfo{})
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, e.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var elmReservedNames = []string{
+	"alias", "as", "case", "else", "exposing", "if", "import", "in", "infix", "let", "module", "of", "port",
+	"then", "type", "where",
+}
+
+const elmAndMap = "andMap : Decoder a -> Decoder (a -> b) -> Decoder b\n" +
+	"andMap =\n" +
+	"    Decode.map2 (|>)\n"
+
+const elmDateCoders = "dateDecoder : Decoder Time.Posix\n" +
+	"dateDecoder =\n" +
+	"    Iso8601.decoder\n\n\n" +
+	"encodeDate : Time.Posix -> Encode.Value\n" +
+	"encodeDate =\n" +
+	"    Iso8601.encode\n"
+
+const elmIntDictDecoder = "intDictDecoder : Decoder a -> Decoder (Dict Int a)\n" +
+	"intDictDecoder valueDecoder =\n" +
+	"    Decode.keyValuePairs valueDecoder\n" +
+	"        |> Decode.andThen\n" +
+	"            (\\pairs ->\n" +
+	"                List.foldr\n" +
+	"                    (\\( key, item ) result ->\n" +
+	"                        case String.toInt key of\n" +
+	"                            Just intKey ->\n" +
+	"                                Decode.map (Dict.insert intKey item) result\n\n" +
+	"                            Nothing ->\n" +
+	"                                Decode.fail (\"Invalid integer key \" ++ key)\n" +
+	"                    )\n" +
+	"                    (Decode.succeed Dict.empty)\n" +
+	"                    pairs\n" +
+	"            )\n"
+
+/**
+Generate a single Elm module with a type alias for every class, a custom type for every enum,
+and a decoder and an encoder for every one of them.
+*/
+type elmLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+/**
+The helpers the types of a module use, which are generated once at its end.
+*/
+type elmHelpers struct {
+	imports    []string
+	andMap     bool
+	dates      bool
+	intDict    bool
+	dictionary bool
+}
+
+func newElmLanguageSerializer() *elmLanguageSerializer {
+	result := &elmLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "Bool"
+	result.typesMap["int"] = "Int"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "Float"
+	result.typesMap["float"] = "Float"
+	result.typesMap["char"] = "String"
+	result.typesMap["byte"] = "Int"
+	result.typesMap["date"] = "Time.Posix"
+
+	return result
+}
+
+func (e *elmLanguageSerializer) getType() languageType {
+	return LanguageTypeElm
+}
+
+func (e *elmLanguageSerializer) getTypeName() string {
+	return "elm"
+}
+
+func (e *elmLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	helpers := &elmHelpers{imports: make([]string, 0)}
+	serialized := make([]string, 0)
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			code, err := e.serializeClass(o, serializerInfo, helpers)
+			if err != nil {
+				return nil, err
+			}
+
+			serialized = append(serialized, code)
+		case *enum:
+			code, err := e.serializeEnum(o)
+			if err != nil {
+				return nil, err
+			}
+
+			serialized = append(serialized, code)
+		}
+
+		// Extern types are hand-written, and services and channels aren't generated for Elm
+	}
+
+	if helpers.andMap {
+		serialized = append(serialized, elmAndMap)
+	}
+
+	if helpers.dates {
+		serialized = append(serialized, elmDateCoders)
+	}
+
+	if helpers.intDict {
+		serialized = append(serialized, elmIntDictDecoder)
+	}
+
+	moduleName := e.moduleName(serializerInfo)
+
+	return []*generatedCode{newGeneratedCode(strings.Replace(moduleName, ".", "/", -1)+".elm",
+		e.serializeDeclaration(moduleName, helpers)+strings.Join(serialized, "\n\n"))}, nil
+}
+
+/**
+Get the module name, like "Shop.Models" for the package "shop.models", or "Models" without a package.
+*/
+func (e *elmLanguageSerializer) moduleName(serializerInfo *serializerInfo) string {
+	if serializerInfo.packageName == "" {
+		return "Models"
+	}
+
+	parts := make([]string, 0)
+	for _, part := range strings.Split(serializerInfo.packageName, ".") {
+		parts = append(parts, toFirstCharUpper(snakeToCamelCase(part)))
+	}
+
+	return strings.Join(parts, ".")
+}
+
+/**
+Serialize the module declaration and the imports. Elm comments come after the module declaration.
+*/
+func (e *elmLanguageSerializer) serializeDeclaration(moduleName string, helpers *elmHelpers) string {
+	result := fmt.Sprintf("module %s exposing (..)\n\n", moduleName) +
+		"-- **********************************\n" +
+		"--\tGenerated by ModelsGenerator\n--\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n-- **********************************\n\n"
+
+	imports := append([]string{}, helpers.imports...)
+	imports = appendUnique(imports, "Json.Decode as Decode exposing (Decoder)")
+	imports = appendUnique(imports, "Json.Encode as Encode")
+
+	if helpers.dictionary {
+		imports = appendUnique(imports, "Dict exposing (Dict)")
+	}
+
+	if helpers.dates {
+		imports = appendUnique(imports, "Iso8601")
+		imports = appendUnique(imports, "Time")
+	}
+
+	sort.Strings(imports)
+
+	for _, imp := range imports {
+		result += fmt.Sprintf("import %s\n", imp)
+	}
+
+	return result + "\n\n"
+}
+
+/**
+Get the Elm name of a class or an enum, taking the name annotation into account.
+*/
+func (e *elmLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(typeName, LanguageTypeElm, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(typeName)
+}
+
+/**
+Split an extern type, written like "Money#Money", to the module it's imported from and its type.
+The module exposes "decoder" and "encode" for the type.
+*/
+func (e *elmLanguageSerializer) externType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeElm)
+	if !isExtern {
+		return "", "", false
+	}
+
+	if module, name, ok := strings.Cut(externName, "#"); ok {
+		return module, name, true
+	}
+
+	// A type without a module, like "Money", is in the module of the same name
+	return externName, externName, true
+}
+
+/**
+Serialize the Elm type of a gen file type, with "List T" for lists and "Dict K V" for maps.
+*/
+func (e *elmLanguageSerializer) memberType(typeName string, serializerInfo *serializerInfo, helpers *elmHelpers) string {
+	if isList, listType := isList(typeName); isList {
+		return "List " + e.memberType(listType, serializerInfo, helpers)
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		helpers.dictionary = true
+
+		return fmt.Sprintf("Dict %s %s", e.memberType(mapKeyType, serializerInfo, helpers),
+			e.memberType(mapValueType, serializerInfo, helpers))
+	}
+
+	if primitiveType, isPrimitive := e.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			helpers.dates = true
+		}
+
+		return primitiveType
+	}
+
+	if module, name, isExtern := e.externType(typeName, serializerInfo); isExtern {
+		helpers.imports = appendUnique(helpers.imports, module)
+
+		return module + "." + name
+	}
+
+	return e.typeName(typeName, serializerInfo)
+}
+
+/**
+Serialize the decoder of a gen file type.
+Decoders of classes that contain themselves are lazy, since Elm values can't refer to themselves directly.
+*/
+func (e *elmLanguageSerializer) decoder(typeName string, serializerInfo *serializerInfo, helpers *elmHelpers) string {
+	if isList, listType := isList(typeName); isList {
+		return "Decode.list " + e.argument(e.decoder(listType, serializerInfo, helpers))
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		valueDecoder := e.argument(e.decoder(mapValueType, serializerInfo, helpers))
+
+		// JSON object keys are strings, so number keys are parsed
+		if mapKeyType == "int" || mapKeyType == "byte" {
+			helpers.intDict = true
+
+			return "intDictDecoder " + valueDecoder
+		}
+
+		return "Decode.dict " + valueDecoder
+	}
+
+	switch typeName {
+	case "bool":
+		return "Decode.bool"
+	case "int", "byte":
+		return "Decode.int"
+	case "string", "char":
+		return "Decode.string"
+	case "double", "float":
+		return "Decode.float"
+	case "date":
+		return "dateDecoder"
+	}
+
+	if module, _, isExtern := e.externType(typeName, serializerInfo); isExtern {
+		return module + ".decoder"
+	}
+
+	decoderName := toCamelCase(e.typeName(typeName, serializerInfo)) + "Decoder"
+	if c, isClass := serializerInfo.classes[typeName]; isClass && isCyclicClass(c, serializerInfo.classes) {
+		return fmt.Sprintf("Decode.lazy (\\_ -> %s)", decoderName)
+	}
+
+	return decoderName
+}
+
+/**
+Serialize the encoder function of a gen file type.
+*/
+func (e *elmLanguageSerializer) encoder(typeName string, serializerInfo *serializerInfo) string {
+	if isList, listType := isList(typeName); isList {
+		return "Encode.list " + e.argument(e.encoder(listType, serializerInfo))
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyEncoder := "identity"
+		if mapKeyType == "int" || mapKeyType == "byte" {
+			keyEncoder = "String.fromInt"
+		}
+
+		return fmt.Sprintf("Encode.dict %s %s", keyEncoder, e.argument(e.encoder(mapValueType, serializerInfo)))
+	}
+
+	switch typeName {
+	case "bool":
+		return "Encode.bool"
+	case "int", "byte":
+		return "Encode.int"
+	case "string", "char":
+		return "Encode.string"
+	case "double", "float":
+		return "Encode.float"
+	case "date":
+		return "encodeDate"
+	}
+
+	if module, _, isExtern := e.externType(typeName, serializerInfo); isExtern {
+		return module + ".encode"
+	}
+
+	return "encode" + e.typeName(typeName, serializerInfo)
+}
+
+/**
+Wrap an expression with parentheses when it's passed as an argument and has its own arguments.
+*/
+func (e *elmLanguageSerializer) argument(expression string) string {
+	if strings.Contains(expression, " ") {
+		return "(" + expression + ")"
+	}
+
+	return expression
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Elm keywords get an underscore suffix, like "type_".
+*/
+func (e *elmLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeElm, "name"); ok {
+		return name
+	}
+
+	name := toCamelCase(member.name)
+	for _, reserved := range elmReservedNames {
+		if name == reserved {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+/**
+Check the map keys of a data member. Dict keys must be comparable, and JSON object keys are strings,
+so only string and number keys are supported.
+*/
+func (e *elmLanguageSerializer) validateMapKey(class *class, member *dataMember) error {
+	isMap, mapKeyType, _ := isMap(member.memberType)
+	if !isMap {
+		return nil
+	}
+
+	switch mapKeyType {
+	case "string", "char", "int", "byte":
+		return nil
+	}
+
+	return errors.New(fmt.Sprintf("%s map keys aren't supported by Elm dictionaries, which %s.%s uses",
+		mapKeyType, class.name, member.name))
+}
+
+/**
+Serialize a class as a record type alias with its decoder and encoder.
+Type aliases can't contain themselves, so a class that does is a custom type with a single constructor, like "type Node = Node { ... }".
+*/
+func (e *elmLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo, helpers *elmHelpers) (string, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeElm, "name"); ok {
+		className = name
+	}
+
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypeElm) {
+		helpers.imports = appendUnique(helpers.imports, imp)
+	}
+
+	isWrapped := isCyclicClass(class, serializerInfo.classes)
+
+	fields := make([]string, 0)
+	fieldNames := make([]string, 0)
+	decoders := make([]string, 0)
+	encoders := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		if err := e.validateMapKey(class, member); err != nil {
+			return "", err
+		}
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeElm) {
+			helpers.imports = appendUnique(helpers.imports, imp)
+		}
+
+		fieldName := e.fieldName(member)
+		jsonName := toCamelCase(member.name)
+
+		memberType := e.memberType(member.memberType, serializerInfo, helpers)
+		decoder := fmt.Sprintf("Decode.field \"%s\" %s", jsonName, e.argument(e.decoder(member.memberType, serializerInfo, helpers)))
+		encoder := fmt.Sprintf("%s value.%s", e.encoder(member.memberType, serializerInfo), fieldName)
+
+		// A missing or null optional value is Nothing, and Nothing is written as null
+		if hasLanguageAnnotation(member.annotations, LanguageTypeElm, "optional") {
+			memberType = "Maybe " + e.argument(memberType)
+			decoder = fmt.Sprintf("Decode.maybe (%s)", decoder)
+			encoder = fmt.Sprintf("Maybe.withDefault Encode.null (Maybe.map %s value.%s)",
+				e.argument(e.encoder(member.memberType, serializerInfo)), fieldName)
+		}
+
+		fields = append(fields, fmt.Sprintf("%s : %s", fieldName, memberType))
+		fieldNames = append(fieldNames, fieldName)
+		decoders = append(decoders, decoder)
+		encoders = append(encoders, fmt.Sprintf("( \"%s\", %s )", jsonName, encoder))
+	}
+
+	record := "{}"
+	if len(fields) > 0 {
+		record = fmt.Sprintf("{ %s\n    }", strings.Join(fields, "\n    , "))
+	}
+
+	serializedCode := ""
+	if isWrapped {
+		serializedCode += fmt.Sprintf("type %s\n    = %s\n        %s\n\n\n", className, className,
+			strings.Replace(record, "\n", "\n    ", -1))
+	} else {
+		serializedCode += fmt.Sprintf("type alias %s =\n    %s\n\n\n", className, record)
+	}
+
+	decoderName := toCamelCase(className) + "Decoder"
+	serializedCode += fmt.Sprintf("%s : Decoder %s\n%s =\n", decoderName, className, decoderName)
+
+	if len(fields) == 0 {
+		serializedCode += "    Decode.succeed {}\n\n\n"
+	} else {
+		helpers.andMap = true
+
+		// Records of type aliases have constructors, but records of custom types are built by a function
+		constructor := className
+		if isWrapped {
+			assignments := make([]string, 0)
+			for _, fieldName := range fieldNames {
+				assignments = append(assignments, fmt.Sprintf("%s = %s", fieldName, fieldName))
+			}
+
+			constructor = fmt.Sprintf("(\\%s -> %s { %s })", strings.Join(fieldNames, " "), className,
+				strings.Join(assignments, ", "))
+		}
+
+		serializedCode += fmt.Sprintf("    Decode.succeed %s\n", constructor)
+		for _, decoder := range decoders {
+			serializedCode += fmt.Sprintf("        |> andMap (%s)\n", decoder)
+		}
+
+		serializedCode += "\n\n"
+	}
+
+	parameter := "value"
+	if isWrapped {
+		parameter = fmt.Sprintf("(%s value)", className)
+	}
+
+	if len(fields) == 0 {
+		parameter = "_"
+	}
+
+	serializedCode += fmt.Sprintf("encode%s : %s -> Encode.Value\nencode%s %s =\n", className, className, className, parameter)
+
+	if len(encoders) == 0 {
+		serializedCode += "    Encode.object []\n"
+	} else {
+		serializedCode += fmt.Sprintf("    Encode.object\n        [ %s\n        ]\n", strings.Join(encoders, "\n        , "))
+	}
+
+	return serializedCode, nil
+}
+
+/**
+Serialize an enum as a custom type, with a decoder and an encoder of its integer values.
+Constructors are prefixed with the enum name, since constructors of all the types in the module share names.
+*/
+func (e *elmLanguageSerializer) serializeEnum(enum *enum) (string, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeElm, "name"); ok {
+		enumName = name
+	}
+
+	// Custom types must have constructors
+	if len(enum.enumValues) == 0 {
+		return "", errors.New(fmt.Sprintf("enum %s has no values", enum.name))
+	}
+
+	constructors := make([]string, 0)
+	decoderCases := ""
+	encoderCases := make([]string, 0)
+	values := make(map[int]bool)
+
+	for _, value := range enum.enumValues {
+		constructor := enumName + toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeElm, "name"); ok {
+			constructor = name
+		}
+
+		constructors = append(constructors, constructor)
+		encoderCases = append(encoderCases, fmt.Sprintf("        %s ->\n            Encode.int %v\n", constructor, value.value))
+
+		// Few constructors can have the same value, so the first one is decoded
+		if !values[value.value] {
+			values[value.value] = true
+			decoderCases += fmt.Sprintf("                    %v ->\n                        Decode.succeed %s\n\n", value.value, constructor)
+		}
+	}
+
+	decoderName := toCamelCase(enumName) + "Decoder"
+
+	serializedCode := fmt.Sprintf("type %s\n    = %s\n\n\n", enumName, strings.Join(constructors, "\n    | "))
+	serializedCode += fmt.Sprintf("%s : Decoder %s\n%s =\n", decoderName, enumName, decoderName)
+	serializedCode += "    Decode.int\n"
+	serializedCode += "        |> Decode.andThen\n"
+	serializedCode += "            (\\value ->\n"
+	serializedCode += "                case value of\n"
+	serializedCode += decoderCases
+	serializedCode += "                    _ ->\n"
+	serializedCode += fmt.Sprintf("                        Decode.fail (\"Unknown %s value \" ++ String.fromInt value)\n", enumName)
+	serializedCode += "            )\n\n\n"
+	serializedCode += fmt.Sprintf("encode%s : %s -> Encode.Value\nencode%s value =\n", enumName, enumName, enumName)
+	serializedCode += "    case value of\n"
+	serializedCode += strings.Join(encoderCases, "\n")
+
+	return serializedCode, nil
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_elmLanguageSerializer_getType(t *testing.T) {
+	if got := newElmLanguageSerializer().getType(); got != LanguageTypeElm {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeElm)
+	}
+}
+
+func Test_elmLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newElmLanguageSerializer().getTypeName(); got != "elm" {
+		t.Errorf("getTypeName() = %v, want %v", got, "elm")
+	}
+}
+
+func Test_elmLanguageSerializer_generateCode(t *testing.T) {
+	order := &class{
+		name:        "order",
+		dataMembers: []*dataMember{{memberType: "date", name: "createdAt"}},
+	}
+	status := &enum{
+		name:       "orderStatus",
+		enumValues: []*enumValue{{name: "active", value: 1}},
+	}
+
+	testService, _ := getTestService()
+	generatedCode, err := newElmLanguageSerializer().generateCode(
+		[]middleware{order, status, testService}, &serializerInfo{packageName: "shop.order_models"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(generatedCode) != 1 || generatedCode[0].fileName != "Shop/OrderModels.elm" {
+		t.Errorf("generateCode() should generate only Shop/OrderModels.elm")
+		return
+	}
+
+	code := generatedCode[0].code
+	if !strings.HasPrefix(code, "module Shop.OrderModels exposing (..)\n\n") {
+		t.Errorf("generateCode() module isn't declared.\ncode: %v", code)
+	}
+
+	wantImports := "import Iso8601\n" +
+		"import Json.Decode as Decode exposing (Decoder)\n" +
+		"import Json.Encode as Encode\n" +
+		"import Time\n\n\n" +
+		"type alias Order ="
+	if !strings.Contains(code, wantImports) {
+		t.Errorf("generateCode() imports = %v, want %v", code, wantImports)
+	}
+
+	// The helpers are generated once, after the types
+	wantHelpers := "        OrderStatusActive ->\n" +
+		"            Encode.int 1\n\n\n" +
+		elmAndMap + "\n\n" +
+		elmDateCoders
+	if !strings.HasSuffix(code, wantHelpers) {
+		t.Errorf("generateCode() got = %v, want suffix %v", code, wantHelpers)
+	}
+}
+
+func Test_elmLanguageSerializer_serializeClass(t *testing.T) {
+	node := &class{
+		name: "node",
+		dataMembers: []*dataMember{
+			{memberType: "int", name: "id"},
+			{memberType: "list<node>", name: "children"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		class       *class
+		info        *serializerInfo
+		want        string
+		wantHelpers *elmHelpers
+		wantErr     bool
+	}{
+		{
+			name: "Class serialize",
+			class: &class{
+				name: "order",
+				dataMembers: []*dataMember{
+					{memberType: "string", name: "type"},
+					{memberType: "list<orderItem>", name: "items"},
+					{memberType: "map<int,orderStatus>", name: "statuses"},
+					{memberType: "map<string,double>", name: "prices"},
+					{memberType: "date", name: "paidAt", annotations: []*annotation{{namespace: "elm", name: "optional"}}},
+				},
+			},
+			info: &serializerInfo{},
+			want: "type alias Order =\n" +
+				"    { type_ : String\n" +
+				"    , items : List OrderItem\n" +
+				"    , statuses : Dict Int OrderStatus\n" +
+				"    , prices : Dict String Float\n" +
+				"    , paidAt : Maybe Time.Posix\n" +
+				"    }\n\n\n" +
+				"orderDecoder : Decoder Order\n" +
+				"orderDecoder =\n" +
+				"    Decode.succeed Order\n" +
+				"        |> andMap (Decode.field \"type\" Decode.string)\n" +
+				"        |> andMap (Decode.field \"items\" (Decode.list orderItemDecoder))\n" +
+				"        |> andMap (Decode.field \"statuses\" (intDictDecoder orderStatusDecoder))\n" +
+				"        |> andMap (Decode.field \"prices\" (Decode.dict Decode.float))\n" +
+				"        |> andMap (Decode.maybe (Decode.field \"paidAt\" dateDecoder))\n\n\n" +
+				"encodeOrder : Order -> Encode.Value\n" +
+				"encodeOrder value =\n" +
+				"    Encode.object\n" +
+				"        [ ( \"type\", Encode.string value.type_ )\n" +
+				"        , ( \"items\", Encode.list encodeOrderItem value.items )\n" +
+				"        , ( \"statuses\", Encode.dict String.fromInt encodeOrderStatus value.statuses )\n" +
+				"        , ( \"prices\", Encode.dict identity Encode.float value.prices )\n" +
+				"        , ( \"paidAt\", Maybe.withDefault Encode.null (Maybe.map encodeDate value.paidAt) )\n" +
+				"        ]\n",
+			wantHelpers: &elmHelpers{imports: []string{}, andMap: true, dates: true, intDict: true, dictionary: true},
+		},
+		{
+			name:  "Empty class",
+			class: &class{name: "test", dataMembers: []*dataMember{}},
+			info:  &serializerInfo{},
+			want: "type alias Test =\n" +
+				"    {}\n\n\n" +
+				"testDecoder : Decoder Test\n" +
+				"testDecoder =\n" +
+				"    Decode.succeed {}\n\n\n" +
+				"encodeTest : Test -> Encode.Value\n" +
+				"encodeTest _ =\n" +
+				"    Encode.object []\n",
+			wantHelpers: &elmHelpers{imports: []string{}},
+		},
+		{
+			name:  "Class that contains itself",
+			class: node,
+			info:  &serializerInfo{classes: map[string]*class{"node": node}},
+			want: "type Node\n" +
+				"    = Node\n" +
+				"        { id : Int\n" +
+				"        , children : List Node\n" +
+				"        }\n\n\n" +
+				"nodeDecoder : Decoder Node\n" +
+				"nodeDecoder =\n" +
+				"    Decode.succeed (\\id children -> Node { id = id, children = children })\n" +
+				"        |> andMap (Decode.field \"id\" Decode.int)\n" +
+				"        |> andMap (Decode.field \"children\" (Decode.list (Decode.lazy (\\_ -> nodeDecoder))))\n\n\n" +
+				"encodeNode : Node -> Encode.Value\n" +
+				"encodeNode (Node value) =\n" +
+				"    Encode.object\n" +
+				"        [ ( \"id\", Encode.int value.id )\n" +
+				"        , ( \"children\", Encode.list encodeNode value.children )\n" +
+				"        ]\n",
+			wantHelpers: &elmHelpers{imports: []string{}, andMap: true},
+		},
+		{
+			name: "Class with language overrides and extern types",
+			class: &class{
+				name:        "test",
+				annotations: []*annotation{{namespace: "elm", name: "name", arguments: []string{"Renamed"}}},
+				dataMembers: []*dataMember{
+					{
+						memberType:  "money",
+						name:        "price",
+						annotations: []*annotation{{namespace: "elm", name: "name", arguments: []string{"cost"}}},
+					},
+				},
+			},
+			info: &serializerInfo{externTypes: map[string]*externType{
+				"money": {
+					name:          "money",
+					languageNames: map[languageType]string{LanguageTypeElm: "Acme.Money#Money"},
+				},
+			}},
+			want: "type alias Renamed =\n" +
+				"    { cost : Acme.Money.Money\n" +
+				"    }\n\n\n" +
+				"renamedDecoder : Decoder Renamed\n" +
+				"renamedDecoder =\n" +
+				"    Decode.succeed Renamed\n" +
+				"        |> andMap (Decode.field \"price\" Acme.Money.decoder)\n\n\n" +
+				"encodeRenamed : Renamed -> Encode.Value\n" +
+				"encodeRenamed value =\n" +
+				"    Encode.object\n" +
+				"        [ ( \"price\", Acme.Money.encode value.cost )\n" +
+				"        ]\n",
+			wantHelpers: &elmHelpers{imports: []string{"Acme.Money"}, andMap: true},
+		},
+		{
+			name: "Class with renamed types",
+			class: &class{
+				name: "holder",
+				dataMembers: []*dataMember{
+					{memberType: "event", name: "e"},
+					{memberType: "kind", name: "kind"},
+				},
+			},
+			info: getTestRenamedTypesInfo(),
+			want: "type alias Holder =\n" +
+				"    { e : Evt\n" +
+				"    , kind : Category\n" +
+				"    }\n\n\n" +
+				"holderDecoder : Decoder Holder\n" +
+				"holderDecoder =\n" +
+				"    Decode.succeed Holder\n" +
+				"        |> andMap (Decode.field \"e\" evtDecoder)\n" +
+				"        |> andMap (Decode.field \"kind\" categoryDecoder)\n\n\n" +
+				"encodeHolder : Holder -> Encode.Value\n" +
+				"encodeHolder value =\n" +
+				"    Encode.object\n" +
+				"        [ ( \"e\", encodeEvt value.e )\n" +
+				"        , ( \"kind\", encodeCategory value.kind )\n" +
+				"        ]\n",
+			wantHelpers: &elmHelpers{imports: []string{}, andMap: true},
+		},
+		{
+			name: "Unsupported map keys",
+			class: &class{
+				name:        "test",
+				dataMembers: []*dataMember{{memberType: "map<orderStatus,int>", name: "counts"}},
+			},
+			info:    &serializerInfo{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helpers := &elmHelpers{imports: []string{}}
+
+			got, err := newElmLanguageSerializer().serializeClass(tt.class, tt.info, helpers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(helpers, tt.wantHelpers) {
+				t.Errorf("serializeClass() helpers = %+v, want %+v", helpers, tt.wantHelpers)
+			}
+		})
+	}
+}
+
+func Test_elmLanguageSerializer_serializeEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		enum    *enum
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			enum: &enum{
+				name: "orderStatus",
+				enumValues: []*enumValue{
+					{name: "active", value: 5},
+					{name: "onHold", value: 8},
+					{name: "paused", value: 8, annotations: []*annotation{
+						{namespace: "elm", name: "name", arguments: []string{"Stopped"}},
+					}},
+				},
+			},
+			want: "type OrderStatus\n" +
+				"    = OrderStatusActive\n" +
+				"    | OrderStatusOnHold\n" +
+				"    | Stopped\n\n\n" +
+				"orderStatusDecoder : Decoder OrderStatus\n" +
+				"orderStatusDecoder =\n" +
+				"    Decode.int\n" +
+				"        |> Decode.andThen\n" +
+				"            (\\value ->\n" +
+				"                case value of\n" +
+				"                    5 ->\n" +
+				"                        Decode.succeed OrderStatusActive\n\n" +
+				"                    8 ->\n" +
+				"                        Decode.succeed OrderStatusOnHold\n\n" +
+				"                    _ ->\n" +
+				"                        Decode.fail (\"Unknown OrderStatus value \" ++ String.fromInt value)\n" +
+				"            )\n\n\n" +
+				"encodeOrderStatus : OrderStatus -> Encode.Value\n" +
+				"encodeOrderStatus value =\n" +
+				"    case value of\n" +
+				"        OrderStatusActive ->\n" +
+				"            Encode.int 5\n\n" +
+				"        OrderStatusOnHold ->\n" +
+				"            Encode.int 8\n\n" +
+				"        Stopped ->\n" +
+				"            Encode.int 8\n",
+		},
+		{
+			name:    "Empty enum",
+			enum:    &enum{name: "test", enumValues: []*enumValue{}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newElmLanguageSerializer().serializeEnum(tt.enum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+)
+
+const defaultFieldNumbersLock = "fields.lock"
+const maxFieldNumber = 536870911
+
+/**
+Keep the field numbers of binary formats, like Protocol Buffers and Thrift, stable between generations.
+A data member can set its number with @field(number). Otherwise, the numbers are kept in a lock file,
+so reordering data members doesn't change them, and removed data members keep their numbers reserved.
+The lock file is written like {"className": {"memberName": 1}}.
+*/
+type fieldNumbers struct {
+	path    string
+	classes map[string]map[string]int
+}
+
+/**
+Load the field numbers from the lock file of the options, or from fields.lock.
+A missing lock file is an empty one.
+*/
+func loadFieldNumbers(serializerInfo *serializerInfo) (*fieldNumbers, error) {
+	path, ok := findOption(serializerInfo, "lock")
+	if !ok || path == "" {
+		path = defaultFieldNumbersLock
+	}
+
+	result := &fieldNumbers{path: path, classes: make(map[string]map[string]int)}
+
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &result.classes); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to read the field numbers lock file %s: %v", path, err))
+	}
+
+	return result, nil
+}
+
+/**
+Assign a field number to every data member of the class, and lock them.
+*/
+func (f *fieldNumbers) assign(class *class) (map[string]int, error) {
+	locked, ok := f.classes[class.name]
+	if !ok {
+		locked = make(map[string]int)
+		f.classes[class.name] = locked
+	}
+
+	result := make(map[string]int)
+	used := make(map[int]string)
+
+	// Explicit numbers come first, and they take over the lock
+	for _, member := range class.dataMembers {
+		value, ok := findAnnotation(member.annotations, "field")
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil || !isValidFieldNumber(number) {
+			return nil, errors.New(fmt.Sprintf(
+				"data member %s of class %s has invalid field number %s", member.name, class.name, value))
+		}
+
+		if other, exists := used[number]; exists {
+			return nil, errors.New(fmt.Sprintf(
+				"data members %s and %s of class %s have the same field number %v", other, member.name, class.name, number))
+		}
+
+		result[member.name] = number
+		used[number] = member.name
+	}
+
+	// Locked numbers stay taken, even by removed data members, unless their data member got an explicit number
+	for name, number := range locked {
+		if _, isExplicit := result[name]; isExplicit {
+			continue
+		}
+
+		if other, exists := used[number]; exists {
+			return nil, errors.New(fmt.Sprintf(
+				"field number %v of class %s is locked for data member %s, but %s uses it", number, class.name, name, other))
+		}
+
+		used[number] = name
+	}
+
+	next := 1
+	for _, member := range class.dataMembers {
+		if _, exists := result[member.name]; exists {
+			continue
+		}
+
+		if number, isLocked := locked[member.name]; isLocked {
+			result[member.name] = number
+			continue
+		}
+
+		next = nextFieldNumber(used, next)
+		result[member.name] = next
+		used[next] = member.name
+	}
+
+	for name, number := range result {
+		locked[name] = number
+	}
+
+	return result, nil
+}
+
+/**
+Get the field numbers and the names of the removed data members of a class, which must stay reserved.
+They are sorted by their numbers, and the name of every number is at the same index.
+*/
+func (f *fieldNumbers) reserved(class *class) ([]int, []string) {
+	removed := make(map[int]string)
+	numbers := make([]int, 0)
+
+	for name, number := range f.classes[class.name] {
+		if findDataMember(class, name) == nil {
+			removed[number] = name
+			numbers = append(numbers, number)
+		}
+	}
+
+	sort.Ints(numbers)
+
+	names := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		names = append(names, removed[number])
+	}
+
+	return numbers, names
+}
+
+func (f *fieldNumbers) save() error {
+	content, err := json.MarshalIndent(f.classes, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(f.path, append(content, '\n'), 0644)
+}
+
+/**
+Get the next free field number, skipping the numbers Protocol Buffers reserves for itself.
+*/
+func nextFieldNumber(used map[int]string, from int) int {
+	number := from
+	for {
+		if _, exists := used[number]; !exists && isValidFieldNumber(number) {
+			return number
+		}
+
+		number++
+	}
+}
+
+func isValidFieldNumber(number int) bool {
+	return number > 0 && number <= maxFieldNumber && (number < 19000 || number > 19999)
+}
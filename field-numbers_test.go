@@ -0,0 +1,179 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func fieldAnnotation(number string) []*annotation {
+	return []*annotation{{name: "field", arguments: []string{number}}}
+}
+
+func Test_fieldNumbers_assign(t *testing.T) {
+	tests := []struct {
+		name    string
+		locked  map[string]map[string]int
+		class   *class
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name: "New class",
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int"},
+				{name: "name", memberType: "string"},
+			}},
+			want: map[string]int{"id": 1, "name": 2},
+		},
+		{
+			name: "Explicit numbers",
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int"},
+				{name: "name", memberType: "string", annotations: fieldAnnotation("1")},
+			}},
+			want: map[string]int{"id": 2, "name": 1},
+		},
+		{
+			name:   "Reordered members keep their numbers",
+			locked: map[string]map[string]int{"order": {"id": 1, "name": 2}},
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "name", memberType: "string"},
+				{name: "id", memberType: "int"},
+			}},
+			want: map[string]int{"id": 1, "name": 2},
+		},
+		{
+			name:   "Removed members keep their numbers",
+			locked: map[string]map[string]int{"order": {"id": 1, "old": 2}},
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int"},
+				{name: "name", memberType: "string"},
+			}},
+			want: map[string]int{"id": 1, "name": 3},
+		},
+		{
+			name:   "Fill the gaps",
+			locked: map[string]map[string]int{"order": {"id": 18999}},
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int"},
+				{name: "name", memberType: "string", annotations: fieldAnnotation("1")},
+				{name: "other", memberType: "string"},
+			}},
+			want: map[string]int{"id": 18999, "name": 1, "other": 2},
+		},
+		{
+			name:   "Explicit number of a locked member",
+			locked: map[string]map[string]int{"order": {"id": 1, "name": 2}},
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int", annotations: fieldAnnotation("5")},
+				{name: "name", memberType: "string"},
+			}},
+			want: map[string]int{"id": 5, "name": 2},
+		},
+		{
+			name:   "Explicit number conflicts with the lock",
+			locked: map[string]map[string]int{"order": {"id": 1, "old": 2}},
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int"},
+				{name: "name", memberType: "string", annotations: fieldAnnotation("2")},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "Duplicated explicit numbers",
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int", annotations: fieldAnnotation("1")},
+				{name: "name", memberType: "string", annotations: fieldAnnotation("1")},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "Invalid explicit number",
+			class: &class{name: "order", dataMembers: []*dataMember{
+				{name: "id", memberType: "int", annotations: fieldAnnotation("19000")},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fieldNumbers{classes: make(map[string]map[string]int)}
+			for className, members := range tt.locked {
+				f.classes[className] = members
+			}
+
+			got, err := f.assign(tt.class)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("assign() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assign() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nextFieldNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		used map[int]string
+		from int
+		want int
+	}{
+		{name: "Free number", used: map[int]string{}, from: 1, want: 1},
+		{name: "Used number", used: map[int]string{1: "id", 2: "name"}, from: 1, want: 3},
+		{name: "Reserved range", used: map[int]string{18999: "id"}, from: 18999, want: 20000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextFieldNumber(tt.used, tt.from); got != tt.want {
+				t.Errorf("nextFieldNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_fieldNumbers_reserved(t *testing.T) {
+	f := &fieldNumbers{classes: map[string]map[string]int{"order": {"id": 1, "zeta": 2, "alpha": 4}}}
+	class := &class{name: "order", dataMembers: []*dataMember{{name: "id", memberType: "int"}}}
+
+	// The names are in the order of their numbers
+	numbers, names := f.reserved(class)
+	if !reflect.DeepEqual(numbers, []int{2, 4}) || !reflect.DeepEqual(names, []string{"zeta", "alpha"}) {
+		t.Errorf("reserved() = %v, %v", numbers, names)
+	}
+}
+
+func Test_fieldNumbers_save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fields.lock")
+	info := &serializerInfo{options: map[string]string{"lock": path}}
+
+	f, err := loadFieldNumbers(info)
+	if err != nil {
+		t.Errorf("loadFieldNumbers() error = %v", err)
+		return
+	}
+
+	class := &class{name: "order", dataMembers: []*dataMember{{name: "id", memberType: "int"}}}
+	if _, err := f.assign(class); err != nil {
+		t.Errorf("assign() error = %v", err)
+		return
+	}
+
+	if err := f.save(); err != nil {
+		t.Errorf("save() error = %v", err)
+		return
+	}
+
+	loaded, err := loadFieldNumbers(info)
+	if err != nil {
+		t.Errorf("loadFieldNumbers() error = %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(loaded.classes, f.classes) {
+		t.Errorf("loadFieldNumbers() = %v, want %v", loaded.classes, f.classes)
+	}
+}
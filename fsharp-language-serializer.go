@@ -0,0 +1,294 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+/**
+Generate F# immutable records with System.Text.Json names and F# enums.
+Everything is generated into a single file, "Models.fs", since F# types must be declared before they're used.
+Types that use each other are declared together, with "and".
+*/
+type fsharpLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newFsharpLanguageSerializer() *fsharpLanguageSerializer {
+	result := &fsharpLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "int"
+	result.typesMap["string"] = "string"
+	result.typesMap["double"] = "float"
+	result.typesMap["float"] = "float32"
+	result.typesMap["char"] = "char"
+	result.typesMap["byte"] = "byte"
+	result.typesMap["date"] = "DateTime"
+
+	return result
+}
+
+func (f *fsharpLanguageSerializer) getType() languageType {
+	return LanguageTypeFsharp
+}
+
+func (f *fsharpLanguageSerializer) getTypeName() string {
+	return "fsharp"
+}
+
+func (f *fsharpLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	names := make([]string, 0)
+	definitions := make(map[string]middleware)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for F#
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		names = append(names, middlewareName(object))
+		definitions[middlewareName(object)] = object
+	}
+
+	dependencies := make(map[string][]string)
+	for _, name := range names {
+		if class, ok := serializerInfo.classes[name]; ok {
+			dependencies[name] = f.classDependencies(class, definitions)
+		}
+	}
+
+	opens := make([]string, 0)
+	serialized := make([]string, 0)
+
+	// F# types must be declared before they are used, and types that use each other are declared together
+	for _, group := range groupByDependencies(names, dependencies) {
+		for i, name := range group {
+			code := ""
+
+			switch o := definitions[name].(type) {
+			case *class:
+				classCode, classOpens := f.serializeClass(o, serializerInfo)
+				for _, open := range classOpens {
+					opens = appendUnique(opens, open)
+				}
+
+				code = classCode
+			case *enum:
+				enumCode, err := f.serializeEnum(o)
+				if err != nil {
+					return nil, err
+				}
+
+				code = enumCode
+			}
+
+			// The rest of the group is chained to the first type, like "type A = ... and B = ..."
+			if i > 0 {
+				code = "and" + strings.TrimPrefix(code, "type")
+			}
+
+			serialized = append(serialized, code)
+		}
+	}
+
+	return []*generatedCode{newGeneratedCode("Models.fs",
+		f.serializeDeclaration(serializerInfo, opens)+strings.Join(serialized, "\n"))}, nil
+}
+
+/**
+Get the classes and enums a class uses, which must be declared before it.
+*/
+func (f *fsharpLanguageSerializer) classDependencies(class *class, definitions map[string]middleware) []string {
+	result := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeFsharp, "type"); ok {
+			continue
+		}
+
+		for _, typeName := range elementTypes(member.memberType) {
+			if _, ok := definitions[typeName]; ok {
+				result = appendUnique(result, typeName)
+			}
+		}
+	}
+
+	return result
+}
+
+/**
+Serialize the header, the namespace and the opens. A file without a package is the "Models" module.
+*/
+func (f *fsharpLanguageSerializer) serializeDeclaration(serializerInfo *serializerInfo, opens []string) string {
+	result := "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+
+	if serializerInfo.packageName != "" {
+		result += fmt.Sprintf("namespace %s\n\n", serializerInfo.packageName)
+	} else {
+		result += "module Models\n\n"
+	}
+
+	if len(opens) == 0 {
+		return result
+	}
+
+	sorted := append([]string{}, opens...)
+	sort.Strings(sorted)
+
+	for _, open := range sorted {
+		result += fmt.Sprintf("open %s\n", open)
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to an F# type.
+Return the namespace the type needs to open, or an empty string if it doesn't need one.
+*/
+func (f *fsharpLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := f.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			return primitiveType, "System", true
+		}
+
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeFsharp)
+
+	return externName, "", isExtern
+}
+
+/**
+Get the F# name of a class or an enum, taking the name annotation into account.
+*/
+func (f *fsharpLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeFsharp, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Serialize the F# type of a gen file type, with "T list" for lists and Map<K, V> for maps.
+Return the namespaces the type needs to open.
+*/
+func (f *fsharpLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, opens := f.typeName(listType, serializerInfo)
+
+		return itemType + " list", opens
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, opens := f.typeName(mapKeyType, serializerInfo)
+		valueType, valueOpens := f.typeName(mapValueType, serializerInfo)
+
+		for _, open := range valueOpens {
+			opens = appendUnique(opens, open)
+		}
+
+		return fmt.Sprintf("Map<%s, %s>", keyType, valueType), opens
+	}
+
+	if knownType, open, isKnown := f.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, open)
+	}
+
+	return f.className(typeName, serializerInfo), []string{}
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Record fields are pascal case, and keep the JSON name in [<JsonPropertyName>].
+*/
+func (f *fsharpLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeFsharp, "name"); ok {
+		return name
+	}
+
+	return toFirstCharUpper(member.name)
+}
+
+/**
+Serialize a class as an immutable record. F# records can't be empty, so an empty class is an empty class type.
+Return the namespaces the record needs to open.
+*/
+func (f *fsharpLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (string, []string) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeFsharp, "name"); ok {
+		className = name
+	}
+
+	opens := findLanguageImports(class.annotations, LanguageTypeFsharp)
+
+	if len(class.dataMembers) == 0 {
+		return fmt.Sprintf("type %s() =\n    class end\n", className), opens
+	}
+
+	opens = appendUnique(opens, "System.Text.Json.Serialization")
+	serializedCode := fmt.Sprintf("type %s =\n    {\n", className)
+
+	for _, member := range class.dataMembers {
+		memberType, memberOpens := f.typeName(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeFsharp, "type"); ok {
+			memberType, memberOpens = overrideType, []string{}
+		}
+
+		for _, open := range append(memberOpens, findLanguageImports(member.annotations, LanguageTypeFsharp)...) {
+			opens = appendUnique(opens, open)
+		}
+
+		// System.Text.Json writes None as null and reads null as None
+		if hasLanguageAnnotation(member.annotations, LanguageTypeFsharp, "optional") {
+			memberType += " option"
+		}
+
+		serializedCode += fmt.Sprintf("        [<JsonPropertyName(\"%s\")>]\n", toCamelCase(member.name))
+		serializedCode += fmt.Sprintf("        %s: %s\n", f.fieldName(member), memberType)
+	}
+
+	return serializedCode + "    }\n", opens
+}
+
+/**
+Serialize an enum as an F# enum, which System.Text.Json writes and reads by its integer values.
+F# enums must have cases, so an empty enum is an error.
+*/
+func (f *fsharpLanguageSerializer) serializeEnum(enum *enum) (string, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeFsharp, "name"); ok {
+		enumName = name
+	}
+
+	if len(enum.enumValues) == 0 {
+		return "", errors.New(fmt.Sprintf("enum %s has no values", enum.name))
+	}
+
+	serializedCode := fmt.Sprintf("type %s =\n", enumName)
+
+	for _, value := range enum.enumValues {
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeFsharp, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("    | %s = %v\n", valueName, value.value)
+	}
+
+	return serializedCode, nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_fsharpLanguageSerializer_getType(t *testing.T) {
+	if got := newFsharpLanguageSerializer().getType(); got != LanguageTypeFsharp {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeFsharp)
+	}
+}
+
+func Test_fsharpLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newFsharpLanguageSerializer().getTypeName(); got != "fsharp" {
+		t.Errorf("getTypeName() = %v, want %v", got, "fsharp")
+	}
+}
+
+func Test_fsharpLanguageSerializer_generateCode(t *testing.T) {
+	f := newFsharpLanguageSerializer()
+
+	order := &class{
+		name: "order",
+		dataMembers: []*dataMember{
+			{memberType: "list<orderItem>", name: "items"},
+			{memberType: "orderStatus", name: "status"},
+		},
+	}
+	orderItem := &class{
+		name:        "orderItem",
+		dataMembers: []*dataMember{{memberType: "date", name: "createdAt"}},
+	}
+	status := &enum{
+		name:       "orderStatus",
+		enumValues: []*enumValue{{name: "active", value: 1}},
+	}
+
+	testService, _ := getTestService()
+	generatedCode, err := f.generateCode([]middleware{order, orderItem, status, testService}, &serializerInfo{packageName: "Shop"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(generatedCode) != 1 || generatedCode[0].fileName != "Models.fs" {
+		t.Errorf("generateCode() should generate only Models.fs")
+		return
+	}
+
+	// Types are declared after the types they use
+	want := "namespace Shop\n\n" +
+		"open System\n" +
+		"open System.Text.Json.Serialization\n\n" +
+		"type OrderItem =\n" +
+		"    {\n" +
+		"        [<JsonPropertyName(\"createdAt\")>]\n" +
+		"        CreatedAt: DateTime\n" +
+		"    }\n\n" +
+		"type OrderStatus =\n" +
+		"    | Active = 1\n\n" +
+		"type Order =\n" +
+		"    {\n" +
+		"        [<JsonPropertyName(\"items\")>]\n" +
+		"        Items: OrderItem list\n" +
+		"        [<JsonPropertyName(\"status\")>]\n" +
+		"        Status: OrderStatus\n" +
+		"    }\n"
+
+	if !strings.HasSuffix(generatedCode[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", generatedCode[0].code, want)
+	}
+
+	// Types that use each other are declared together
+	_ = orderItem.addValue("order", "order", nil)
+	generatedCode, err = f.generateCode([]middleware{order, orderItem, status}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	want = "type OrderStatus =\n" +
+		"    | Active = 1\n\n" +
+		"type Order =\n" +
+		"    {\n" +
+		"        [<JsonPropertyName(\"items\")>]\n" +
+		"        Items: OrderItem list\n" +
+		"        [<JsonPropertyName(\"status\")>]\n" +
+		"        Status: OrderStatus\n" +
+		"    }\n\n" +
+		"and OrderItem =\n" +
+		"    {\n" +
+		"        [<JsonPropertyName(\"createdAt\")>]\n" +
+		"        CreatedAt: DateTime\n" +
+		"        [<JsonPropertyName(\"order\")>]\n" +
+		"        Order: Order\n" +
+		"    }\n"
+
+	if !strings.HasSuffix(generatedCode[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", generatedCode[0].code, want)
+	}
+}
+
+func Test_fsharpLanguageSerializer_serializeDeclaration(t *testing.T) {
+	f := newFsharpLanguageSerializer()
+
+	if got := f.serializeDeclaration(&serializerInfo{}, nil); !strings.HasSuffix(got, "\nmodule Models\n\n") {
+		t.Errorf("serializeDeclaration() without a package = %v, want the Models module", got)
+	}
+}
+
+func Test_fsharpLanguageSerializer_serializeClass(t *testing.T) {
+	tests := []struct {
+		name      string
+		class     *class
+		info      *serializerInfo
+		want      string
+		wantOpens []string
+	}{
+		{
+			name: "Class serialize",
+			class: &class{
+				name: "order",
+				dataMembers: []*dataMember{
+					{memberType: "double", name: "price"},
+					{memberType: "list<int>", name: "counts"},
+					{memberType: "map<string,orderItem>", name: "itemsByName"},
+					{memberType: "string", name: "note", annotations: []*annotation{{namespace: "fsharp", name: "optional"}}},
+				},
+			},
+			info: &serializerInfo{},
+			want: "type Order =\n" +
+				"    {\n" +
+				"        [<JsonPropertyName(\"price\")>]\n" +
+				"        Price: float\n" +
+				"        [<JsonPropertyName(\"counts\")>]\n" +
+				"        Counts: int list\n" +
+				"        [<JsonPropertyName(\"itemsByName\")>]\n" +
+				"        ItemsByName: Map<string, OrderItem>\n" +
+				"        [<JsonPropertyName(\"note\")>]\n" +
+				"        Note: string option\n" +
+				"    }\n",
+			wantOpens: []string{"System.Text.Json.Serialization"},
+		},
+		{
+			name:      "Empty class",
+			class:     &class{name: "test", dataMembers: []*dataMember{}},
+			info:      &serializerInfo{},
+			want:      "type Test() =\n    class end\n",
+			wantOpens: []string{},
+		},
+		{
+			name: "Class with language overrides and extern types",
+			class: &class{
+				name:        "test",
+				annotations: []*annotation{{namespace: "fsharp", name: "name", arguments: []string{"Renamed"}}},
+				dataMembers: []*dataMember{
+					{
+						memberType:  "money",
+						name:        "price",
+						annotations: []*annotation{{namespace: "fsharp", name: "name", arguments: []string{"Cost"}}},
+					},
+					{
+						memberType: "string",
+						name:       "id",
+						annotations: []*annotation{
+							{namespace: "fsharp", name: "type", arguments: []string{"Guid"}},
+							{namespace: "fsharp", name: "import", arguments: []string{"System"}},
+						},
+					},
+				},
+			},
+			info: &serializerInfo{externTypes: map[string]*externType{
+				"money": {
+					name:          "money",
+					languageNames: map[languageType]string{LanguageTypeFsharp: "Acme.Money"},
+				},
+			}},
+			want: "type Renamed =\n" +
+				"    {\n" +
+				"        [<JsonPropertyName(\"price\")>]\n" +
+				"        Cost: Acme.Money\n" +
+				"        [<JsonPropertyName(\"id\")>]\n" +
+				"        Id: Guid\n" +
+				"    }\n",
+			wantOpens: []string{"System.Text.Json.Serialization", "System"},
+		},
+		{
+			name: "Class with renamed types",
+			class: &class{
+				name: "holder",
+				dataMembers: []*dataMember{
+					{memberType: "event", name: "e"},
+					{memberType: "kind", name: "kind"},
+				},
+			},
+			info: getTestRenamedTypesInfo(),
+			want: "type Holder =\n" +
+				"    {\n" +
+				"        [<JsonPropertyName(\"e\")>]\n" +
+				"        E: Evt\n" +
+				"        [<JsonPropertyName(\"kind\")>]\n" +
+				"        Kind: Category\n" +
+				"    }\n",
+			wantOpens: []string{"System.Text.Json.Serialization"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, opens := newFsharpLanguageSerializer().serializeClass(tt.class, tt.info)
+			if got != tt.want {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(opens, tt.wantOpens) {
+				t.Errorf("serializeClass() opens = %v, want %v", opens, tt.wantOpens)
+			}
+		})
+	}
+}
+
+func Test_fsharpLanguageSerializer_serializeEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		enum    *enum
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			enum: &enum{
+				name: "orderStatus",
+				enumValues: []*enumValue{
+					{name: "active", value: 5},
+					{name: "onHold", value: 8, annotations: []*annotation{
+						{namespace: "fsharp", name: "name", arguments: []string{"Paused"}},
+					}},
+				},
+			},
+			want: "type OrderStatus =\n" +
+				"    | Active = 5\n" +
+				"    | Paused = 8\n",
+		},
+		{
+			name:    "Empty enum",
+			enum:    &enum{name: "test", enumValues: []*enumValue{}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newFsharpLanguageSerializer().serializeEnum(tt.enum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
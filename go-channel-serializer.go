@@ -0,0 +1,70 @@
+package main
+
+import "fmt"
+
+/**
+Serialize all the channels to a single file with the topic constants, and typed publisher
+and subscriber interfaces for every channel. Channels with a key also get a function which
+extracts the key from the message.
+*/
+func (g *goLanguageSerializer) serializeChannels(channels []*channel, serializerInfo *serializerInfo) (*generatedCode, error) {
+	imports := []string{"context"}
+
+	constantsCode := "const (\n"
+	channelsCode := ""
+
+	for _, c := range channels {
+		typeName := topicTypeName(c.topic)
+		payloadName := g.structName(c.payloadType, serializerInfo)
+
+		constantsCode += fmt.Sprintf("\t%sTopic = \"%s\"\n", typeName, c.topic)
+
+		parameters := fmt.Sprintf("ctx context.Context, message *%s", payloadName)
+
+		if len(c.headers) > 0 {
+			parameters += fmt.Sprintf(", headers *%sHeaders", typeName)
+			channelsCode += fmt.Sprintf("// %sHeaders are the headers of %s messages\n", typeName, c.topic)
+			channelsCode += fmt.Sprintf("type %sHeaders struct {\n", typeName)
+
+			for _, header := range c.headers {
+				headerType := header.headerType
+				if knownType, imp, isKnown := g.mapType(header.headerType, serializerInfo); isKnown {
+					headerType = knownType
+					imports = appendImport(imports, imp)
+				}
+
+				channelsCode += fmt.Sprintf("\t%s %s\n", toFirstCharUpper(header.name), headerType)
+			}
+
+			channelsCode += "}\n\n"
+		}
+
+		channelsCode += fmt.Sprintf("// %sPublisher publishes %s messages to %s\n", typeName, payloadName, c.topic)
+		channelsCode += fmt.Sprintf("type %sPublisher interface {\n\tPublish%s(%s) error\n}\n\n",
+			typeName, typeName, parameters)
+
+		channelsCode += fmt.Sprintf("// %sSubscriber handles %s messages from %s\n", typeName, payloadName, c.topic)
+		channelsCode += fmt.Sprintf("type %sSubscriber interface {\n\tHandle%s(%s) error\n}\n\n",
+			typeName, typeName, parameters)
+
+		if c.keyField != "" {
+			fieldName := toFirstCharUpper(c.keyField)
+			if member := findDataMember(serializerInfo.classes[c.payloadType], c.keyField); member != nil {
+				fieldName = g.fieldName(member)
+			}
+
+			imports = appendUnique(imports, "fmt")
+			channelsCode += fmt.Sprintf("// %sKey returns the key of %s messages\n", typeName, c.topic)
+			channelsCode += fmt.Sprintf("func %sKey(message *%s) string {\n\treturn fmt.Sprint(message.%s)\n}\n\n",
+				typeName, payloadName, fieldName)
+		}
+	}
+
+	constantsCode += ")\n\n"
+
+	// Delete the last empty line
+	channelsCode = channelsCode[:len(channelsCode)-1]
+
+	return newGeneratedCode("channels.go",
+		g.serializeDeclaration(serializerInfo)+g.serializeImports(imports)+constantsCode+channelsCode), nil
+}
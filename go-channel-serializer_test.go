@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_goLanguageSerializer_serializeChannels(t *testing.T) {
+	g := newGoLanguageSerializer()
+	channels, info := getTestChannels()
+
+	got, err := g.serializeChannels(channels, info)
+	if err != nil {
+		t.Errorf("serializeChannels() error = %v", err)
+		return
+	}
+
+	if got.fileName != "channels.go" {
+		t.Errorf("serializeChannels() fileName = %v, want %v", got.fileName, "channels.go")
+	}
+
+	expectedParts := []string{
+		"package models",
+		"OrdersCreatedTopic = \"orders.created\"",
+		"type OrdersCreatedHeaders struct {\n\tTraceId string\n}",
+		"PublishOrdersCreated(ctx context.Context, message *OrderCreated, headers *OrdersCreatedHeaders) error",
+		"HandleOrdersClosed(ctx context.Context, message *OrderCreated) error",
+		"func OrdersCreatedKey(message *OrderCreated) string {\n\treturn fmt.Sprint(message.OrderId)\n}",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeChannels() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+
+	if strings.Contains(got.code, "OrdersClosedKey") {
+		t.Errorf("serializeChannels() created a key function for a channel without key")
+	}
+}
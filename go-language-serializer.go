@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -36,13 +37,42 @@ func (g *goLanguageSerializer) getTypeName() string {
 func (g *goLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
 	result := make([]*generatedCode, 0)
 
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
 	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Channels are serialized together in the end
+		if object.getType() == middlewareTypeExtern || object.getType() == middlewareTypeChannel {
+			continue
+		}
+
 		serialized, err := g.serializeMiddleware(object, serializerInfo)
 		if err != nil {
 			return nil, err
 		}
 
 		result = append(result, serialized)
+
+		// Services get a server file next to the client
+		if service, ok := object.(*service); ok {
+			server, err := g.serializeServiceServer(service, serializerInfo)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, server)
+		}
+	}
+
+	if channels := collectChannels(objects); len(channels) > 0 {
+		serialized, err := g.serializeChannels(channels, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
 	}
 
 	return result, nil
@@ -57,6 +87,10 @@ func (g *goLanguageSerializer) serializeMiddleware(middleware middleware, serial
 		return g.serializeEnum(enum, serializerInfo)
 	}
 
+	if service, ok := middleware.(*service); ok {
+		return g.serializeService(service, serializerInfo)
+	}
+
 	return nil, errors.New("tried to serialize unknown middleware type")
 }
 
@@ -73,79 +107,187 @@ func (g *goLanguageSerializer) serializeDeclaration(serializerInfo *serializerIn
 	return fmt.Sprintf("package %s\n\n", serializerInfo.packageName) + generatedMark
 }
 
+/**
+Serialize the import block. Go allows the imports to come after the generated mark comment.
+*/
+func (g *goLanguageSerializer) serializeImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	result := "import (\n"
+	for _, imp := range imports {
+		result += fmt.Sprintf("\t\"%s\"\n", imp)
+	}
+
+	return result + ")\n\n"
+}
+
+/**
+Map a gen file type which Go uses by value - a primitive or an extern type.
+Return the import the type needs, or an empty string if it doesn't need one.
+Return false for other types, which we use as pointers.
+*/
+func (g *goLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := g.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeGo)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "github.com/acme/money.Money" is imported from "github.com/acme/money" and used as "money.Money"
+	dot := strings.LastIndex(externName, ".")
+	slash := strings.LastIndex(externName, "/")
+	if dot == -1 || dot < slash {
+		return externName, "", true
+	}
+
+	return externName[slash+1:], externName[:dot], true
+}
+
+/**
+Get the struct name of a class, taking the name annotation into account.
+*/
+func (g *goLanguageSerializer) structName(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeGo, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the Go name of a class or an enum a data member uses, taking their name annotations into account.
+*/
+func (g *goLanguageSerializer) referenceName(typeName string, serializerInfo *serializerInfo) string {
+	if _, ok := serializerInfo.classes[typeName]; ok {
+		return g.structName(typeName, serializerInfo)
+	}
+
+	if name, ok := findLanguageName(typeName, LanguageTypeGo, serializerInfo); ok {
+		return name
+	}
+
+	return typeName
+}
+
+/**
+Get the struct field name of a data member, taking the name annotation into account.
+*/
+func (g *goLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeGo, "name"); ok {
+		return name
+	}
+
+	return toFirstCharUpper(member.name)
+}
+
 func (g *goLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
-	serializedCode := g.serializeDeclaration(serializerInfo)
+	serializedCode := ""
 	fileName := fmt.Sprintf("%s.go", class.name)
 
-	serializedCode += fmt.Sprintf("type %s struct {\n", toFirstCharUpper(class.name))
+	structName := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeGo, "name"); ok {
+		structName = name
+	}
+
+	imports := findLanguageImports(class.annotations, LanguageTypeGo)
+
+	serializedCode += fmt.Sprintf("type %s struct {\n", structName)
 
 	for _, member := range class.dataMembers {
-		if isList, listType := isList(member.memberType); isList {
-			// If the list type isn't primitive, we put it as a pointer
-			pointerMark := ""
-			if _, shouldNotBePointer := g.typesMap[listType]; !shouldNotBePointer {
-				pointerMark = "*"
-			}
+		fieldName := g.fieldName(member)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeGo) {
+			imports = appendUnique(imports, imp)
+		}
+
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeGo, "type"); ok {
+			serializedCode += fmt.Sprintf("\t%s %s `json:\"%s\"`\n",
+				fieldName, overrideType, toCamelCase(member.name))
+			continue
+		}
 
-			primitiveType, isPrimitive := g.typesMap[listType]
-			if isPrimitive {
-				listType = primitiveType
+		if isList, listType := isList(member.memberType); isList {
+			// If the list type isn't primitive or extern, we put it as a pointer
+			pointerMark := "*"
+			if knownType, imp, isKnown := g.mapType(listType, serializerInfo); isKnown {
+				listType = knownType
+				imports = appendImport(imports, imp)
+				pointerMark = ""
+			} else {
+				listType = g.referenceName(listType, serializerInfo)
 			}
 
 			serializedCode += fmt.Sprintf("\t%s []%s%s `json:\"%s\"`\n",
-				toFirstCharUpper(member.name), pointerMark, listType, toCamelCase(member.name))
+				fieldName, pointerMark, listType, toCamelCase(member.name))
 			continue
 		}
 
 		if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
-			// If the map value type isn't primitive, we put it as a pointer
+			// If the map value type isn't primitive or extern, we put it as a pointer
 			// We assume the key is a primitive
-			pointerMark := ""
-			if _, shouldNotBePointer := g.typesMap[mapValueType]; !shouldNotBePointer {
-				pointerMark = "*"
-			}
-
-			primitiveType, isPrimitive := g.typesMap[mapKeyType]
-			if isPrimitive {
-				mapKeyType = primitiveType
+			if knownType, imp, isKnown := g.mapType(mapKeyType, serializerInfo); isKnown {
+				mapKeyType = knownType
+				imports = appendImport(imports, imp)
+			} else {
+				mapKeyType = g.referenceName(mapKeyType, serializerInfo)
 			}
 
-			primitiveType, isPrimitive = g.typesMap[mapValueType]
-			if isPrimitive {
-				mapValueType = primitiveType
+			pointerMark := "*"
+			if knownType, imp, isKnown := g.mapType(mapValueType, serializerInfo); isKnown {
+				mapValueType = knownType
+				imports = appendImport(imports, imp)
+				pointerMark = ""
+			} else {
+				mapValueType = g.referenceName(mapValueType, serializerInfo)
 			}
 
-			serializedCode += fmt.Sprintf("\t%s map[%s]%s%s `json:\"%s\"`\n", toFirstCharUpper(member.name),
+			serializedCode += fmt.Sprintf("\t%s map[%s]%s%s `json:\"%s\"`\n", fieldName,
 				mapKeyType, pointerMark, mapValueType, toCamelCase(member.name))
 			continue
 		}
 
-		if primitiveType, ok := g.typesMap[member.memberType]; ok {
+		if knownType, imp, isKnown := g.mapType(member.memberType, serializerInfo); isKnown {
+			imports = appendImport(imports, imp)
 			serializedCode += fmt.Sprintf("\t%s %s `json:\"%s\"`\n",
-				toFirstCharUpper(member.name), primitiveType, toCamelCase(member.name))
+				fieldName, knownType, toCamelCase(member.name))
 			continue
 		}
 
 		// It's not a language type, so we create it as a pointer
 		serializedCode += fmt.Sprintf("\t%s *%s `json:\"%s\"`\n",
-			toFirstCharUpper(member.name), member.memberType, toCamelCase(member.name))
+			fieldName, g.referenceName(member.memberType, serializerInfo), toCamelCase(member.name))
 	}
 
 	serializedCode += "}"
 
-	return newGeneratedCode(fileName, serializedCode), nil
+	return newGeneratedCode(fileName,
+		g.serializeDeclaration(serializerInfo)+g.serializeImports(imports)+serializedCode), nil
 }
 
 func (g *goLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
 	serializedCode := g.serializeDeclaration(serializerInfo)
 	fileName := fmt.Sprintf("%s.go", enum.name)
 
+	enumName := enum.name
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeGo, "name"); ok {
+		enumName = name
+	}
+
 	serializedCode += fmt.Sprintf("type %s int\n\n"+
-		"const (\n", enum.name)
+		"const (\n", enumName)
 
 	for _, value := range enum.enumValues {
-		serializedCode += fmt.Sprintf("\t%s%s = %s(%v)\n", enum.name,
-			toFirstCharUpper(value.name), enum.name, value.value)
+		valueName := enumName + toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeGo, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("\t%s = %s(%v)\n", valueName, enumName, value.value)
 	}
 
 	serializedCode += ")"
@@ -224,6 +224,89 @@ func Test_goLanguageSerializer_serializeClass(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+							annotations: []*annotation{
+								{namespace: "go", name: "type", arguments: []string{"time.Time"}},
+								{namespace: "go", name: "import", arguments: []string{"time"}},
+								{namespace: "kotlin", name: "type", arguments: []string{"Instant"}},
+								{namespace: "kotlin", name: "import", arguments: []string{"java.time.Instant"}},
+								{namespace: "ts", name: "type", arguments: []string{"string"}},
+								{namespace: "csharp", name: "type", arguments: []string{"DateTimeOffset"}},
+								{namespace: "csharp", name: "import", arguments: []string{"System"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "go", name: "name", arguments: []string{"ID"}},
+								{namespace: "csharp", name: "name", arguments: []string{"ID"}},
+							},
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{packageName: "bla"},
+			},
+			want: &generatedCode{
+				fileName: "test.go",
+				code: "import (\n\t\"time\"\n)\n\n" +
+					"type Test struct {\n\tCreatedAt time.Time `json:\"createdAt\"`\n\tID string `json:\"id\"`\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+						{
+							memberType: "list<Money>",
+							name:       "history",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{packageName: "bla", externTypes: getTestExternTypes()},
+			},
+			want: &generatedCode{
+				fileName: "test.go",
+				code: "import (\n\t\"github.com/acme/money\"\n)\n\n" +
+					"type Test struct {\n\tPrice money.Money `json:\"price\"`\n\tHistory []money.Money `json:\"history\"`\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "list<event>", name: "es"},
+						{memberType: "map<kind,event>", name: "byKind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "holder.go",
+				code: "type Holder struct {\n\tE *Evt `json:\"e\"`\n\tEs []*Evt `json:\"es\"`\n" +
+					"\tByKind map[Category]*Evt `json:\"byKind\"`\n}",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Class with struct",
 			args: args{
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/**
+Serialize a service to a Go interface, a typed error and an HTTP client based on net/http.
+The request and response types are the structs generated for the classes.
+*/
+func (g *goLanguageSerializer) serializeService(service *service, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.go", service.name)
+	serviceName := toFirstCharUpper(service.name)
+
+	imports := []string{"bytes", "context", "encoding/json", "fmt", "io", "net/http"}
+
+	interfaceCode := fmt.Sprintf("type %s interface {\n", serviceName)
+	clientCode := ""
+
+	for _, method := range service.methods {
+		signature := g.serializeMethodSignature(method, serializerInfo)
+		interfaceCode += fmt.Sprintf("\t%s\n", signature)
+
+		path, pathImports := g.serializePath(method, serializerInfo)
+		for _, imp := range pathImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		body := "nil"
+		if hasRequestBody(method) {
+			body = "request"
+		}
+
+		clientCode += fmt.Sprintf("func (c *%sClient) %s {\n", serviceName, signature)
+
+		if query := g.serializeQuery(method, serializerInfo); query != "" {
+			clientCode += query + "\n"
+			path += " + \"?\" + query.Encode()"
+			imports = appendUnique(imports, "net/url")
+		}
+
+		clientCode += fmt.Sprintf("\tresponse := &%s{}\n", g.structName(method.responseType, serializerInfo))
+		clientCode += fmt.Sprintf("\tif err := c.send(ctx, \"%s\", %s, %s, response); err != nil {\n"+
+			"\t\treturn nil, err\n\t}\n\n\treturn response, nil\n}\n\n", method.httpMethod, path, body)
+	}
+
+	interfaceCode += "}\n\n"
+
+	sort.Strings(imports)
+
+	serializedCode := g.serializeDeclaration(serializerInfo) + g.serializeImports(imports) +
+		interfaceCode + fmt.Sprintf(goServiceErrorTemplate, serviceName) +
+		fmt.Sprintf(goServiceClientTemplate, serviceName) + clientCode +
+		fmt.Sprintf(goServiceSendTemplate, serviceName)
+
+	return newGeneratedCode(fileName, serializedCode), nil
+}
+
+/**
+Serialize the method signature, like:
+GetUser(ctx context.Context, request *GetUserRequest) (*GetUserResponse, error)
+*/
+func (g *goLanguageSerializer) serializeMethodSignature(method *serviceMethod, serializerInfo *serializerInfo) string {
+	parameters := "ctx context.Context"
+	if method.requestType != "" {
+		parameters += fmt.Sprintf(", request *%s", g.structName(method.requestType, serializerInfo))
+	}
+
+	return fmt.Sprintf("%s(%s) (*%s, error)", toFirstCharUpper(method.name), parameters,
+		g.structName(method.responseType, serializerInfo))
+}
+
+/**
+Serialize the method path to a Go expression. Path parameters are taken from the request.
+Return the imports the expression needs.
+*/
+func (g *goLanguageSerializer) serializePath(method *serviceMethod, serializerInfo *serializerInfo) (string, []string) {
+	parts := make([]string, 0)
+	imports := make([]string, 0)
+
+	for _, part := range splitPath(method.path) {
+		if !part.isParameter {
+			parts = append(parts, strconv.Quote(part.value))
+			continue
+		}
+
+		fieldName := toFirstCharUpper(part.value)
+		if member := findDataMember(serializerInfo.classes[method.requestType], part.value); member != nil {
+			fieldName = g.fieldName(member)
+		}
+
+		parts = append(parts, fmt.Sprintf("url.PathEscape(fmt.Sprint(request.%s))", fieldName))
+		imports = appendUnique(imports, "net/url")
+	}
+
+	if len(parts) == 0 {
+		return "\"\"", imports
+	}
+
+	return strings.Join(parts, " + "), imports
+}
+
+/**
+Serialize the code which puts the query parameters of a method to url.Values, like:
+query.Set("verbose", fmt.Sprint(request.Verbose))
+Return an empty string if the method has no query parameters.
+*/
+func (g *goLanguageSerializer) serializeQuery(method *serviceMethod, serializerInfo *serializerInfo) string {
+	members := queryMembers(method, serializerInfo.classes)
+	if len(members) == 0 {
+		return ""
+	}
+
+	result := "\tquery := url.Values{}\n"
+	for _, member := range members {
+		value := fmt.Sprintf("fmt.Sprint(request.%s)", g.fieldName(member))
+		if member.memberType == "string" {
+			value = fmt.Sprintf("request.%s", g.fieldName(member))
+		}
+
+		result += fmt.Sprintf("\tquery.Set(\"%s\", %s)\n", toCamelCase(member.name), value)
+	}
+
+	return result
+}
+
+const goServiceErrorTemplate = `// %[1]sError is returned when the server responds with a status which isn't 2xx
+type %[1]sError struct {
+	StatusCode int    ` + "`json:\"-\"`" + `
+	Message    string ` + "`json:\"message\"`" + `
+}
+
+func (e *%[1]sError) Error() string {
+	return fmt.Sprintf("request failed with status %%d: %%s", e.StatusCode, e.Message)
+}
+
+`
+
+const goServiceClientTemplate = `// %[1]sClient implements %[1]s over HTTP
+type %[1]sClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+func New%[1]sClient(baseURL string, httpClient *http.Client) *%[1]sClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &%[1]sClient{BaseURL: baseURL, HTTPClient: httpClient}
+}
+
+`
+
+const goServiceSendTemplate = `func (c *%[1]sClient) send(ctx context.Context, method string, path string, request interface{}, response interface{}) error {
+	var body io.Reader
+	if request != nil {
+		encoded, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(encoded)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	httpResponse, err := c.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		message, _ := io.ReadAll(httpResponse.Body)
+		serviceError := &%[1]sError{}
+		if json.Unmarshal(message, serviceError) != nil || serviceError.Message == "" {
+			serviceError.Message = string(message)
+		}
+
+		serviceError.StatusCode = httpResponse.StatusCode
+
+		return serviceError
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}`
+
+/**
+Serialize the server side of a service - an http.Handler which routes the requests to a
+UserService implementation. The handler decodes the request, calls the implementation, and
+encodes the response. A returned service error is written with its status code.
+*/
+func (g *goLanguageSerializer) serializeServiceServer(service *service, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%sServer.go", service.name)
+	serviceName := toFirstCharUpper(service.name)
+
+	imports := []string{"encoding/json", "errors", "net/http"}
+	routesCode := ""
+	handlersCode := ""
+
+	for _, method := range service.methods {
+		methodName := toFirstCharUpper(method.name)
+		routesCode += fmt.Sprintf("\thandler.mux.HandleFunc(\"%s %s\", handler.handle%s)\n",
+			method.httpMethod, method.path, methodName)
+
+		handlersCode += fmt.Sprintf("func (h *%sHandler) handle%s(w http.ResponseWriter, r *http.Request) {\n",
+			serviceName, methodName)
+
+		if method.requestType == "" {
+			handlersCode += fmt.Sprintf("\tresponse, err := h.service.%s(r.Context())\n", methodName)
+			handlersCode += "\th.writeResponse(w, response, err)\n}\n\n"
+			continue
+		}
+
+		handlersCode += fmt.Sprintf("\trequest := &%s{}\n", g.structName(method.requestType, serializerInfo))
+
+		if hasRequestBody(method) {
+			handlersCode += "\tif err := json.NewDecoder(r.Body).Decode(request); err != nil {\n" +
+				"\t\th.writeError(w, &" + serviceName + "Error{StatusCode: http.StatusBadRequest, Message: err.Error()})\n" +
+				"\t\treturn\n\t}\n"
+		}
+
+		for _, parameter := range pathParameters(method.path) {
+			parameterCode, parameterImports := g.serializePathParameterDecode(method, parameter, serviceName, serializerInfo)
+			handlersCode += parameterCode
+			for _, imp := range parameterImports {
+				imports = appendUnique(imports, imp)
+			}
+		}
+
+		for _, member := range queryMembers(method, serializerInfo.classes) {
+			parameterCode, parameterImports := g.serializeQueryParameterDecode(member, serviceName)
+			handlersCode += parameterCode
+			for _, imp := range parameterImports {
+				imports = appendUnique(imports, imp)
+			}
+		}
+
+		handlersCode += fmt.Sprintf("\n\tresponse, err := h.service.%s(r.Context(), request)\n", methodName)
+		handlersCode += "\th.writeResponse(w, response, err)\n}\n\n"
+	}
+
+	sort.Strings(imports)
+
+	serializedCode := g.serializeDeclaration(serializerInfo) + g.serializeImports(imports) +
+		fmt.Sprintf(goServiceHandlerTemplate, serviceName, routesCode) + handlersCode +
+		fmt.Sprintf(goServiceWriteTemplate, serviceName)
+
+	return newGeneratedCode(fileName, serializedCode), nil
+}
+
+/**
+Serialize the code which reads a path parameter into the request.
+Strings are assigned as is, and other types are scanned with fmt.
+*/
+func (g *goLanguageSerializer) serializePathParameterDecode(method *serviceMethod, parameter string,
+	serviceName string, serializerInfo *serializerInfo) (string, []string) {
+	fieldName := toFirstCharUpper(parameter)
+	memberType := "string"
+
+	if member := findDataMember(serializerInfo.classes[method.requestType], parameter); member != nil {
+		fieldName = g.fieldName(member)
+		memberType = member.memberType
+	}
+
+	if memberType == "string" {
+		return fmt.Sprintf("\trequest.%s = r.PathValue(\"%s\")\n", fieldName, parameter), []string{}
+	}
+
+	return fmt.Sprintf("\tif _, err := fmt.Sscan(r.PathValue(\"%[1]s\"), &request.%[2]s); err != nil {\n"+
+		"\t\th.writeError(w, &%[3]sError{StatusCode: http.StatusBadRequest, Message: \"invalid path parameter %[1]s\"})\n"+
+		"\t\treturn\n\t}\n", parameter, fieldName, serviceName), []string{"fmt"}
+}
+
+/**
+Serialize the code which reads a query parameter into the request. A missing parameter leaves the zero value.
+Strings are assigned as is, and other types are scanned with fmt.
+*/
+func (g *goLanguageSerializer) serializeQueryParameterDecode(member *dataMember, serviceName string) (string, []string) {
+	parameter := toCamelCase(member.name)
+
+	if member.memberType == "string" {
+		return fmt.Sprintf("\trequest.%s = r.URL.Query().Get(\"%s\")\n", g.fieldName(member), parameter), []string{}
+	}
+
+	return fmt.Sprintf("\tif value := r.URL.Query().Get(\"%[1]s\"); value != \"\" {\n"+
+		"\t\tif _, err := fmt.Sscan(value, &request.%[2]s); err != nil {\n"+
+		"\t\t\th.writeError(w, &%[3]sError{StatusCode: http.StatusBadRequest, Message: \"invalid query parameter %[1]s\"})\n"+
+		"\t\t\treturn\n\t\t}\n\t}\n", parameter, g.fieldName(member), serviceName), []string{"fmt"}
+}
+
+const goServiceHandlerTemplate = `// %[1]sHandler serves %[1]s over HTTP by calling the given implementation
+type %[1]sHandler struct {
+	service %[1]s
+	mux     *http.ServeMux
+}
+
+func New%[1]sHandler(service %[1]s) *%[1]sHandler {
+	handler := &%[1]sHandler{service: service, mux: http.NewServeMux()}
+
+%[2]s
+	return handler
+}
+
+func (h *%[1]sHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+`
+
+const goServiceWriteTemplate = `func (h *%[1]sHandler) writeResponse(w http.ResponseWriter, response interface{}, err error) {
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// writeError writes the error as JSON. Errors which aren't %[1]sError are written as internal server errors
+func (h *%[1]sHandler) writeError(w http.ResponseWriter, err error) {
+	serviceError := &%[1]sError{}
+	if !errors.As(err, &serviceError) || serviceError.StatusCode == 0 {
+		serviceError = &%[1]sError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    http.StatusText(http.StatusInternalServerError),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(serviceError.StatusCode)
+	_ = json.NewEncoder(w).Encode(serviceError)
+}`
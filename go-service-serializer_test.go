@@ -0,0 +1,189 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_goLanguageSerializer_serializeService(t *testing.T) {
+	g := newGoLanguageSerializer()
+	userService, info := getTestService()
+
+	got, err := g.serializeService(userService, info)
+	if err != nil {
+		t.Errorf("serializeService() error = %v", err)
+		return
+	}
+
+	if got.fileName != "userService.go" {
+		t.Errorf("serializeService() fileName = %v, want %v", got.fileName, "userService.go")
+	}
+
+	expectedParts := []string{
+		"package models",
+		"\t\"net/url\"\n",
+		"type UserService interface {\n" +
+			"\tGetUser(ctx context.Context, request *GetUserRequest) (*User, error)\n" +
+			"\tCreateUser(ctx context.Context, request *CreateUserRequest) (*User, error)\n" +
+			"\tPing(ctx context.Context) (*User, error)\n}",
+		"type UserServiceError struct {",
+		"func NewUserServiceClient(baseURL string, httpClient *http.Client) *UserServiceClient {",
+		"\tquery := url.Values{}\n" +
+			"\tquery.Set(\"verbose\", fmt.Sprint(request.Verbose))\n\n" +
+			"\tresponse := &User{}\n" +
+			"\tif err := c.send(ctx, \"GET\", \"/users/\" + url.PathEscape(fmt.Sprint(request.ID)) + \"?\" + query.Encode(), nil, response); err != nil {",
+		"c.send(ctx, \"POST\", \"/users\", request, response)",
+		"c.send(ctx, \"GET\", \"/ping\", nil, response)",
+		"func (c *UserServiceClient) send(",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeService() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+}
+
+func Test_goLanguageSerializer_serializePath(t *testing.T) {
+	g := newGoLanguageSerializer()
+	info := &serializerInfo{}
+
+	type args struct {
+		method *serviceMethod
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 []string
+	}{
+		{
+			name:  "Without parameters",
+			args:  args{method: &serviceMethod{path: "/users"}},
+			want:  "\"/users\"",
+			want1: []string{},
+		},
+		{
+			name:  "With parameter",
+			args:  args{method: &serviceMethod{path: "/users/{id}/orders"}},
+			want:  "\"/users/\" + url.PathEscape(fmt.Sprint(request.Id)) + \"/orders\"",
+			want1: []string{"net/url"},
+		},
+		{
+			name:  "Empty path",
+			args:  args{method: &serviceMethod{path: ""}},
+			want:  "\"\"",
+			want1: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := g.serializePath(tt.args.method, info)
+			if got != tt.want {
+				t.Errorf("serializePath() got = %v, want %v", got, tt.want)
+			}
+			if len(got1) != len(tt.want1) {
+				t.Errorf("serializePath() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_goLanguageSerializer_serializeServiceServer(t *testing.T) {
+	g := newGoLanguageSerializer()
+	userService, info := getTestService()
+
+	got, err := g.serializeServiceServer(userService, info)
+	if err != nil {
+		t.Errorf("serializeServiceServer() error = %v", err)
+		return
+	}
+
+	if got.fileName != "userServiceServer.go" {
+		t.Errorf("serializeServiceServer() fileName = %v, want %v", got.fileName, "userServiceServer.go")
+	}
+
+	expectedParts := []string{
+		"\t\"fmt\"\n",
+		"func NewUserServiceHandler(service UserService) *UserServiceHandler {",
+		"\thandler.mux.HandleFunc(\"GET /users/{id}\", handler.handleGetUser)\n" +
+			"\thandler.mux.HandleFunc(\"POST /users\", handler.handleCreateUser)\n" +
+			"\thandler.mux.HandleFunc(\"GET /ping\", handler.handlePing)\n",
+		"func (h *UserServiceHandler) handleGetUser(w http.ResponseWriter, r *http.Request) {\n" +
+			"\trequest := &GetUserRequest{}\n" +
+			"\trequest.ID = r.PathValue(\"id\")\n" +
+			"\tif value := r.URL.Query().Get(\"verbose\"); value != \"\" {\n" +
+			"\t\tif _, err := fmt.Sscan(value, &request.Verbose); err != nil {\n" +
+			"\t\t\th.writeError(w, &UserServiceError{StatusCode: http.StatusBadRequest, Message: \"invalid query parameter verbose\"})\n" +
+			"\t\t\treturn\n\t\t}\n\t}\n",
+		"\trequest := &CreateUserRequest{}\n" +
+			"\tif err := json.NewDecoder(r.Body).Decode(request); err != nil {\n",
+		"\tresponse, err := h.service.Ping(r.Context())\n",
+		"func (h *UserServiceHandler) writeError(w http.ResponseWriter, err error) {",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeServiceServer() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+
+}
+
+func Test_goLanguageSerializer_serializePathParameterDecode(t *testing.T) {
+	g := newGoLanguageSerializer()
+
+	request := newClass("request")
+	_ = request.addValue("version", "int", nil)
+	info := &serializerInfo{classes: collectClasses([]middleware{request})}
+	method := &serviceMethod{requestType: "request", path: "/items/{version}"}
+
+	got, got1 := g.serializePathParameterDecode(method, "version", "ItemService", info)
+
+	want := "\tif _, err := fmt.Sscan(r.PathValue(\"version\"), &request.Version); err != nil {\n" +
+		"\t\th.writeError(w, &ItemServiceError{StatusCode: http.StatusBadRequest, Message: \"invalid path parameter version\"})\n" +
+		"\t\treturn\n\t}\n"
+	if got != want {
+		t.Errorf("serializePathParameterDecode() got = %v, want %v", got, want)
+	}
+
+	if len(got1) != 1 || got1[0] != "fmt" {
+		t.Errorf("serializePathParameterDecode() got1 = %v, want %v", got1, []string{"fmt"})
+	}
+}
+
+func Test_goLanguageSerializer_serializeQueryParameterDecode(t *testing.T) {
+	g := newGoLanguageSerializer()
+
+	got, got1 := g.serializeQueryParameterDecode(&dataMember{name: "filter", memberType: "string"}, "ItemService")
+
+	want := "\trequest.Filter = r.URL.Query().Get(\"filter\")\n"
+	if got != want {
+		t.Errorf("serializeQueryParameterDecode() got = %v, want %v", got, want)
+	}
+
+	if len(got1) != 0 {
+		t.Errorf("serializeQueryParameterDecode() got1 = %v, want no imports", got1)
+	}
+}
+
+func Test_goLanguageSerializer_generateCode_service(t *testing.T) {
+	g := newGoLanguageSerializer()
+	userService, info := getTestService()
+
+	objects := []middleware{userService}
+	for _, c := range info.classes {
+		objects = append(objects, c)
+	}
+
+	generatedCode, err := g.generateCode(objects, &serializerInfo{packageName: "models"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	// The classes, the service client and the service server
+	if len(generatedCode) != len(info.classes)+2 {
+		t.Errorf("generateCode() generated %v files. expected %v", len(generatedCode), len(info.classes)+2)
+	}
+}
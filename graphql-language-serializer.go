@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/**
+A key/value type which replaces a map, since GraphQL doesn't have maps.
+The key and value types are gen file types.
+*/
+type graphQLEntry struct {
+	name      string
+	keyType   string
+	valueType string
+}
+
+/**
+Generate a GraphQL schema with a type and an input for every class, and an enum for every enum.
+*/
+type graphQLLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newGraphQLLanguageSerializer() *graphQLLanguageSerializer {
+	result := &graphQLLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "Boolean"
+	result.typesMap["int"] = "Int"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "Float"
+	result.typesMap["float"] = "Float"
+	result.typesMap["char"] = "String"
+	result.typesMap["byte"] = "Int"
+	result.typesMap["date"] = "DateTime"
+
+	return result
+}
+
+func (g *graphQLLanguageSerializer) getType() languageType {
+	return LanguageTypeGraphQL
+}
+
+func (g *graphQLLanguageSerializer) getTypeName() string {
+	return "graphql"
+}
+
+func (g *graphQLLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	definitions := make([]string, 0)
+	entries := make([]*graphQLEntry, 0)
+	usesDate := false
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			for _, member := range o.dataMembers {
+				if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeGraphQL, "type"); ok {
+					continue
+				}
+
+				if isList, listType := isList(member.memberType); isList {
+					usesDate = usesDate || listType == "date"
+				} else if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
+					usesDate = usesDate || mapKeyType == "date" || mapValueType == "date"
+					entries = g.appendEntry(entries, mapKeyType, mapValueType, serializerInfo)
+				} else {
+					usesDate = usesDate || member.memberType == "date"
+				}
+			}
+
+			definitions = append(definitions, g.serializeClass(o, false, serializerInfo), g.serializeClass(o, true, serializerInfo))
+		case *enum:
+			serialized, err := g.serializeEnum(o)
+			if err != nil {
+				return nil, err
+			}
+
+			definitions = append(definitions, serialized)
+		}
+
+		// Extern types are hand-written, and services and channels aren't generated for GraphQL
+	}
+
+	for _, entry := range entries {
+		definitions = append(definitions, g.serializeEntry(entry, false, serializerInfo), g.serializeEntry(entry, true, serializerInfo))
+	}
+
+	return []*generatedCode{newGeneratedCode("schema.graphql",
+		g.serializeDeclaration(usesDate)+strings.Join(definitions, "\n"))}, nil
+}
+
+func (g *graphQLLanguageSerializer) serializeDeclaration(usesDate bool) string {
+	result := "# **********************************\n" +
+		"#\tGenerated by ModelsGenerator\n#\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n# **********************************\n\n"
+
+	if usesDate {
+		result += "\"\"\"\nAn ISO 8601 date and time, like 2024-01-31T10:00:00Z.\n\"\"\"\nscalar DateTime\n\n"
+	}
+
+	return result
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a GraphQL type.
+*/
+func (g *graphQLLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, bool) {
+	if primitiveType, isPrimitive := g.typesMap[typeName]; isPrimitive {
+		return primitiveType, true
+	}
+
+	return findExternType(serializerInfo, typeName, LanguageTypeGraphQL)
+}
+
+/**
+Get the GraphQL name of a gen file type. Classes have a different name for inputs, like "OrderInput".
+*/
+func (g *graphQLLanguageSerializer) typeName(typeName string, isInput bool, serializerInfo *serializerInfo) string {
+	if knownType, isKnown := g.mapType(typeName, serializerInfo); isKnown {
+		return knownType
+	}
+
+	name := toFirstCharUpper(typeName)
+	if languageName, ok := findLanguageName(typeName, LanguageTypeGraphQL, serializerInfo); ok {
+		name = languageName
+	}
+
+	// Enums are the same for inputs and outputs
+	if _, isClass := serializerInfo.classes[typeName]; isClass && isInput {
+		return name + "Input"
+	}
+
+	return name
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+*/
+func (g *graphQLLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeGraphQL, "name"); ok {
+		return name
+	}
+
+	return toCamelCase(member.name)
+}
+
+/**
+Serialize the GraphQL type of a data member. Nothing is nullable, since gen files don't have nullable types.
+Maps are lists of entries, like [StringIntEntry!]!.
+*/
+func (g *graphQLLanguageSerializer) memberType(member *dataMember, isInput bool, serializerInfo *serializerInfo) string {
+	if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeGraphQL, "type"); ok {
+		return overrideType
+	}
+
+	if isList, listType := isList(member.memberType); isList {
+		return fmt.Sprintf("[%s!]!", g.typeName(listType, isInput, serializerInfo))
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
+		return fmt.Sprintf("[%s!]!", g.entryName(mapKeyType, mapValueType, isInput, serializerInfo))
+	}
+
+	return g.typeName(member.memberType, isInput, serializerInfo) + "!"
+}
+
+/**
+Get the name of the entry type of a map, like "StringOrderItemEntry" for map<string,orderItem>.
+*/
+func (g *graphQLLanguageSerializer) entryName(keyType string, valueType string, isInput bool, serializerInfo *serializerInfo) string {
+	// Classes are named by their GraphQL names, and the other types by their gen file names, so double and float don't collide
+	valueName := toFirstCharUpper(valueType)
+	if _, isClass := serializerInfo.classes[valueType]; isClass {
+		valueName = g.typeName(valueType, false, serializerInfo)
+	}
+
+	name := toFirstCharUpper(keyType) + valueName + "Entry"
+
+	if isInput {
+		return name + "Input"
+	}
+
+	return name
+}
+
+func (g *graphQLLanguageSerializer) appendEntry(entries []*graphQLEntry, keyType string, valueType string, serializerInfo *serializerInfo) []*graphQLEntry {
+	name := g.entryName(keyType, valueType, false, serializerInfo)
+	for _, entry := range entries {
+		if entry.name == name {
+			return entries
+		}
+	}
+
+	return append(entries, &graphQLEntry{name: name, keyType: keyType, valueType: valueType})
+}
+
+func (g *graphQLLanguageSerializer) serializeEntry(entry *graphQLEntry, isInput bool, serializerInfo *serializerInfo) string {
+	keyword := "type"
+	if isInput {
+		keyword = "input"
+	}
+
+	return fmt.Sprintf("%s %s {\n  key: %s!\n  value: %s!\n}\n",
+		keyword, g.entryName(entry.keyType, entry.valueType, isInput, serializerInfo),
+		g.typeName(entry.keyType, isInput, serializerInfo), g.typeName(entry.valueType, isInput, serializerInfo))
+}
+
+/**
+Serialize a class as a type, or as an input for the arguments of mutations.
+*/
+func (g *graphQLLanguageSerializer) serializeClass(class *class, isInput bool, serializerInfo *serializerInfo) string {
+	keyword := "type"
+	if isInput {
+		keyword = "input"
+	}
+
+	// Classes without data members get a placeholder, since GraphQL types must have fields
+	if len(class.dataMembers) == 0 {
+		return fmt.Sprintf("%s %s {\n  _empty: Boolean\n}\n", keyword, g.typeName(class.name, isInput, serializerInfo))
+	}
+
+	serializedCode := fmt.Sprintf("%s %s {\n", keyword, g.typeName(class.name, isInput, serializerInfo))
+
+	for _, member := range class.dataMembers {
+		serializedCode += fmt.Sprintf("  %s: %s\n", g.fieldName(member), g.memberType(member, isInput, serializerInfo))
+	}
+
+	return serializedCode + "}\n"
+}
+
+/**
+Serialize an enum. GraphQL enums are written and read by their names, so the integer values are only documented.
+*/
+func (g *graphQLLanguageSerializer) serializeEnum(enum *enum) (string, error) {
+	if len(enum.enumValues) == 0 {
+		return "", errors.New(fmt.Sprintf("enum %s doesn't have values, but GraphQL enums can't be empty", enum.name))
+	}
+
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeGraphQL, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := fmt.Sprintf("enum %s {\n", enumName)
+
+	for _, value := range enum.enumValues {
+		valueName := strings.ToUpper(toSnakeCase(value.name))
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeGraphQL, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("  \"JSON value %v\"\n  %s\n", value.value, valueName)
+	}
+
+	return serializedCode + "}\n", nil
+}
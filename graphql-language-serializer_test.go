@@ -0,0 +1,221 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_graphQLLanguageSerializer_getType(t *testing.T) {
+	if got := newGraphQLLanguageSerializer().getType(); got != LanguageTypeGraphQL {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeGraphQL)
+	}
+}
+
+func Test_graphQLLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newGraphQLLanguageSerializer().getTypeName(); got != "graphql" {
+		t.Errorf("getTypeName() = %v, want %v", got, "graphql")
+	}
+}
+
+func Test_graphQLLanguageSerializer_generateCode(t *testing.T) {
+	order := newClass("order")
+	_ = order.addValue("createdAt", "date", nil)
+	_ = order.addValue("prices", "map<string,double>", nil)
+	_ = order.addValue("items", "map<int,orderItem>", nil)
+	_ = order.addValue("totals", "map<string,double>", nil)
+
+	item := newClass("orderItem")
+	_ = item.addValue("name", "string", nil)
+
+	testService, _ := getTestService()
+
+	got, err := newGraphQLLanguageSerializer().generateCode([]middleware{order, item, testService}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 1 || got[0].fileName != "schema.graphql" {
+		t.Errorf("generateCode() should create a single schema.graphql file, got %v", got)
+		return
+	}
+
+	want := "scalar DateTime\n\n" +
+		"type Order {\n  createdAt: DateTime!\n  prices: [StringDoubleEntry!]!\n  items: [IntOrderItemEntry!]!\n  totals: [StringDoubleEntry!]!\n}\n\n" +
+		"input OrderInput {\n  createdAt: DateTime!\n  prices: [StringDoubleEntryInput!]!\n  items: [IntOrderItemEntryInput!]!\n  totals: [StringDoubleEntryInput!]!\n}\n\n" +
+		"type OrderItem {\n  name: String!\n}\n\n" +
+		"input OrderItemInput {\n  name: String!\n}\n\n" +
+		"type StringDoubleEntry {\n  key: String!\n  value: Float!\n}\n\n" +
+		"input StringDoubleEntryInput {\n  key: String!\n  value: Float!\n}\n\n" +
+		"type IntOrderItemEntry {\n  key: Int!\n  value: OrderItem!\n}\n\n" +
+		"input IntOrderItemEntryInput {\n  key: Int!\n  value: OrderItemInput!\n}\n"
+
+	if !strings.HasSuffix(got[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", got[0].code, want)
+	}
+
+	got, err = newGraphQLLanguageSerializer().generateCode([]middleware{item}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if strings.Contains(got[0].code, "scalar DateTime") {
+		t.Errorf("generateCode() declared DateTime without dates")
+	}
+}
+
+func Test_graphQLLanguageSerializer_serializeClass(t *testing.T) {
+	type args struct {
+		class   *class
+		isInput bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "int", name: "id"},
+						{memberType: "list<string>", name: "tags"},
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "orderStatus", name: "status"},
+					},
+				},
+			},
+			want: "type Order {\n  id: Int!\n  tags: [String!]!\n  items: [OrderItem!]!\n  status: OrderStatus!\n}\n",
+		},
+		{
+			name: "Input serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "orderStatus", name: "status"},
+					},
+				},
+				isInput: true,
+			},
+			want: "input OrderInput {\n  items: [OrderItemInput!]!\n  status: OrderStatus!\n}\n",
+		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name:        "event",
+					annotations: []*annotation{{namespace: "graphql", name: "name", arguments: []string{"UserEvent"}}},
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "payload",
+							annotations: []*annotation{
+								{namespace: "graphql", name: "type", arguments: []string{"JSON"}},
+								{namespace: "graphql", name: "name", arguments: []string{"body"}},
+							},
+						},
+					},
+				},
+			},
+			want: "type UserEvent {\n  body: JSON\n}\n",
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name:        "price",
+					dataMembers: []*dataMember{{memberType: "Money", name: "amount"}},
+				},
+			},
+			want: "type Price {\n  amount: Money!\n}\n",
+		},
+		{
+			name: "Input with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				isInput: true,
+			},
+			want: "input HolderInput {\n  e: EvtInput!\n  kind: Category!\n}\n",
+		},
+		{
+			name: "Empty class",
+			args: args{class: &class{name: "empty"}, isInput: true},
+			want: "input EmptyInput {\n  _empty: Boolean\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renamed := getTestRenamedTypesInfo()
+			info := &serializerInfo{
+				externTypes: getTestExternTypes(),
+				classes:     collectClasses([]middleware{renamed.classes["event"], tt.args.class, newClass("orderItem")}),
+				enums:       renamed.enums,
+			}
+			info.externTypes["Money"].languageNames[LanguageTypeGraphQL] = "Money"
+
+			if got := newGraphQLLanguageSerializer().serializeClass(tt.args.class, tt.args.isInput, info); got != tt.want {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_graphQLLanguageSerializer_serializeEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		enum    *enum
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Enum serialize",
+			enum: &enum{
+				name: "orderStatus",
+				enumValues: []*enumValue{
+					{name: "active", value: 1},
+					{name: "cancelledByUser", value: 5},
+				},
+			},
+			want: "enum OrderStatus {\n  \"JSON value 1\"\n  ACTIVE\n  \"JSON value 5\"\n  CANCELLED_BY_USER\n}\n",
+		},
+		{
+			name: "Enum with language overrides",
+			enum: &enum{
+				name:        "color",
+				annotations: []*annotation{{namespace: "graphql", name: "name", arguments: []string{"Colour"}}},
+				enumValues: []*enumValue{
+					{name: "red", value: 1, annotations: []*annotation{{namespace: "graphql", name: "name", arguments: []string{"RED_COLOUR"}}}},
+				},
+			},
+			want: "enum Colour {\n  \"JSON value 1\"\n  RED_COLOUR\n}\n",
+		},
+		{
+			name:    "Empty enum",
+			enum:    &enum{name: "empty"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newGraphQLLanguageSerializer().serializeEnum(tt.enum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
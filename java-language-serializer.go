@@ -0,0 +1,395 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var javaKeywords = []string{
+	"abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class", "const",
+	"continue", "default", "do", "double", "else", "enum", "extends", "final", "finally", "float",
+	"for", "goto", "if", "implements", "import", "instanceof", "int", "interface", "long", "native",
+	"new", "package", "private", "protected", "public", "return", "short", "static", "strictfp",
+	"super", "switch", "synchronized", "this", "throw", "throws", "transient", "try", "void",
+	"volatile", "while", "record", "var", "yield",
+}
+
+const javaDateFormat = "@JsonFormat(shape = JsonFormat.Shape.STRING)"
+
+/**
+Generate Java 17 records, or POJOs with getters and setters with the "pojo" option.
+The classes are annotated for Jackson, and they are generated into the directories of their package.
+*/
+type javaLanguageSerializer struct {
+	typesMap map[string]string
+	boxedMap map[string]string
+}
+
+func newJavaLanguageSerializer() *javaLanguageSerializer {
+	result := &javaLanguageSerializer{
+		typesMap: make(map[string]string, 0),
+		boxedMap: make(map[string]string, 0),
+	}
+
+	result.typesMap["bool"] = "boolean"
+	result.typesMap["int"] = "int"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "double"
+	result.typesMap["float"] = "float"
+	result.typesMap["char"] = "char"
+	result.typesMap["byte"] = "byte"
+	result.typesMap["date"] = "Date"
+
+	// Generic types can't use primitives
+	result.boxedMap["boolean"] = "Boolean"
+	result.boxedMap["int"] = "Integer"
+	result.boxedMap["double"] = "Double"
+	result.boxedMap["float"] = "Float"
+	result.boxedMap["char"] = "Character"
+	result.boxedMap["byte"] = "Byte"
+
+	return result
+}
+
+func (j *javaLanguageSerializer) getType() languageType {
+	return LanguageTypeJava
+}
+
+func (j *javaLanguageSerializer) getTypeName() string {
+	return "java"
+}
+
+func (j *javaLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Java
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := j.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result, nil
+}
+
+func (j *javaLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return j.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return j.serializeEnum(enum, serializerInfo)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (j *javaLanguageSerializer) serializeDeclaration(serializerInfo *serializerInfo) string {
+	generatedMark := "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+
+	if serializerInfo.packageName == "" {
+		return generatedMark
+	}
+
+	return fmt.Sprintf("package %s;\n\n", serializerInfo.packageName) + generatedMark
+}
+
+/**
+Serialize the imports sorted, like most Java formatters do.
+*/
+func (j *javaLanguageSerializer) serializeImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	sorted := append([]string{}, imports...)
+	sort.Strings(sorted)
+
+	result := ""
+	for _, imp := range sorted {
+		result += fmt.Sprintf("import %s;\n", imp)
+	}
+
+	return result + "\n"
+}
+
+/**
+Get the path of a generated file, inside the directories of the package.
+For example, "com/acme/models/Order.java" for the "com.acme.models" package.
+*/
+func (j *javaLanguageSerializer) filePath(typeName string, serializerInfo *serializerInfo) string {
+	fileName := fmt.Sprintf("%s.java", typeName)
+	if serializerInfo.packageName == "" {
+		return fileName
+	}
+
+	return strings.Replace(serializerInfo.packageName, ".", "/", -1) + "/" + fileName
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Java type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (j *javaLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := j.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			return primitiveType, "java.util.Date", true
+		}
+
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeJava)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "com.acme.Money" is imported as is and used as "Money"
+	dot := strings.LastIndex(externName, ".")
+	if dot == -1 {
+		return externName, "", true
+	}
+
+	return externName[dot+1:], externName, true
+}
+
+/**
+Map a gen file type which is used as a generic argument, so primitives are boxed.
+*/
+func (j *javaLanguageSerializer) mapGenericType(typeName string, serializerInfo *serializerInfo) (string, string) {
+	knownType, imp, isKnown := j.mapType(typeName, serializerInfo)
+	if !isKnown {
+		return j.className(typeName, serializerInfo), ""
+	}
+
+	if boxed, ok := j.boxedMap[knownType]; ok {
+		return boxed, imp
+	}
+
+	return knownType, imp
+}
+
+/**
+Get the Java class name of a class or an enum, taking the name annotation into account.
+*/
+func (j *javaLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeJava, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Java keywords get a _ suffix, and the JSON name stays the same.
+*/
+func (j *javaLanguageSerializer) memberName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeJava, "name"); ok {
+		return name
+	}
+
+	name := toCamelCase(member.name)
+	for _, keyword := range javaKeywords {
+		if name == keyword {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+/**
+Serialize the Java type of a data member.
+Return the imports the type needs.
+*/
+func (j *javaLanguageSerializer) memberType(member *dataMember, serializerInfo *serializerInfo) (string, []string) {
+	imports := findLanguageImports(member.annotations, LanguageTypeJava)
+
+	if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeJava, "type"); ok {
+		return overrideType, imports
+	}
+
+	if isList, listType := isList(member.memberType); isList {
+		itemType, imp := j.mapGenericType(listType, serializerInfo)
+		imports = appendImport(appendUnique(imports, "java.util.List"), imp)
+
+		return fmt.Sprintf("List<%s>", itemType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
+		keyType, keyImport := j.mapGenericType(mapKeyType, serializerInfo)
+		valueType, valueImport := j.mapGenericType(mapValueType, serializerInfo)
+		imports = appendImport(appendImport(appendUnique(imports, "java.util.Map"), keyImport), valueImport)
+
+		return fmt.Sprintf("Map<%s, %s>", keyType, valueType), imports
+	}
+
+	if knownType, imp, isKnown := j.mapType(member.memberType, serializerInfo); isKnown {
+		return knownType, appendImport(imports, imp)
+	}
+
+	// It's not a language type, so we use the class or the enum name
+	return j.className(member.memberType, serializerInfo), imports
+}
+
+func (j *javaLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeJava, "name"); ok {
+		className = name
+	}
+
+	imports := findLanguageImports(class.annotations, LanguageTypeJava)
+	if len(class.dataMembers) > 0 {
+		imports = appendUnique(imports, "com.fasterxml.jackson.annotation.JsonProperty")
+	}
+
+	names := make([]string, 0)
+	types := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		memberType, memberImports := j.memberType(member, serializerInfo)
+		for _, imp := range memberImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		if j.isDateMember(member) {
+			imports = appendUnique(imports, "com.fasterxml.jackson.annotation.JsonFormat")
+		}
+
+		names = append(names, j.memberName(member))
+		types = append(types, memberType)
+	}
+
+	serializedCode := ""
+	if _, isPojo := findOption(serializerInfo, "pojo"); isPojo {
+		serializedCode = j.serializePojo(className, class, names, types)
+	} else {
+		serializedCode = j.serializeRecord(className, class, names, types)
+	}
+
+	return newGeneratedCode(j.filePath(className, serializerInfo),
+		j.serializeDeclaration(serializerInfo)+j.serializeImports(imports)+serializedCode), nil
+}
+
+/**
+Check if a data member holds dates, including lists and map values of dates.
+Jackson writes dates as epoch milliseconds, so they need a string format to be written as ISO-8601 like the other languages.
+*/
+func (j *javaLanguageSerializer) isDateMember(member *dataMember) bool {
+	if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeJava, "type"); ok {
+		return false
+	}
+
+	types := elementTypes(member.memberType)
+
+	return types[len(types)-1] == "date"
+}
+
+func (j *javaLanguageSerializer) serializeRecord(className string, class *class, names []string, types []string) string {
+	if len(class.dataMembers) == 0 {
+		return fmt.Sprintf("public record %s() {\n}", className)
+	}
+
+	components := make([]string, 0)
+	for i, member := range class.dataMembers {
+		format := ""
+		if j.isDateMember(member) {
+			format = javaDateFormat + " "
+		}
+
+		components = append(components, fmt.Sprintf("\t@JsonProperty(\"%s\") %s%s %s",
+			toCamelCase(member.name), format, types[i], names[i]))
+	}
+
+	return fmt.Sprintf("public record %s(\n%s\n) {\n}", className, strings.Join(components, ",\n"))
+}
+
+func (j *javaLanguageSerializer) serializePojo(className string, class *class, names []string, types []string) string {
+	result := fmt.Sprintf("public class %s {\n", className)
+
+	for i, member := range class.dataMembers {
+		result += fmt.Sprintf("\t@JsonProperty(\"%s\")\n", toCamelCase(member.name))
+		if j.isDateMember(member) {
+			result += fmt.Sprintf("\t%s\n", javaDateFormat)
+		}
+
+		result += fmt.Sprintf("\tprivate %s %s;\n\n", types[i], names[i])
+	}
+
+	result += fmt.Sprintf("\tpublic %s() {\n\t}\n", className)
+
+	for i := range class.dataMembers {
+		accessorName := toFirstCharUpper(names[i])
+
+		getter := "get"
+		if types[i] == "boolean" {
+			getter = "is"
+		}
+
+		result += fmt.Sprintf("\n\tpublic %s %s%s() {\n\t\treturn %s;\n\t}\n", types[i], getter, accessorName, names[i])
+		result += fmt.Sprintf("\n\tpublic void set%s(%s %s) {\n\t\tthis.%s = %s;\n\t}\n",
+			accessorName, types[i], names[i], names[i], names[i])
+	}
+
+	return result + "}"
+}
+
+func (j *javaLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeJava, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := j.serializeDeclaration(serializerInfo) + j.serializeImports([]string{
+		"com.fasterxml.jackson.annotation.JsonCreator",
+		"com.fasterxml.jackson.annotation.JsonValue",
+	})
+
+	serializedCode += fmt.Sprintf("public enum %s {\n", enumName)
+
+	values := make([]string, 0)
+	for _, value := range enum.enumValues {
+		valueName := strings.ToUpper(toSnakeCase(value.name))
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeJava, "name"); ok {
+			valueName = name
+		}
+
+		values = append(values, fmt.Sprintf("\t%s(%v)", valueName, value.value))
+	}
+
+	if len(values) > 0 {
+		serializedCode += strings.Join(values, ",\n") + ";\n\n"
+	} else {
+		serializedCode += "\t;\n\n"
+	}
+
+	// Jackson writes and reads the enums by their integer values
+	serializedCode += "\tprivate final int value;\n\n"
+	serializedCode += fmt.Sprintf("\t%s(int value) {\n\t\tthis.value = value;\n\t}\n\n", enumName)
+	serializedCode += "\t@JsonValue\n\tpublic int getValue() {\n\t\treturn value;\n\t}\n\n"
+	serializedCode += fmt.Sprintf("\t@JsonCreator\n\tpublic static %s fromValue(int value) {\n", enumName)
+	serializedCode += fmt.Sprintf("\t\tfor (%s item : values()) {\n", enumName)
+	serializedCode += "\t\t\tif (item.value == value) {\n\t\t\t\treturn item;\n\t\t\t}\n\t\t}\n\n"
+	serializedCode += fmt.Sprintf("\t\tthrow new IllegalArgumentException(\"Unknown %s value \" + value);\n\t}\n}", enumName)
+
+	return newGeneratedCode(j.filePath(enumName, serializerInfo), serializedCode), nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_javaLanguageSerializer_generateCode(t *testing.T) {
+	j := newJavaLanguageSerializer()
+
+	testClass := &class{
+		name: "test",
+		dataMembers: []*dataMember{
+			{
+				memberType: "int",
+				name:       "a",
+			},
+		},
+	}
+
+	testEnum := &enum{
+		name: "testStatus",
+		enumValues: []*enumValue{
+			{
+				name:  "a",
+				value: 5,
+			},
+		},
+	}
+
+	generatedCode, err := j.generateCode([]middleware{testClass, testEnum}, &serializerInfo{packageName: "com.acme"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	if len(generatedCode) != 2 {
+		t.Errorf("generateCode() generated %v files. expected 2", len(generatedCode))
+		return
+	}
+
+	if generatedCode[0].fileName != "com/acme/Test.java" || generatedCode[1].fileName != "com/acme/TestStatus.java" {
+		t.Errorf("generateCode() file names = %v, %v", generatedCode[0].fileName, generatedCode[1].fileName)
+	}
+}
+
+func Test_javaLanguageSerializer_getType(t *testing.T) {
+	if got := newJavaLanguageSerializer().getType(); got != LanguageTypeJava {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeJava)
+	}
+}
+
+func Test_javaLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newJavaLanguageSerializer().getTypeName(); got != "java" {
+		t.Errorf("getTypeName() = %v, want %v", got, "java")
+	}
+}
+
+func Test_javaLanguageSerializer_serializeClass(t *testing.T) {
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Record serialize",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "a",
+						},
+						{
+							memberType: "list<int>",
+							name:       "b",
+						},
+						{
+							memberType: "map<string,other>",
+							name:       "c",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{packageName: "bla"},
+			},
+			want: &generatedCode{
+				fileName: "bla/Test.java",
+				code: "import com.fasterxml.jackson.annotation.JsonProperty;\n" +
+					"import java.util.List;\n" +
+					"import java.util.Map;\n\n" +
+					"public record Test(\n" +
+					"\t@JsonProperty(\"a\") String a,\n" +
+					"\t@JsonProperty(\"b\") List<Integer> b,\n" +
+					"\t@JsonProperty(\"c\") Map<String, Other> c\n" +
+					") {\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Record with dates",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{memberType: "date", name: "createdAt"},
+						{memberType: "list<date>", name: "history"},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Test.java",
+				code: "import com.fasterxml.jackson.annotation.JsonFormat;\n" +
+					"import com.fasterxml.jackson.annotation.JsonProperty;\n" +
+					"import java.util.Date;\n" +
+					"import java.util.List;\n\n" +
+					"public record Test(\n" +
+					"\t@JsonProperty(\"createdAt\") @JsonFormat(shape = JsonFormat.Shape.STRING) Date createdAt,\n" +
+					"\t@JsonProperty(\"history\") @JsonFormat(shape = JsonFormat.Shape.STRING) List<Date> history\n" +
+					") {\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty record",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Test.java",
+				code:     "public record Test() {\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "POJO serialize",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+						},
+						{
+							memberType: "bool",
+							name:       "class",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{options: map[string]string{"pojo": ""}},
+			},
+			want: &generatedCode{
+				fileName: "Test.java",
+				code: "import com.fasterxml.jackson.annotation.JsonFormat;\n" +
+					"import com.fasterxml.jackson.annotation.JsonProperty;\n" +
+					"import java.util.Date;\n\n" +
+					"public class Test {\n" +
+					"\t@JsonProperty(\"createdAt\")\n\t@JsonFormat(shape = JsonFormat.Shape.STRING)\n\tprivate Date createdAt;\n\n" +
+					"\t@JsonProperty(\"class\")\n\tprivate boolean class_;\n\n" +
+					"\tpublic Test() {\n\t}\n\n" +
+					"\tpublic Date getCreatedAt() {\n\t\treturn createdAt;\n\t}\n\n" +
+					"\tpublic void setCreatedAt(Date createdAt) {\n\t\tthis.createdAt = createdAt;\n\t}\n\n" +
+					"\tpublic boolean isClass_() {\n\t\treturn class_;\n\t}\n\n" +
+					"\tpublic void setClass_(boolean class_) {\n\t\tthis.class_ = class_;\n\t}\n" +
+					"}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+							annotations: []*annotation{
+								{namespace: "java", name: "type", arguments: []string{"Instant"}},
+								{namespace: "java", name: "import", arguments: []string{"java.time.Instant"}},
+								{namespace: "java", name: "name", arguments: []string{"created"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "java", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Renamed.java",
+				code: "import com.fasterxml.jackson.annotation.JsonProperty;\n" +
+					"import java.time.Instant;\n\n" +
+					"public record Renamed(\n" +
+					"\t@JsonProperty(\"createdAt\") Instant created\n" +
+					") {\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "list<Money>",
+							name:       "prices",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeJava: "java.math.BigDecimal"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "Test.java",
+				code: "import com.fasterxml.jackson.annotation.JsonProperty;\n" +
+					"import java.math.BigDecimal;\n" +
+					"import java.util.List;\n\n" +
+					"public record Test(\n" +
+					"\t@JsonProperty(\"prices\") List<BigDecimal> prices\n" +
+					") {\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "list<event>", name: "es"},
+						{memberType: "map<kind,event>", name: "byKind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "bla/Holder.java",
+				code: "import com.fasterxml.jackson.annotation.JsonProperty;\n" +
+					"import java.util.List;\n" +
+					"import java.util.Map;\n\n" +
+					"public record Holder(\n" +
+					"\t@JsonProperty(\"e\") Evt e,\n" +
+					"\t@JsonProperty(\"es\") List<Evt> es,\n" +
+					"\t@JsonProperty(\"byKind\") Map<Category, Evt> byKind\n" +
+					") {\n}",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newJavaLanguageSerializer()
+			got, err := j.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, j.serializeDeclaration(tt.args.serializerInfo), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_javaLanguageSerializer_serializeDeclaration(t *testing.T) {
+	j := newJavaLanguageSerializer()
+
+	if got := j.serializeDeclaration(&serializerInfo{packageName: "com.acme"}); !strings.HasPrefix(got, "package com.acme;\n\n//") {
+		t.Errorf("serializeDeclaration() = %v, want the package first", got)
+	}
+
+	if got := j.serializeDeclaration(&serializerInfo{}); strings.Contains(got, "package") {
+		t.Errorf("serializeDeclaration() = %v, want no package", got)
+	}
+}
+
+func Test_javaLanguageSerializer_serializeEnum(t *testing.T) {
+	j := newJavaLanguageSerializer()
+	info := &serializerInfo{packageName: "com.acme"}
+
+	got, err := j.serializeEnum(&enum{
+		name: "orderStatus",
+		enumValues: []*enumValue{
+			{name: "active", value: 5},
+			{name: "onHold", value: 8},
+		},
+	}, info)
+	if err != nil {
+		t.Errorf("serializeEnum() error = %v", err)
+		return
+	}
+
+	if got.fileName != "com/acme/OrderStatus.java" {
+		t.Errorf("serializeEnum() fileName = %v, want %v", got.fileName, "com/acme/OrderStatus.java")
+	}
+
+	expectedParts := []string{
+		"import com.fasterxml.jackson.annotation.JsonCreator;\nimport com.fasterxml.jackson.annotation.JsonValue;\n",
+		"public enum OrderStatus {\n\tACTIVE(5),\n\tON_HOLD(8);\n\n\tprivate final int value;\n",
+		"\t@JsonValue\n\tpublic int getValue() {",
+		"\t@JsonCreator\n\tpublic static OrderStatus fromValue(int value) {",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeEnum() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+
+	empty, _ := j.serializeEnum(&enum{name: "empty", enumValues: []*enumValue{}}, info)
+	if !strings.Contains(empty.code, "public enum Empty {\n\t;\n") {
+		t.Errorf("serializeEnum() empty enum code = %v", empty.code)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+const jsonSchemaFileRef = "%s.schema.json"
+const jsonSchemaDefsRef = "#/$defs/%s"
+
+/**
+Generate a JSON Schema (draft 2020-12) for every class and enum, like "OrderItem.schema.json",
+which reference each other by their file names.
+Use the bundle option to generate a single "models.schema.json" with all the schemas under $defs.
+*/
+type jsonSchemaLanguageSerializer struct {
+}
+
+func newJSONSchemaLanguageSerializer() *jsonSchemaLanguageSerializer {
+	return &jsonSchemaLanguageSerializer{}
+}
+
+func (j *jsonSchemaLanguageSerializer) getType() languageType {
+	return LanguageTypeJSONSchema
+}
+
+func (j *jsonSchemaLanguageSerializer) getTypeName() string {
+	return "jsonschema"
+}
+
+func (j *jsonSchemaLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+
+	if _, isBundle := findOption(serializerInfo, "bundle"); isBundle {
+		return []*generatedCode{j.serializeBundle(objects, serializerInfo)}, nil
+	}
+
+	result := make([]*generatedCode, 0)
+
+	for _, object := range objects {
+		// Extern types are hand-written, and services and channels aren't generated for JSON Schema
+		schema := j.serializeMiddleware(object, jsonSchemaFileRef, serializerInfo)
+		if schema == nil {
+			continue
+		}
+
+		fileName := fmt.Sprintf(jsonSchemaFileRef, schemaName(middlewareName(object)))
+		document := newOrderedMap().
+			set("$schema", jsonSchemaDialect).
+			set("$id", fileName)
+
+		for _, key := range schema.keys {
+			document.set(key, schema.values[key])
+		}
+
+		result = append(result, newGeneratedCode(fileName, serializeJSON(document)+"\n"))
+	}
+
+	return result, nil
+}
+
+/**
+Serialize all the classes and enums into a single schema, under $defs.
+*/
+func (j *jsonSchemaLanguageSerializer) serializeBundle(objects []middleware, serializerInfo *serializerInfo) *generatedCode {
+	title := serializerInfo.packageName
+	if title == "" {
+		title = "Models"
+	}
+
+	defs := newOrderedMap()
+
+	for _, object := range objects {
+		if schema := j.serializeMiddleware(object, jsonSchemaDefsRef, serializerInfo); schema != nil {
+			defs.set(schemaName(middlewareName(object)), schema)
+		}
+	}
+
+	document := newOrderedMap().
+		set("$schema", jsonSchemaDialect).
+		set("$id", "models.schema.json").
+		set("title", title).
+		set("$defs", defs)
+
+	return newGeneratedCode("models.schema.json", serializeJSON(document)+"\n")
+}
+
+/**
+Build the schema of a class or an enum, or nil for the other middlewares.
+*/
+func (j *jsonSchemaLanguageSerializer) serializeMiddleware(middleware middleware, refFormat string, serializerInfo *serializerInfo) *orderedMap {
+	switch m := middleware.(type) {
+	case *class:
+		return j.serializeClass(m, refFormat, serializerInfo)
+	case *enum:
+		return j.serializeEnum(m)
+	}
+
+	return nil
+}
+
+func (j *jsonSchemaLanguageSerializer) serializeClass(class *class, refFormat string, serializerInfo *serializerInfo) *orderedMap {
+	result := newOrderedMap().set("title", schemaName(class.name))
+	schema := classSchema(class, refFormat, serializerInfo)
+
+	for _, key := range schema.keys {
+		result.set(key, schema.values[key])
+	}
+
+	return j.standardFormats(result)
+}
+
+/**
+Replace the OpenAPI number formats, like int32, which JSON Schema validators reject in strict mode.
+int32 becomes its minimum and maximum, and the other number formats are removed.
+*/
+func (j *jsonSchemaLanguageSerializer) standardFormats(schema *orderedMap) *orderedMap {
+	result := newOrderedMap()
+
+	for _, key := range schema.keys {
+		value := schema.values[key]
+
+		if key == "format" && value == "int32" {
+			result.set("minimum", math.MinInt32).set("maximum", math.MaxInt32)
+			continue
+		}
+
+		if key == "format" && (value == "double" || value == "float") {
+			continue
+		}
+
+		if inner, ok := value.(*orderedMap); ok {
+			value = j.standardFormats(inner)
+		}
+
+		result.set(key, value)
+	}
+
+	return result
+}
+
+/**
+Serialize an enum with its integer values. JSON Schema validators reject unknown keywords in strict mode,
+so the value names are written in the description instead of x-enum-varnames.
+*/
+func (j *jsonSchemaLanguageSerializer) serializeEnum(enum *enum) *orderedMap {
+	values := make([]interface{}, 0)
+	names := make([]string, 0)
+
+	for _, value := range enum.enumValues {
+		values = append(values, value.value)
+		names = append(names, fmt.Sprintf("%s = %v", value.name, value.value))
+	}
+
+	result := newOrderedMap().
+		set("title", schemaName(enum.name)).
+		set("type", "integer").
+		set("enum", values)
+
+	if len(names) > 0 {
+		result.set("description", strings.Join(names, ", "))
+	}
+
+	return result
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_jsonSchemaLanguageSerializer_getType(t *testing.T) {
+	if got := newJSONSchemaLanguageSerializer().getType(); got != LanguageTypeJSONSchema {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeJSONSchema)
+	}
+}
+
+func Test_jsonSchemaLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newJSONSchemaLanguageSerializer().getTypeName(); got != "jsonschema" {
+		t.Errorf("getTypeName() = %v, want %v", got, "jsonschema")
+	}
+}
+
+func getTestSchemaObjects() []middleware {
+	order := newClass("order")
+	_ = order.addValue("id", "int", nil)
+	_ = order.addValue("items", "list<orderItem>", nil)
+	_ = order.addValue("status", "orderStatus", nil)
+
+	item := newClass("orderItem")
+	_ = item.addValue("price", "double", nil)
+
+	status := newEnum("orderStatus")
+	_ = status.addValue("active", "1", nil)
+
+	testService, _ := getTestService()
+
+	return []middleware{order, item, status, testService}
+}
+
+func Test_jsonSchemaLanguageSerializer_generateCode(t *testing.T) {
+	got, err := newJSONSchemaLanguageSerializer().generateCode(getTestSchemaObjects(), &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 3 {
+		t.Errorf("generateCode() generated %v files. expected 3", len(got))
+		return
+	}
+
+	fileNames := []string{got[0].fileName, got[1].fileName, got[2].fileName}
+	if !reflect.DeepEqual(fileNames, []string{"Order.schema.json", "OrderItem.schema.json", "OrderStatus.schema.json"}) {
+		t.Errorf("generateCode() file names = %v", fileNames)
+	}
+
+	expectedParts := []string{
+		"{\n  \"$schema\": \"https://json-schema.org/draft/2020-12/schema\",\n  \"$id\": \"Order.schema.json\",\n  \"title\": \"Order\",\n",
+		"\"items\": {\n      \"type\": \"array\",\n      \"items\": {\n        \"$ref\": \"OrderItem.schema.json\"\n      }\n    }",
+		"\"status\": {\n      \"$ref\": \"OrderStatus.schema.json\"\n    }",
+		"\"id\": {\n      \"type\": \"integer\",\n      \"minimum\": -2147483648,\n      \"maximum\": 2147483647\n    }",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got[0].code, part) {
+			t.Errorf("generateCode() code doesn't contain %v.\ncode: %v", part, got[0].code)
+		}
+	}
+}
+
+func Test_jsonSchemaLanguageSerializer_generateCode_bundle(t *testing.T) {
+	info := &serializerInfo{packageName: "shop", options: map[string]string{"bundle": ""}}
+
+	got, err := newJSONSchemaLanguageSerializer().generateCode(getTestSchemaObjects(), info)
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 1 || got[0].fileName != "models.schema.json" {
+		t.Errorf("generateCode() should create a single models.schema.json file, got %v", got)
+		return
+	}
+
+	expectedParts := []string{
+		"\"$id\": \"models.schema.json\",\n  \"title\": \"shop\",\n  \"$defs\": {\n    \"Order\": {\n",
+		"\"$ref\": \"#/$defs/OrderItem\"",
+		"\"$ref\": \"#/$defs/OrderStatus\"",
+		"\"OrderItem\": {\n      \"title\": \"OrderItem\",\n      \"type\": \"object\",\n" +
+			"      \"properties\": {\n        \"price\": {\n          \"type\": \"number\"\n        }\n      },",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got[0].code, part) {
+			t.Errorf("generateCode() code doesn't contain %v.\ncode: %v", part, got[0].code)
+		}
+	}
+}
+
+func Test_jsonSchemaLanguageSerializer_serializeEnum(t *testing.T) {
+	status := newEnum("orderStatus")
+	_ = status.addValue("active", "1", nil)
+	_ = status.addValue("closed", "5", nil)
+
+	want := newOrderedMap().
+		set("title", "OrderStatus").
+		set("type", "integer").
+		set("enum", []interface{}{1, 5}).
+		set("description", "active = 1, closed = 5")
+
+	if got := newJSONSchemaLanguageSerializer().serializeEnum(status); !reflect.DeepEqual(got, want) {
+		t.Errorf("serializeEnum() = %v, want %v", serializeJSON(got), serializeJSON(want))
+	}
+
+	empty := newJSONSchemaLanguageSerializer().serializeEnum(newEnum("empty"))
+	if _, ok := empty.get("description"); ok {
+		t.Errorf("serializeEnum() added a description to an empty enum")
+	}
+}
+
+func Test_jsonSchemaLanguageSerializer_standardFormats(t *testing.T) {
+	schema := newOrderedMap().
+		set("type", "object").
+		set("additionalProperties", newOrderedMap().set("type", "number").set("format", "float")).
+		set("properties", newOrderedMap().
+			set("createdAt", newOrderedMap().set("type", "string").set("format", "date-time")))
+
+	want := newOrderedMap().
+		set("type", "object").
+		set("additionalProperties", newOrderedMap().set("type", "number")).
+		set("properties", newOrderedMap().
+			set("createdAt", newOrderedMap().set("type", "string").set("format", "date-time")))
+
+	if got := newJSONSchemaLanguageSerializer().standardFormats(schema); !reflect.DeepEqual(got, want) {
+		t.Errorf("standardFormats() = %v, want %v", serializeJSON(got), serializeJSON(want))
+	}
+}
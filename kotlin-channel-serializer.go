@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+Serialize all the channels to a single file with the topic constants, and typed publisher
+and subscriber interfaces for every channel. Channels with a key also get a function which
+extracts the key from the message.
+*/
+func (k *kotlinLanguageSerializer) serializeChannels(channels []*channel, serializerInfo *serializerInfo) (*generatedCode, error) {
+	imports := make([]string, 0)
+	constantsCode := "object Topics {\n"
+	channelsCode := ""
+
+	for _, c := range channels {
+		typeName := topicTypeName(c.topic)
+		payloadName := k.className(c.payloadType, serializerInfo)
+
+		constantsCode += fmt.Sprintf("\tconst val %s = \"%s\"\n", topicConstantName(c.topic), c.topic)
+
+		parameters := fmt.Sprintf("message: %s", payloadName)
+
+		if len(c.headers) > 0 {
+			parameters += fmt.Sprintf(", headers: %sHeaders", typeName)
+			headers := make([]string, 0)
+
+			for _, header := range c.headers {
+				headerType := toFirstCharUpper(header.headerType)
+				if knownType, imp, isKnown := k.mapType(header.headerType, serializerInfo); isKnown {
+					headerType = knownType
+					imports = appendImport(imports, imp)
+				}
+
+				headers = append(headers, fmt.Sprintf("val %s: %s", toCamelCase(header.name), headerType))
+			}
+
+			channelsCode += fmt.Sprintf("\ndata class %sHeaders(%s)\n", typeName, strings.Join(headers, ", "))
+		}
+
+		channelsCode += fmt.Sprintf("\ninterface %sPublisher {\n\tsuspend fun publish%s(%s)\n}\n",
+			typeName, typeName, parameters)
+		channelsCode += fmt.Sprintf("\ninterface %sSubscriber {\n\tsuspend fun handle%s(%s)\n}\n",
+			typeName, typeName, parameters)
+
+		if c.keyField != "" {
+			memberName := toCamelCase(c.keyField)
+			if member := findDataMember(serializerInfo.classes[c.payloadType], c.keyField); member != nil {
+				memberName = k.memberName(member)
+			}
+
+			channelsCode += fmt.Sprintf("\nfun %sKey(message: %s): String = message.%s.toString()\n",
+				toCamelCase(typeName), payloadName, memberName)
+		}
+	}
+
+	constantsCode += "}\n"
+
+	// Delete the last new line
+	channelsCode = channelsCode[:len(channelsCode)-1]
+
+	return newGeneratedCode("channels.kt",
+		k.serializeDeclaration(serializerInfo)+k.serializeImports(imports)+constantsCode+channelsCode), nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_kotlinLanguageSerializer_serializeChannels(t *testing.T) {
+	k := newKotlinLanguageSerializer()
+	channels, info := getTestChannels()
+
+	got, err := k.serializeChannels(channels, info)
+	if err != nil {
+		t.Errorf("serializeChannels() error = %v", err)
+		return
+	}
+
+	if got.fileName != "channels.kt" {
+		t.Errorf("serializeChannels() fileName = %v, want %v", got.fileName, "channels.kt")
+	}
+
+	expectedParts := []string{
+		"package models",
+		"object Topics {\n\tconst val ORDERS_CREATED = \"orders.created\"\n\tconst val ORDERS_CLOSED = \"orders.closed\"\n}",
+		"data class OrdersCreatedHeaders(val traceId: String)",
+		"suspend fun publishOrdersCreated(message: OrderCreated, headers: OrdersCreatedHeaders)",
+		"suspend fun handleOrdersClosed(message: OrderCreated)",
+		"fun ordersCreatedKey(message: OrderCreated): String = message.orderId.toString()",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeChannels() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+}
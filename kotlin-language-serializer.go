@@ -37,7 +37,17 @@ func (k *kotlinLanguageSerializer) getTypeName() string {
 func (k *kotlinLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
 	result := make([]*generatedCode, 0)
 
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
 	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Channels are serialized together in the end
+		if object.getType() == middlewareTypeExtern || object.getType() == middlewareTypeChannel {
+			continue
+		}
+
 		serialized, err := k.serializeMiddleware(object, serializerInfo)
 		if err != nil {
 			return nil, err
@@ -46,6 +56,15 @@ func (k *kotlinLanguageSerializer) generateCode(objects []middleware, serializer
 		result = append(result, serialized)
 	}
 
+	if channels := collectChannels(objects); len(channels) > 0 {
+		serialized, err := k.serializeChannels(channels, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
 	return result, nil
 }
 
@@ -58,6 +77,10 @@ func (k *kotlinLanguageSerializer) serializeMiddleware(middleware middleware, se
 		return k.serializeEnum(enum, serializerInfo)
 	}
 
+	if service, ok := middleware.(*service); ok {
+		return k.serializeService(service, serializerInfo)
+	}
+
 	return nil, errors.New("tried to serialize unknown middleware type")
 }
 
@@ -74,49 +97,140 @@ func (k *kotlinLanguageSerializer) serializeDeclaration(serializerInfo *serializ
 	return fmt.Sprintf("package %s\n\n", serializerInfo.packageName) + generatedMark
 }
 
+func (k *kotlinLanguageSerializer) serializeImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	result := ""
+	for _, imp := range imports {
+		result += fmt.Sprintf("import %s\n", imp)
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Kotlin type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (k *kotlinLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := k.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeKotlin)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "com.acme.Money" is imported as is and used as "Money"
+	dot := strings.LastIndex(externName, ".")
+	if dot == -1 {
+		return externName, "", true
+	}
+
+	return externName[dot+1:], externName, true
+}
+
+/**
+Get the Kotlin name of a class or an enum, taking the name annotation into account.
+*/
+func (k *kotlinLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeKotlin, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the property name of a data member, taking the name annotation into account.
+*/
+func (k *kotlinLanguageSerializer) memberName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeKotlin, "name"); ok {
+		return name
+	}
+
+	return toCamelCase(member.name)
+}
+
+/**
+Serialize the Kotlin type of a data member.
+Return the imports the type needs.
+*/
+func (k *kotlinLanguageSerializer) memberType(member *dataMember, serializerInfo *serializerInfo) (string, []string) {
+	imports := findLanguageImports(member.annotations, LanguageTypeKotlin)
+
+	if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeKotlin, "type"); ok {
+		return overrideType, imports
+	}
+
+	if isList, listType := isList(member.memberType); isList {
+		if knownType, imp, isKnown := k.mapType(listType, serializerInfo); isKnown {
+			listType = knownType
+			imports = appendImport(imports, imp)
+		} else {
+			listType = k.className(listType, serializerInfo)
+		}
+
+		return fmt.Sprintf("List<%s>", listType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
+		if knownType, imp, isKnown := k.mapType(mapKeyType, serializerInfo); isKnown {
+			mapKeyType = toFirstCharUpper(knownType)
+			imports = appendImport(imports, imp)
+		} else {
+			mapKeyType = k.className(mapKeyType, serializerInfo)
+		}
+
+		if knownType, imp, isKnown := k.mapType(mapValueType, serializerInfo); isKnown {
+			mapValueType = toFirstCharUpper(knownType)
+			imports = appendImport(imports, imp)
+		} else {
+			mapValueType = k.className(mapValueType, serializerInfo)
+		}
+
+		return fmt.Sprintf("HashMap<%s, %s>", mapKeyType, mapValueType), imports
+	}
+
+	if knownType, imp, isKnown := k.mapType(member.memberType, serializerInfo); isKnown {
+		return toFirstCharUpper(knownType), appendImport(imports, imp)
+	}
+
+	// It's not a language type, so we use the class or the enum name
+	return k.className(member.memberType, serializerInfo), imports
+}
+
 func (k *kotlinLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
-	serializedCode := k.serializeDeclaration(serializerInfo)
+	serializedCode := ""
 	fileName := fmt.Sprintf("%s.kt", class.name)
 
-	serializedCode += fmt.Sprintf("data class %s(", toFirstCharUpper(class.name))
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeKotlin, "name"); ok {
+		className = name
+	}
 
-	for _, member := range class.dataMembers {
-		if isList, listType := isList(member.memberType); isList {
-			primitiveType, isPrimitive := k.typesMap[listType]
-			if isPrimitive {
-				listType = primitiveType
-			}
-
-			serializedCode += fmt.Sprintf("val %s: List<%s>, ",
-				toCamelCase(member.name), listType)
-			continue
-		}
+	imports := findLanguageImports(class.annotations, LanguageTypeKotlin)
 
-		if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
-			primitiveType, isPrimitive := k.typesMap[mapKeyType]
-			if isPrimitive {
-				mapKeyType = primitiveType
-			}
+	serializedCode += fmt.Sprintf("data class %s(", className)
 
-			primitiveType, isPrimitive = k.typesMap[mapValueType]
-			if isPrimitive {
-				mapValueType = primitiveType
-			}
+	for _, member := range class.dataMembers {
+		memberName := k.memberName(member)
 
-			serializedCode += fmt.Sprintf("val %s: HashMap<%s, %s>, ", toCamelCase(member.name),
-				toFirstCharUpper(mapKeyType), toFirstCharUpper(mapValueType))
-			continue
+		memberType, memberImports := k.memberType(member, serializerInfo)
+		for _, imp := range memberImports {
+			imports = appendUnique(imports, imp)
 		}
 
-		if primitiveType, ok := k.typesMap[member.memberType]; ok {
-			serializedCode += fmt.Sprintf("val %s: %s, ",
-				toCamelCase(member.name), toFirstCharUpper(primitiveType))
-			continue
+		// A renamed property keeps its JSON name
+		if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeKotlin, "name"); ok {
+			imports = appendUnique(imports, "com.google.gson.annotations.SerializedName")
+			serializedCode += fmt.Sprintf("@SerializedName(\"%s\") ", toCamelCase(member.name))
 		}
 
-		// It's not a language type, so we create it as a pointer
-		serializedCode += fmt.Sprintf("val %s: %s, ",
-			toCamelCase(member.name), toFirstCharUpper(member.memberType))
+		serializedCode += fmt.Sprintf("val %s: %s, ", memberName, memberType)
 	}
 
 	// Delete the last ", "
@@ -124,17 +238,28 @@ func (k *kotlinLanguageSerializer) serializeClass(class *class, serializerInfo *
 
 	serializedCode += ")"
 
-	return newGeneratedCode(fileName, serializedCode), nil
+	return newGeneratedCode(fileName,
+		k.serializeDeclaration(serializerInfo)+k.serializeImports(imports)+serializedCode), nil
 }
 
 func (k *kotlinLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
 	serializedCode := k.serializeDeclaration(serializerInfo)
 	fileName := fmt.Sprintf("%s.kt", enum.name)
 
-	serializedCode += fmt.Sprintf("enum class %s(val value: Int) {\n", toFirstCharUpper(enum.name))
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeKotlin, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode += fmt.Sprintf("enum class %s(val value: Int) {\n", enumName)
 
 	for i, value := range enum.enumValues {
-		serializedCode += fmt.Sprintf("\t%s(%v)", strings.ToUpper(value.name), value.value)
+		valueName := strings.ToUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeKotlin, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("\t%s(%v)", valueName, value.value)
 
 		if i < len(enum.enumValues)-1 {
 			serializedCode += ",\n"
@@ -224,6 +224,67 @@ func Test_kotlinLanguageSerializer_serializeClass(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+							annotations: []*annotation{
+								{namespace: "go", name: "type", arguments: []string{"time.Time"}},
+								{namespace: "go", name: "import", arguments: []string{"time"}},
+								{namespace: "kotlin", name: "type", arguments: []string{"Instant"}},
+								{namespace: "kotlin", name: "import", arguments: []string{"java.time.Instant"}},
+								{namespace: "ts", name: "type", arguments: []string{"string"}},
+								{namespace: "csharp", name: "type", arguments: []string{"DateTimeOffset"}},
+								{namespace: "csharp", name: "import", arguments: []string{"System"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "go", name: "name", arguments: []string{"ID"}},
+								{namespace: "csharp", name: "name", arguments: []string{"ID"}},
+							},
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{packageName: "bla"},
+			},
+			want: &generatedCode{
+				fileName: "test.kt",
+				code:     "import java.time.Instant\n\ndata class Test(val createdAt: Instant, val id: String)",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+						{
+							memberType: "list<Money>",
+							name:       "history",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{packageName: "bla", externTypes: getTestExternTypes()},
+			},
+			want: &generatedCode{
+				fileName: "test.kt",
+				code:     "import com.acme.Money\n\ndata class Test(val price: Money, val history: List<Money>)",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Class with struct",
 			args: args{
@@ -248,6 +309,50 @@ func Test_kotlinLanguageSerializer_serializeClass(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "list<event>", name: "es"},
+						{memberType: "map<kind,event>", name: "byKind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "holder.kt",
+				code:     "data class Holder(val e: Evt, val es: List<Evt>, val byKind: HashMap<Category, Evt>)",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed member",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "kotlin", name: "name", arguments: []string{"identifier"}},
+							},
+						},
+						{memberType: "int", name: "count"},
+					},
+				},
+				serializerInfo: &serializerInfo{packageName: "bla"},
+			},
+			want: &generatedCode{
+				fileName: "test.kt",
+				code: "import com.google.gson.annotations.SerializedName\n\n" +
+					"data class Test(@SerializedName(\"id\") val identifier: String, val count: Int)",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/**
+Serialize a service to a Retrofit interface with suspending functions.
+Path parameters are taken from the request data members, and the request is sent as the body
+for methods which have one. The other methods get the rest of the data members as query parameters.
+*/
+func (k *kotlinLanguageSerializer) serializeService(service *service, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.kt", service.name)
+
+	imports := make([]string, 0)
+	serializedCode := fmt.Sprintf("interface %s {\n", toFirstCharUpper(service.name))
+
+	for i, method := range service.methods {
+		if i > 0 {
+			serializedCode += "\n"
+		}
+
+		imports = appendUnique(imports, fmt.Sprintf("retrofit2.http.%s", method.httpMethod))
+		parameters := make([]string, 0)
+
+		for _, parameter := range pathParameters(method.path) {
+			imports = appendUnique(imports, "retrofit2.http.Path")
+
+			parameterType := "String"
+			if member := findDataMember(serializerInfo.classes[method.requestType], parameter); member != nil {
+				memberType, memberImports := k.memberType(member, serializerInfo)
+				parameterType = memberType
+				for _, imp := range memberImports {
+					imports = appendUnique(imports, imp)
+				}
+			}
+
+			parameters = append(parameters, fmt.Sprintf("@Path(\"%s\") %s: %s",
+				parameter, toCamelCase(parameter), parameterType))
+		}
+
+		for _, member := range queryMembers(method, serializerInfo.classes) {
+			imports = appendUnique(imports, "retrofit2.http.Query")
+
+			memberType, memberImports := k.memberType(member, serializerInfo)
+			for _, imp := range memberImports {
+				imports = appendUnique(imports, imp)
+			}
+
+			parameters = append(parameters, fmt.Sprintf("@Query(\"%s\") %s: %s",
+				toCamelCase(member.name), k.memberName(member), memberType))
+		}
+
+		if hasRequestBody(method) {
+			imports = appendUnique(imports, "retrofit2.http.Body")
+			parameters = append(parameters, fmt.Sprintf("@Body request: %s",
+				k.className(method.requestType, serializerInfo)))
+		}
+
+		serializedCode += fmt.Sprintf("\t@%s(\"%s\")\n", method.httpMethod, method.path)
+		serializedCode += fmt.Sprintf("\tsuspend fun %s(%s): %s\n", toCamelCase(method.name),
+			strings.Join(parameters, ", "), k.className(method.responseType, serializerInfo))
+	}
+
+	serializedCode += "}"
+
+	sort.Strings(imports)
+
+	return newGeneratedCode(fileName,
+		k.serializeDeclaration(serializerInfo)+k.serializeImports(imports)+serializedCode), nil
+}
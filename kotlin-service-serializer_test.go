@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_kotlinLanguageSerializer_serializeService(t *testing.T) {
+	g := newKotlinLanguageSerializer()
+	userService, info := getTestService()
+
+	got, err := g.serializeService(userService, info)
+	if err != nil {
+		t.Errorf("serializeService() error = %v", err)
+		return
+	}
+
+	// delete the header
+	got.code = strings.Replace(got.code, g.serializeDeclaration(info), "", -1)
+
+	want := "import retrofit2.http.Body\n" +
+		"import retrofit2.http.GET\n" +
+		"import retrofit2.http.POST\n" +
+		"import retrofit2.http.Path\n" +
+		"import retrofit2.http.Query\n\n" +
+		"interface UserService {\n" +
+		"\t@GET(\"/users/{id}\")\n" +
+		"\tsuspend fun getUser(@Path(\"id\") id: String, @Query(\"verbose\") verbose: Boolean): User\n\n" +
+		"\t@POST(\"/users\")\n" +
+		"\tsuspend fun createUser(@Body request: CreateUserRequest): User\n\n" +
+		"\t@GET(\"/ping\")\n" +
+		"\tsuspend fun ping(): User\n" +
+		"}"
+
+	if got.fileName != "userService.kt" {
+		t.Errorf("serializeService() fileName = %v, want %v", got.fileName, "userService.kt")
+	}
+
+	if got.code != want {
+		t.Errorf("serializeService() code = %v, want %v", got.code, want)
+	}
+}
@@ -7,10 +7,66 @@ const (
 	LanguageTypeGo         = languageType(1)
 	LanguageTypeKotlin     = languageType(2)
 	LanguageTypeTypescript = languageType(3)
+	LanguageTypeAsyncAPI   = languageType(4)
+	LanguageTypePython     = languageType(5)
+	LanguageTypeJava       = languageType(6)
+	LanguageTypeSwift      = languageType(7)
+	LanguageTypeRust       = languageType(8)
+	LanguageTypeDart       = languageType(9)
+	LanguageTypeProto      = languageType(10)
+	LanguageTypeJSONSchema = languageType(11)
+	LanguageTypeOpenAPI    = languageType(12)
+	LanguageTypeGraphQL    = languageType(13)
+	LanguageTypeAvro       = languageType(14)
+	LanguageTypeSQL        = languageType(15)
+	LanguageTypeZod        = languageType(16)
+	LanguageTypeScala      = languageType(17)
+	LanguageTypeCpp        = languageType(18)
+	LanguageTypePhp        = languageType(19)
+	LanguageTypeRuby       = languageType(20)
+	LanguageTypeElixir     = languageType(21)
+	LanguageTypeThrift     = languageType(22)
+	LanguageTypeFsharp     = languageType(23)
+	LanguageTypeElm        = languageType(24)
 )
 
+/**
+The namespaces of language specific annotations in gen files.
+For example, @go.type("time.Time") is honored by the Go serializer only.
+*/
+var annotationNamespaces = map[string]languageType{
+	"go":         LanguageTypeGo,
+	"kotlin":     LanguageTypeKotlin,
+	"ts":         LanguageTypeTypescript,
+	"typescript": LanguageTypeTypescript,
+	"csharp":     LanguageTypeCSharp,
+	"python":     LanguageTypePython,
+	"java":       LanguageTypeJava,
+	"swift":      LanguageTypeSwift,
+	"rust":       LanguageTypeRust,
+	"dart":       LanguageTypeDart,
+	"proto":      LanguageTypeProto,
+	"jsonschema": LanguageTypeJSONSchema,
+	"graphql":    LanguageTypeGraphQL,
+	"avro":       LanguageTypeAvro,
+	"sql":        LanguageTypeSQL,
+	"zod":        LanguageTypeZod,
+	"scala":      LanguageTypeScala,
+	"cpp":        LanguageTypeCpp,
+	"php":        LanguageTypePhp,
+	"ruby":       LanguageTypeRuby,
+	"elixir":     LanguageTypeElixir,
+	"thrift":     LanguageTypeThrift,
+	"fsharp":     LanguageTypeFsharp,
+	"elm":        LanguageTypeElm,
+}
+
 type serializerInfo struct {
 	packageName string
+	options     map[string]string
+	externTypes map[string]*externType
+	classes     map[string]*class
+	enums       map[string]*enum
 }
 
 type generatedCode struct {
@@ -14,6 +14,7 @@ var languageMap = make(map[string]languageType)
 type languageParameter struct {
 	languageType languageType
 	packageName  string
+	options      map[string]string
 }
 
 func main() {
@@ -21,11 +22,53 @@ func main() {
 	serializers[LanguageTypeKotlin] = newKotlinLanguageSerializer()
 	serializers[LanguageTypeTypescript] = newTypescriptLanguageSerializer()
 	serializers[LanguageTypeCSharp] = newCsharpLanguageSerializer()
+	serializers[LanguageTypeAsyncAPI] = newAsyncAPILanguageSerializer()
+	serializers[LanguageTypePython] = newPythonLanguageSerializer()
+	serializers[LanguageTypeJava] = newJavaLanguageSerializer()
+	serializers[LanguageTypeSwift] = newSwiftLanguageSerializer()
+	serializers[LanguageTypeRust] = newRustLanguageSerializer()
+	serializers[LanguageTypeDart] = newDartLanguageSerializer()
+	serializers[LanguageTypeProto] = newProtoLanguageSerializer()
+	serializers[LanguageTypeJSONSchema] = newJSONSchemaLanguageSerializer()
+	serializers[LanguageTypeOpenAPI] = newOpenAPILanguageSerializer()
+	serializers[LanguageTypeGraphQL] = newGraphQLLanguageSerializer()
+	serializers[LanguageTypeAvro] = newAvroLanguageSerializer()
+	serializers[LanguageTypeSQL] = newSQLLanguageSerializer()
+	serializers[LanguageTypeZod] = newZodLanguageSerializer()
+	serializers[LanguageTypeScala] = newScalaLanguageSerializer()
+	serializers[LanguageTypeCpp] = newCppLanguageSerializer()
+	serializers[LanguageTypePhp] = newPhpLanguageSerializer()
+	serializers[LanguageTypeRuby] = newRubyLanguageSerializer()
+	serializers[LanguageTypeElixir] = newElixirLanguageSerializer()
+	serializers[LanguageTypeThrift] = newThriftLanguageSerializer()
+	serializers[LanguageTypeFsharp] = newFsharpLanguageSerializer()
+	serializers[LanguageTypeElm] = newElmLanguageSerializer()
 
 	languageMap["go"] = LanguageTypeGo
 	languageMap["kotlin"] = LanguageTypeKotlin
 	languageMap["typescript"] = LanguageTypeTypescript
 	languageMap["c#"] = LanguageTypeCSharp
+	languageMap["asyncapi"] = LanguageTypeAsyncAPI
+	languageMap["python"] = LanguageTypePython
+	languageMap["java"] = LanguageTypeJava
+	languageMap["swift"] = LanguageTypeSwift
+	languageMap["rust"] = LanguageTypeRust
+	languageMap["dart"] = LanguageTypeDart
+	languageMap["proto"] = LanguageTypeProto
+	languageMap["jsonschema"] = LanguageTypeJSONSchema
+	languageMap["openapi"] = LanguageTypeOpenAPI
+	languageMap["graphql"] = LanguageTypeGraphQL
+	languageMap["avro"] = LanguageTypeAvro
+	languageMap["sql"] = LanguageTypeSQL
+	languageMap["zod"] = LanguageTypeZod
+	languageMap["scala"] = LanguageTypeScala
+	languageMap["cpp"] = LanguageTypeCpp
+	languageMap["php"] = LanguageTypePhp
+	languageMap["ruby"] = LanguageTypeRuby
+	languageMap["elixir"] = LanguageTypeElixir
+	languageMap["thrift"] = LanguageTypeThrift
+	languageMap["fsharp"] = LanguageTypeFsharp
+	languageMap["elm"] = LanguageTypeElm
 
 	if err := handleCommand(); err != nil {
 		panic(err)
@@ -36,7 +79,11 @@ func handleCommand() error {
 	if len(os.Args) == 1 || (len(os.Args) > 1 && os.Args[1] == "help") {
 		println("Hello! This tool helps you to convert a \"gen\" file into different languages models.\n" +
 			"The format should be \"gen-file-path.gen language:package\". You can avoid from adding package name.\n" +
-			"The supported languages are Go, Kotlin, C# and Typescript.\n" +
+			"Language options can be added after the package, like \"python:models:dataclass\".\n" +
+			"The supported languages are Go, Kotlin, C#, Typescript, Python, Java, Swift, Rust, Dart, Scala, C++, PHP, Ruby, Elixir, F# and Elm.\n" +
+			"Use \"asyncapi\" to generate an AsyncAPI document for the channels, \"openapi\" for OpenAPI component schemas,\n" +
+			"\"proto\" for Protocol Buffers, \"avro\" for Avro, \"jsonschema\" for JSON Schema, \"graphql\" for a GraphQL schema\n" +
+			"\"sql\" for SQL tables, \"zod\" for Zod schemas and \"thrift\" for Thrift IDL.\n" +
 			"For more info and documentation for gen files, visit https://github.com/BarShavit/ModelsGenerator.\n" +
 			"Thank you!")
 
@@ -73,7 +120,7 @@ func handleGenerate() error {
 
 	for _, lang := range languages {
 		generatedCode, err := serializers[lang.languageType].generateCode(meddlers,
-			&serializerInfo{packageName: lang.packageName})
+			&serializerInfo{packageName: lang.packageName, options: lang.options})
 
 		if err != nil {
 			return err
@@ -89,14 +136,30 @@ func handleGenerate() error {
 	return nil
 }
 
+/**
+Parse a language parameter, written like "language:package:options".
+The options are separated by commas, and can be flags or key=value pairs, like "python:models:dataclass".
+*/
 func parseToLanguageParameter(parameter string) (*languageParameter, error) {
-	splittedParameter := strings.Split(parameter, ":")
+	splittedParameter := strings.SplitN(parameter, ":", 3)
 	packageName := ""
+	options := make(map[string]string)
 
 	if len(splittedParameter) > 1 {
 		packageName = splittedParameter[1]
 	}
 
+	if len(splittedParameter) > 2 {
+		for _, option := range strings.Split(splittedParameter[2], ",") {
+			if option == "" {
+				continue
+			}
+
+			key, value, _ := strings.Cut(option, "=")
+			options[key] = value
+		}
+	}
+
 	languageType, ok := languageMap[splittedParameter[0]]
 	if !ok {
 		return nil, errors.New(
@@ -106,5 +169,6 @@ func parseToLanguageParameter(parameter string) (*languageParameter, error) {
 	return &languageParameter{
 		languageType: languageType,
 		packageName:  packageName,
+		options:      options,
 	}, nil
 }
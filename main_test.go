@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseToLanguageParameter(t *testing.T) {
+	languageMap["go"] = LanguageTypeGo
+	languageMap["python"] = LanguageTypePython
+
+	type args struct {
+		parameter string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *languageParameter
+		wantErr bool
+	}{
+		{
+			name:    "Language only",
+			args:    args{parameter: "go"},
+			want:    &languageParameter{languageType: LanguageTypeGo, options: map[string]string{}},
+			wantErr: false,
+		},
+		{
+			name:    "With package",
+			args:    args{parameter: "go:models"},
+			want:    &languageParameter{languageType: LanguageTypeGo, packageName: "models", options: map[string]string{}},
+			wantErr: false,
+		},
+		{
+			name: "With options",
+			args: args{parameter: "python::dataclass,style=strict"},
+			want: &languageParameter{
+				languageType: LanguageTypePython,
+				options:      map[string]string{"dataclass": "", "style": "strict"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Unknown language",
+			args:    args{parameter: "cobol:models"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseToLanguageParameter(tt.args.parameter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseToLanguageParameter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseToLanguageParameter() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
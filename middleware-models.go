@@ -4,13 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type middlewareType int
 
 const (
-	middlewareTypeClass middlewareType = 0
-	middlewareTypeEnum  middlewareType = 2
+	middlewareTypeClass   middlewareType = 0
+	middlewareTypeEnum    middlewareType = 2
+	middlewareTypeExtern  middlewareType = 3
+	middlewareTypeService middlewareType = 4
+	middlewareTypeChannel middlewareType = 5
 )
 
 /**
@@ -19,17 +23,30 @@ Different languages can implement that in different ways.
 */
 type middleware interface {
 	getType() middlewareType
-	addValue(name string, value string) error
+	addValue(name string, value string, annotations []*annotation) error
+}
+
+/**
+Represent an annotation written in the gen file, like @go.type("time.Time").
+The namespace is the language the annotation belongs to, and it's empty
+for annotations that are relevant to all the languages.
+*/
+type annotation struct {
+	namespace string
+	name      string
+	arguments []string
 }
 
 type dataMember struct {
-	memberType string
-	name       string
+	memberType  string
+	name        string
+	annotations []*annotation
 }
 
 type class struct {
 	name        string
 	dataMembers []*dataMember
+	annotations []*annotation
 }
 
 func newClass(name string) *class {
@@ -43,10 +60,11 @@ func newClass(name string) *class {
 Add new data member to the class.
 The value parameter is the data member type
 */
-func (c *class) addValue(name string, value string) error {
+func (c *class) addValue(name string, value string, annotations []*annotation) error {
 	member := &dataMember{
-		memberType: value,
-		name:       toCamelCase(name),
+		memberType:  value,
+		name:        toCamelCase(name),
+		annotations: annotations,
 	}
 
 	if !memberUnique(c.dataMembers, member) {
@@ -64,13 +82,15 @@ func (c *class) getType() middlewareType {
 }
 
 type enumValue struct {
-	name  string
-	value int
+	name        string
+	value       int
+	annotations []*annotation
 }
 
 type enum struct {
-	name       string
-	enumValues []*enumValue
+	name        string
+	enumValues  []*enumValue
+	annotations []*annotation
 }
 
 func newEnum(name string) *enum {
@@ -80,15 +100,16 @@ func newEnum(name string) *enum {
 	}
 }
 
-func (e *enum) addValue(name string, value string) error {
+func (e *enum) addValue(name string, value string, annotations []*annotation) error {
 	v, err := strconv.Atoi(value)
 	if err != nil {
 		return err
 	}
 
 	newEnumValue := &enumValue{
-		name:  toCamelCase(name),
-		value: v,
+		name:        toCamelCase(name),
+		value:       v,
+		annotations: annotations,
 	}
 
 	if !enumValueUnique(e.enumValues, newEnumValue) {
@@ -103,3 +124,199 @@ func (e *enum) addValue(name string, value string) error {
 func (e *enum) getType() middlewareType {
 	return middlewareTypeEnum
 }
+
+/**
+Represent a hand-written type that the gen file references without generating it.
+Every language has its own full name for the type, like "github.com/acme/money.Money" in Go.
+*/
+type externType struct {
+	name          string
+	languageNames map[languageType]string
+}
+
+func newExternType(name string) *externType {
+	return &externType{
+		name:          name,
+		languageNames: make(map[languageType]string),
+	}
+}
+
+/**
+Add the type's full name in a language.
+The name parameter is the language annotation namespace, like go or ts.
+*/
+func (e *externType) addValue(name string, value string, annotations []*annotation) error {
+	language, ok := annotationNamespaces[name]
+	if !ok {
+		return errors.New(fmt.Sprintf(
+			"tried to add unknown language %s to extern type %s", name, e.name))
+	}
+
+	if _, exists := e.languageNames[language]; exists {
+		return errors.New(fmt.Sprintf(
+			"tried to add language %s to extern type %s, but it's already exists", name, e.name))
+	}
+
+	e.languageNames[language] = value
+
+	return nil
+}
+
+func (e *externType) getType() middlewareType {
+	return middlewareTypeExtern
+}
+
+/**
+Represent an RPC method of a service, like: getUser(GetUserRequest) GetUserResponse @http(GET, "/users/{id}")
+The request type is empty when the method doesn't get a request.
+*/
+type serviceMethod struct {
+	name         string
+	requestType  string
+	responseType string
+	httpMethod   string
+	path         string
+	annotations  []*annotation
+}
+
+type service struct {
+	name        string
+	methods     []*serviceMethod
+	annotations []*annotation
+}
+
+func newService(name string) *service {
+	return &service{
+		name:    name,
+		methods: make([]*serviceMethod, 0),
+	}
+}
+
+var supportedHttpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+/**
+Add new method to the service.
+The name parameter is the method name with the request type, like getUser(GetUserRequest),
+and the value parameter is the response type. Every method must have an @http annotation.
+*/
+func (s *service) addValue(name string, value string, annotations []*annotation) error {
+	open := strings.Index(name, "(")
+	if open < 1 || !strings.HasSuffix(name, ")") {
+		return errors.New(fmt.Sprintf(
+			"tried to add method %s to service %s, but it should be like name(RequestType)", name, s.name))
+	}
+
+	method := &serviceMethod{
+		name:         toCamelCase(name[:open]),
+		requestType:  strings.TrimSpace(name[open+1 : len(name)-1]),
+		responseType: value,
+		annotations:  annotations,
+	}
+
+	for _, a := range annotations {
+		if a.namespace == "" && a.name == "http" && len(a.arguments) == 2 {
+			method.httpMethod = strings.ToUpper(a.arguments[0])
+			method.path = a.arguments[1]
+		}
+	}
+
+	if !isSupportedHttpMethod(method.httpMethod) {
+		return errors.New(fmt.Sprintf(
+			"method %s of service %s should have an annotation like @http(GET, \"/path\")", name, s.name))
+	}
+
+	for _, m := range s.methods {
+		if m.name == method.name {
+			return errors.New(fmt.Sprintf(
+				"tried to add method %s to service %s, but it is already exists", name, s.name))
+		}
+	}
+
+	s.methods = append(s.methods, method)
+
+	return nil
+}
+
+func (s *service) getType() middlewareType {
+	return middlewareTypeService
+}
+
+func isSupportedHttpMethod(method string) bool {
+	for _, m := range supportedHttpMethods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+Check if the HTTP method sends the request as the body.
+GET and DELETE requests send the path parameters, and the other data members as query parameters.
+*/
+func hasRequestBody(method *serviceMethod) bool {
+	return method.requestType != "" && method.httpMethod != "GET" && method.httpMethod != "DELETE"
+}
+
+type channelHeader struct {
+	name        string
+	headerType  string
+	annotations []*annotation
+}
+
+/**
+Represent a message channel, like a Kafka topic, and the class of the messages it carries.
+The key field is the payload data member used as the message key, and it's empty without @key annotation.
+*/
+type channel struct {
+	topic       string
+	payloadType string
+	keyField    string
+	headers     []*channelHeader
+	annotations []*annotation
+}
+
+func newChannel(topic string, payloadType string, annotations []*annotation) *channel {
+	result := &channel{
+		topic:       topic,
+		payloadType: payloadType,
+		headers:     make([]*channelHeader, 0),
+		annotations: annotations,
+	}
+
+	for _, a := range annotations {
+		if a.namespace == "" && a.name == "key" && len(a.arguments) == 1 {
+			result.keyField = toCamelCase(a.arguments[0])
+		}
+	}
+
+	return result
+}
+
+/**
+Add new header to the channel.
+The value parameter is the header type.
+*/
+func (c *channel) addValue(name string, value string, annotations []*annotation) error {
+	header := &channelHeader{
+		name:        toCamelCase(name),
+		headerType:  value,
+		annotations: annotations,
+	}
+
+	for _, h := range c.headers {
+		if h.name == header.name {
+			return errors.New(fmt.Sprintf(
+				"tried to add header %s to channel %s, but it is already exists", name, c.topic))
+		}
+	}
+
+	c.headers = append(c.headers, header)
+
+	return nil
+}
+
+func (c *channel) getType() middlewareType {
+	return middlewareTypeChannel
+}
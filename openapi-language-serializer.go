@@ -0,0 +1,306 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+const openAPISchemasRef = "#/components/schemas/%s"
+
+/**
+Generate an OpenAPI 3.1 document with every class and enum as a component schema.
+Use the json option to write JSON instead of YAML, and the merge option, like merge=api/openapi.yaml,
+to merge the schemas into an existing document, keeping everything else in it as is.
+*/
+type openAPILanguageSerializer struct {
+}
+
+func newOpenAPILanguageSerializer() *openAPILanguageSerializer {
+	return &openAPILanguageSerializer{}
+}
+
+func (o *openAPILanguageSerializer) getType() languageType {
+	return LanguageTypeOpenAPI
+}
+
+func (o *openAPILanguageSerializer) getTypeName() string {
+	return "openapi"
+}
+
+func (o *openAPILanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+
+	schemas := newOrderedMap()
+
+	for _, object := range objects {
+		// Extern types are hand-written, and services and channels aren't generated for OpenAPI
+		switch m := object.(type) {
+		case *class:
+			schemas.set(schemaName(m.name), classSchema(m, openAPISchemasRef, serializerInfo))
+		case *enum:
+			schemas.set(schemaName(m.name), enumSchema(m))
+		}
+	}
+
+	if path, isMerge := findOption(serializerInfo, "merge"); isMerge {
+		return o.mergeDocument(path, schemas)
+	}
+
+	title := serializerInfo.packageName
+	if title == "" {
+		title = "Models"
+	}
+
+	document := newOrderedMap().
+		set("openapi", "3.1.0").
+		set("info", newOrderedMap().
+			set("title", title).
+			set("version", "1.0.0")).
+		set("components", newOrderedMap().
+			set("schemas", schemas))
+
+	if _, isJSON := findOption(serializerInfo, "json"); isJSON {
+		return []*generatedCode{newGeneratedCode("openapi.json", serializeJSON(document)+"\n")}, nil
+	}
+
+	return []*generatedCode{newGeneratedCode("openapi.yaml", serializeYAML(document))}, nil
+}
+
+/**
+Merge the schemas into an existing OpenAPI document, which is written with the same file name.
+Schemas with the same names are replaced, and the paths and the hand-written schemas are kept.
+*/
+func (o *openAPILanguageSerializer) mergeDocument(path string, schemas *orderedMap) ([]*generatedCode, error) {
+	if path == "" {
+		return nil, errors.New("the openapi merge option should be a path, like merge=openapi.yaml")
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged string
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		merged, err = mergeJSONSchemas(content, schemas)
+	} else {
+		merged, err = mergeYAMLSchemas(string(content), schemas)
+	}
+
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to merge the schemas into %s: %v", path, err))
+	}
+
+	return []*generatedCode{newGeneratedCode(filepath.Base(path), merged)}, nil
+}
+
+/**
+Merge the schemas into the components of a JSON document.
+*/
+func mergeJSONSchemas(content []byte, schemas *orderedMap) (string, error) {
+	parsed, err := parseJSON(content)
+	if err != nil {
+		return "", err
+	}
+
+	document, ok := parsed.(*orderedMap)
+	if !ok {
+		return "", errors.New("the document should be an object")
+	}
+
+	components, err := childObject(document, "components")
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := childObject(components, "schemas")
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range schemas.keys {
+		existing.set(name, schemas.values[name])
+	}
+
+	return serializeJSON(document) + "\n", nil
+}
+
+/**
+Get the object under the key, and add an empty one if it doesn't exist.
+*/
+func childObject(parent *orderedMap, key string) (*orderedMap, error) {
+	value, exists := parent.get(key)
+	if !exists {
+		child := newOrderedMap()
+		parent.set(key, child)
+
+		return child, nil
+	}
+
+	child, ok := value.(*orderedMap)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("%s should be an object", key))
+	}
+
+	return child, nil
+}
+
+/**
+Merge the schemas into the components of a YAML document.
+The document is edited line by line, so everything but the replaced schemas stays as it was, including comments.
+*/
+func mergeYAMLSchemas(content string, schemas *orderedMap) (string, error) {
+	lines := strings.Split(content, "\n")
+
+	// Drop the empty line after the last new line, so new lines can be appended
+	hasFinalNewLine := len(lines) > 1 && lines[len(lines)-1] == ""
+	if hasFinalNewLine {
+		lines = lines[:len(lines)-1]
+	}
+
+	for _, name := range schemas.keys {
+		start, end, indent, err := findYAMLSchemas(&lines)
+		if err != nil {
+			return "", err
+		}
+
+		entry := strings.Split(strings.TrimSuffix(
+			writeYAML(newOrderedMap().set(name, schemas.values[name]), strings.Repeat(" ", indent)), "\n"), "\n")
+
+		if entryStart, entryEnd := findYAMLKey(lines, start, end, indent, name); entryStart != -1 {
+			lines = replaceLines(lines, entryStart, entryEnd, entry)
+		} else {
+			lines = replaceLines(lines, end, end, entry)
+		}
+	}
+
+	result := strings.Join(lines, "\n")
+	if hasFinalNewLine || len(schemas.keys) > 0 {
+		result += "\n"
+	}
+
+	return result, nil
+}
+
+/**
+Find the block of components.schemas, and add it if it doesn't exist.
+Return the lines range of the schemas and their indentation.
+*/
+func findYAMLSchemas(lines *[]string) (int, int, int, error) {
+	componentsStart, componentsEnd := findYAMLKey(*lines, 0, len(*lines), 0, "components")
+	if componentsStart == -1 {
+		*lines = append(*lines, "components:", "  schemas:")
+
+		return len(*lines), len(*lines), 4, nil
+	}
+
+	if err := clearEmptyYAMLValue(*lines, componentsStart, "components"); err != nil {
+		return 0, 0, 0, err
+	}
+
+	step := yamlBlockIndent(*lines, componentsStart+1, componentsEnd, 2)
+
+	schemasStart, schemasEnd := findYAMLKey(*lines, componentsStart+1, componentsEnd, step, "schemas")
+	if schemasStart == -1 {
+		*lines = replaceLines(*lines, componentsStart+1, componentsStart+1, []string{strings.Repeat(" ", step) + "schemas:"})
+
+		return componentsStart + 2, componentsStart + 2, step * 2, nil
+	}
+
+	if err := clearEmptyYAMLValue(*lines, schemasStart, "schemas"); err != nil {
+		return 0, 0, 0, err
+	}
+
+	return schemasStart + 1, schemasEnd, yamlBlockIndent(*lines, schemasStart+1, schemasEnd, step*2), nil
+}
+
+/**
+Find a key in the given indentation between the start and end lines.
+Return the key line and the line after its block, which ends after its last nested line, or -1 if it doesn't exist.
+*/
+func findYAMLKey(lines []string, start int, end int, indent int, key string) (int, int) {
+	for i := start; i < end; i++ {
+		if !isYAMLContent(lines[i]) || yamlLineIndent(lines[i]) != indent {
+			continue
+		}
+
+		text := lines[i][indent:]
+		if !strings.HasPrefix(text, key+":") && !strings.HasPrefix(text, fmt.Sprintf("\"%s\":", key)) {
+			continue
+		}
+
+		blockEnd := i + 1
+		for j := i + 1; j < end; j++ {
+			if !isYAMLContent(lines[j]) {
+				continue
+			}
+
+			if yamlLineIndent(lines[j]) <= indent {
+				break
+			}
+
+			blockEnd = j + 1
+		}
+
+		return i, blockEnd
+	}
+
+	return -1, -1
+}
+
+/**
+Remove an empty flow value from a key line, like "schemas: {}", so the key can get nested lines.
+Other flow values aren't supported.
+*/
+func clearEmptyYAMLValue(lines []string, index int, key string) error {
+	colon := strings.Index(lines[index], ":")
+	value := strings.TrimSpace(lines[index][colon+1:])
+
+	if value == "{}" {
+		lines[index] = lines[index][:colon+1]
+		return nil
+	}
+
+	if value != "" && !strings.HasPrefix(value, "#") {
+		return errors.New(fmt.Sprintf("%s should be written in block style", key))
+	}
+
+	return nil
+}
+
+/**
+Get the indentation of the first nested line in the range, or the default one if there isn't any.
+*/
+func yamlBlockIndent(lines []string, start int, end int, defaultIndent int) int {
+	for i := start; i < end; i++ {
+		if isYAMLContent(lines[i]) {
+			return yamlLineIndent(lines[i])
+		}
+	}
+
+	return defaultIndent
+}
+
+func yamlLineIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+/**
+Check if the line has content, and it isn't empty or a comment.
+*/
+func isYAMLContent(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return trimmed != "" && !strings.HasPrefix(trimmed, "#")
+}
+
+func replaceLines(lines []string, start int, end int, replacement []string) []string {
+	result := append([]string{}, lines[:start]...)
+	result = append(result, replacement...)
+
+	return append(result, lines[end:]...)
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_openAPILanguageSerializer_getType(t *testing.T) {
+	if got := newOpenAPILanguageSerializer().getType(); got != LanguageTypeOpenAPI {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeOpenAPI)
+	}
+}
+
+func Test_openAPILanguageSerializer_getTypeName(t *testing.T) {
+	if got := newOpenAPILanguageSerializer().getTypeName(); got != "openapi" {
+		t.Errorf("getTypeName() = %v, want %v", got, "openapi")
+	}
+}
+
+func Test_openAPILanguageSerializer_generateCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       map[string]string
+		wantFileName  string
+		expectedParts []string
+	}{
+		{
+			name:         "YAML",
+			wantFileName: "openapi.yaml",
+			expectedParts: []string{
+				"openapi: \"3.1.0\"\ninfo:\n  title: shop\n  version: \"1.0.0\"\ncomponents:\n  schemas:\n    Order:\n      type: object\n",
+				"        items:\n          type: array\n          items:\n            $ref: \"#/components/schemas/OrderItem\"\n",
+				"    OrderStatus:\n      type: integer\n      enum:\n        - 1\n",
+			},
+		},
+		{
+			name:         "JSON",
+			options:      map[string]string{"json": ""},
+			wantFileName: "openapi.json",
+			expectedParts: []string{
+				"{\n  \"openapi\": \"3.1.0\",\n",
+				"\"$ref\": \"#/components/schemas/OrderStatus\"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &serializerInfo{packageName: "shop", options: tt.options}
+
+			got, err := newOpenAPILanguageSerializer().generateCode(getTestSchemaObjects(), info)
+			if err != nil {
+				t.Errorf("generateCode() error = %v", err)
+				return
+			}
+
+			if len(got) != 1 || got[0].fileName != tt.wantFileName {
+				t.Errorf("generateCode() should create a single %v file, got %v", tt.wantFileName, got)
+				return
+			}
+
+			for _, part := range tt.expectedParts {
+				if !strings.Contains(got[0].code, part) {
+					t.Errorf("generateCode() code doesn't contain %v.\ncode: %v", part, got[0].code)
+				}
+			}
+		})
+	}
+}
+
+func Test_openAPILanguageSerializer_generateCode_merge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.json")
+	_ = ioutil.WriteFile(path, []byte("{\"openapi\": \"3.1.0\", \"paths\": {\"/orders\": {}}}"), 0644)
+
+	info := &serializerInfo{options: map[string]string{"merge": path}}
+
+	got, err := newOpenAPILanguageSerializer().generateCode(getTestSchemaObjects(), info)
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 1 || got[0].fileName != "api.json" {
+		t.Errorf("generateCode() should create a single api.json file, got %v", got)
+		return
+	}
+
+	if !strings.HasPrefix(got[0].code, "{\n  \"openapi\": \"3.1.0\",\n  \"paths\": {\n    \"/orders\": {}\n  },\n  \"components\": {\n    \"schemas\": {\n      \"Order\": {") {
+		t.Errorf("generateCode() didn't keep the paths. code: %v", got[0].code)
+	}
+
+	info.options["merge"] = filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := newOpenAPILanguageSerializer().generateCode(getTestSchemaObjects(), info); err == nil {
+		t.Errorf("generateCode() expected an error for a missing document")
+	}
+}
+
+func Test_mergeJSONSchemas(t *testing.T) {
+	schemas := newOrderedMap().
+		set("Order", newOrderedMap().set("type", "object")).
+		set("Status", newOrderedMap().set("type", "integer"))
+
+	content := "{\"components\": {\"schemas\": {\"Handwritten\": {\"type\": \"string\"}, \"Order\": {\"type\": \"string\"}}}}"
+	want := "{\n  \"components\": {\n    \"schemas\": {\n" +
+		"      \"Handwritten\": {\n        \"type\": \"string\"\n      },\n" +
+		"      \"Order\": {\n        \"type\": \"object\"\n      },\n" +
+		"      \"Status\": {\n        \"type\": \"integer\"\n      }\n" +
+		"    }\n  }\n}\n"
+
+	got, err := mergeJSONSchemas([]byte(content), schemas)
+	if err != nil {
+		t.Errorf("mergeJSONSchemas() error = %v", err)
+		return
+	}
+
+	if got != want {
+		t.Errorf("mergeJSONSchemas() = %v, want %v", got, want)
+	}
+
+	if _, err := mergeJSONSchemas([]byte("{\"components\": []}"), schemas); err == nil {
+		t.Errorf("mergeJSONSchemas() expected an error for components which isn't an object")
+	}
+}
+
+func Test_mergeYAMLSchemas(t *testing.T) {
+	schemas := newOrderedMap().
+		set("Order", newOrderedMap().set("type", "object")).
+		set("Status", newOrderedMap().set("type", "integer"))
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Replace and add schemas",
+			content: "openapi: 3.1.0\n" +
+				"paths:\n  /orders:\n    get: {}\n" +
+				"components:\n    schemas:\n        Order:\n            type: string\n        Handwritten:\n            type: object\n\n" +
+				"# tags\ntags: []\n",
+			want: "openapi: 3.1.0\n" +
+				"paths:\n  /orders:\n    get: {}\n" +
+				"components:\n    schemas:\n        Order:\n          type: object\n        Handwritten:\n            type: object\n" +
+				"        Status:\n          type: integer\n\n" +
+				"# tags\ntags: []\n",
+		},
+		{
+			name:    "Without schemas",
+			content: "components:\n  securitySchemes: {}\npaths: {}\n",
+			want:    "components:\n  schemas:\n    Order:\n      type: object\n    Status:\n      type: integer\n  securitySchemes: {}\npaths: {}\n",
+		},
+		{
+			name:    "Empty schemas",
+			content: "components:\n  schemas: {}\n",
+			want:    "components:\n  schemas:\n    Order:\n      type: object\n    Status:\n      type: integer\n",
+		},
+		{
+			name:    "Without components",
+			content: "openapi: 3.1.0\n",
+			want:    "openapi: 3.1.0\ncomponents:\n  schemas:\n    Order:\n      type: object\n    Status:\n      type: integer\n",
+		},
+		{
+			name:    "Flow style schemas",
+			content: "components:\n  schemas: {Order: {type: string}}\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeYAMLSchemas(tt.content, schemas)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mergeYAMLSchemas() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("mergeYAMLSchemas() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
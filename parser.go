@@ -18,7 +18,12 @@ func parseFile(path string) ([]middleware, error) {
 		return nil, err
 	}
 
-	return parse(string(fileContent))
+	objects, err := parse(string(fileContent))
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, validateMiddlewares(objects)
 }
 
 /**
@@ -38,8 +43,9 @@ func readFile(path string) ([]byte, error) {
 /**
 Get content and parse it to the middleware language.
 Scanning row by row ignoring spaces, and do validation checks do (with the help methods).
-Content can contain few classes and enums.
-Classes and enums declaration should be like:
+Content can contain few classes, enums, services, channels and extern types.
+Channels can be declared without the { } block when they don't have headers.
+The declarations should be like:
 
 class className
 {
@@ -52,6 +58,25 @@ enum enumName
 	value 5
 	anotherValue 8
 }
+
+service serviceName
+{
+	getUser(GetUserRequest) GetUserResponse @http(GET, "/users/{id}")
+}
+
+channel topic.name payloadClass @key(payloadMember)
+{
+	headerName type
+}
+
+extern type typeName
+{
+	go "github.com/acme/money.Money"
+	ts "@acme/money#Money"
+}
+
+Every declaration, data member and enum value can be followed by annotations,
+like: createdAt date @go.type("time.Time")
 */
 func parse(fileContent string) ([]middleware, error) {
 	scanner := bufio.NewScanner(strings.NewReader(fileContent))
@@ -63,73 +88,124 @@ func parse(fileContent string) ([]middleware, error) {
 	currentLine := 0
 
 	for scanner.Scan() {
-		line := trimContent(scanner.Text())
 		currentLine++
 
-		// User can just skip lines
-		if line == "" {
-			continue
-		}
+		// A line can hold few statements, like: extern type Money { go "money.Money"; ts "money#Money" }
+		for _, line := range splitStatements(trimContent(scanner.Text())) {
+			// User can just skip lines
+			if line == "" {
+				continue
+			}
 
-		// Receiving { while reading another object
-		if line == "{" {
-			if startAddMembers {
-				return nil, errors.New(fmt.Sprintf(
-					"failed to parse. Unexpected { token in line %v", currentLine))
+			// Receiving { while reading another object
+			if line == "{" {
+				if startAddMembers {
+					return nil, errors.New(fmt.Sprintf(
+						"failed to parse. Unexpected { token in line %v", currentLine))
+				}
+
+				startAddMembers = true
+				continue
 			}
 
-			startAddMembers = true
-			continue
-		}
+			// Channels can be declared without a block, so a new declaration closes them
+			if c, ok := currentMiddleware.(*channel); ok && !startAddMembers {
+				result = append(result, c)
+				currentMiddleware = nil
+			}
 
-		// Finished to read the current object. Close him and add to result
-		if line == "}" {
-			result = append(result, currentMiddleware)
-			currentMiddleware = nil
-			startAddMembers = false
-			continue
-		}
+			// Finished to read the current object. Close him and add to result
+			if line == "}" {
+				result = append(result, currentMiddleware)
+				currentMiddleware = nil
+				startAddMembers = false
+				continue
+			}
 
-		// If the line isn't { or }, and the current middleware is nil
-		// we expect a new middleware declare, meaning a new class or enum title
-		if currentMiddleware == nil {
-			mw, err := readMiddlewareDeclare(line)
-			if err != nil {
-				return nil, err
+			// If the line isn't { or }, and the current middleware is nil
+			// we expect a new middleware declare, meaning a new class or enum title
+			if currentMiddleware == nil {
+				mw, err := readMiddlewareDeclare(line)
+				if err != nil {
+					return nil, err
+				}
+
+				currentMiddleware = mw
+				continue
 			}
 
-			currentMiddleware = mw
-			continue
-		}
+			// We got to a data member or enum value before getting {
+			// after the class/enum declare
+			if !startAddMembers {
+				return nil, errors.New(fmt.Sprintf(
+					"expected for { token before starting get values in row %v", currentLine))
+			}
 
-		// We got to a data member or enum value before getting {
-		// after the class/enum declare
-		if !startAddMembers {
-			return nil, errors.New(fmt.Sprintf(
-				"expected for { token before starting get values in row %v", currentLine))
+			if err := readMiddlewareValue(currentMiddleware, line); err != nil {
+				return nil, err
+			}
 		}
+	}
 
-		if err := readMiddlewareValue(currentMiddleware, line); err != nil {
-			return nil, err
-		}
+	// A channel without a block in the end of the file
+	if c, ok := currentMiddleware.(*channel); ok && !startAddMembers {
+		result = append(result, c)
 	}
 
 	return result, nil
 }
 
 func readMiddlewareDeclare(line string) (middleware, error) {
-	splittedLine := strings.Split(line, " ")
+	splittedLine := splitTokens(line)
 
-	if len(splittedLine) != 2 {
+	if len(splittedLine) < 2 {
 		return nil, errors.New(fmt.Sprintf(
 			"tried to declare middleware, but got string with the wrong length %s", line))
 	}
 
+	// Channels are declared like "channel orders.created orderCreated @key(orderId)"
+	if splittedLine[0] == "channel" {
+		if len(splittedLine) < 3 {
+			return nil, errors.New(fmt.Sprintf(
+				"tried to declare channel, but got wrong declare %s", line))
+		}
+
+		annotations, err := readAnnotations(splittedLine[3:])
+		if err != nil {
+			return nil, err
+		}
+
+		return newChannel(splittedLine[1], splittedLine[2], annotations), nil
+	}
+
+	// Extern types are declared like "extern type Money"
+	if splittedLine[0] == "extern" {
+		if len(splittedLine) != 3 || splittedLine[1] != "type" {
+			return nil, errors.New(fmt.Sprintf(
+				"tried to declare extern type, but got wrong declare %s", line))
+		}
+
+		return newExternType(splittedLine[2]), nil
+	}
+
+	annotations, err := readAnnotations(splittedLine[2:])
+	if err != nil {
+		return nil, err
+	}
+
 	switch splittedLine[0] {
 	case "class":
-		return newClass(splittedLine[1]), nil
+		c := newClass(splittedLine[1])
+		c.annotations = annotations
+		return c, nil
 	case "enum":
-		return newEnum(splittedLine[1]), nil
+		e := newEnum(splittedLine[1])
+		e.annotations = annotations
+		return e, nil
+	case "service":
+		s := newService(splittedLine[1])
+		s.annotations = annotations
+		return s, nil
 	}
 
 	return nil, errors.New(
@@ -137,14 +213,202 @@ func readMiddlewareDeclare(line string) (middleware, error) {
 }
 
 func readMiddlewareValue(middleware middleware, line string) error {
-	splittedLine := strings.Split(line, " ")
+	splittedLine := splitTokens(line)
 
-	if len(splittedLine) != 2 {
+	if len(splittedLine) < 2 {
 		return errors.New(fmt.Sprintf(
 			"tried to read a new value, but got string with the wrong length %s", line))
 	}
 
-	return middleware.addValue(splittedLine[0], splittedLine[1])
+	annotations, err := readAnnotations(splittedLine[2:])
+	if err != nil {
+		return err
+	}
+
+	return middleware.addValue(splittedLine[0], unquote(splittedLine[1]), annotations)
+}
+
+/**
+Split a line to statements.
+Braces and semicolons outside quotes end a statement, and braces are returned as statements
+of their own, so a whole declaration can be written in a single line.
+*/
+func splitStatements(line string) []string {
+	statements := make([]string, 0)
+	current := ""
+	inQuotes := false
+
+	addCurrent := func() {
+		if trimmed := strings.TrimSpace(current); trimmed != "" {
+			statements = append(statements, trimmed)
+		}
+
+		current = ""
+	}
+
+	for _, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+
+		if inQuotes {
+			current += string(r)
+			continue
+		}
+
+		switch r {
+		case '{', '}':
+			addCurrent()
+			statements = append(statements, string(r))
+		case ';':
+			addCurrent()
+		default:
+			current += string(r)
+		}
+	}
+
+	addCurrent()
+
+	return statements
+}
+
+/**
+Split a line to tokens by spaces.
+Spaces inside quotes or parentheses don't split the line, so an annotation
+like @http(GET, "/users/{id}") stays a single token.
+*/
+func splitTokens(line string) []string {
+	tokens := make([]string, 0)
+	current := ""
+	inQuotes := false
+	depth := 0
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '(' && !inQuotes:
+			depth++
+		case r == ')' && !inQuotes:
+			depth--
+		case r == ' ' && !inQuotes && depth == 0:
+			if current != "" {
+				tokens = append(tokens, current)
+			}
+
+			current = ""
+			continue
+		}
+
+		current += string(r)
+	}
+
+	if current != "" {
+		tokens = append(tokens, current)
+	}
+
+	return tokens
+}
+
+/**
+Read the annotations written after a declaration or a value.
+Return nil if there are no annotations at all.
+*/
+func readAnnotations(tokens []string) ([]*annotation, error) {
+	var annotations []*annotation
+
+	for _, token := range tokens {
+		a, err := parseAnnotation(token)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations = append(annotations, a)
+	}
+
+	return annotations, nil
+}
+
+/**
+Parse a single annotation token.
+Annotations are written like @name, @name(argument, argument) or with a language
+namespace like @go.type("time.Time"). Quotes around the arguments are removed.
+*/
+func parseAnnotation(token string) (*annotation, error) {
+	if !strings.HasPrefix(token, "@") {
+		return nil, errors.New(fmt.Sprintf("expected annotation, but got %s", token))
+	}
+
+	body := token[1:]
+	var arguments []string
+
+	if open := strings.Index(body, "("); open != -1 {
+		if !strings.HasSuffix(body, ")") {
+			return nil, errors.New(fmt.Sprintf("annotation %s doesn't close its arguments", token))
+		}
+
+		arguments = splitArguments(body[open+1 : len(body)-1])
+		body = body[:open]
+	}
+
+	namespace := ""
+	if dot := strings.Index(body, "."); dot != -1 {
+		namespace = body[:dot]
+		body = body[dot+1:]
+
+		if _, ok := annotationNamespaces[namespace]; !ok {
+			return nil, errors.New(fmt.Sprintf("annotation %s uses unknown language %s", token, namespace))
+		}
+	}
+
+	if body == "" {
+		return nil, errors.New(fmt.Sprintf("annotation %s doesn't have a name", token))
+	}
+
+	return &annotation{
+		namespace: namespace,
+		name:      body,
+		arguments: arguments,
+	}, nil
+}
+
+/**
+Split annotation arguments by commas, ignoring commas inside quotes.
+*/
+func splitArguments(value string) []string {
+	arguments := make([]string, 0)
+	current := ""
+	inQuotes := false
+
+	for _, r := range value {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+
+		if r == ',' && !inQuotes {
+			arguments = append(arguments, unquote(current))
+			current = ""
+			continue
+		}
+
+		current += string(r)
+	}
+
+	if strings.TrimSpace(current) != "" || len(arguments) > 0 {
+		arguments = append(arguments, unquote(current))
+	}
+
+	return arguments
+}
+
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+
+	if len(value) > 1 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+
+	return value
 }
 
 func trimContent(content string) string {
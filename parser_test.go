@@ -135,6 +135,49 @@ func Test_parse(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Extern type in a single line",
+			args: args{
+				fileContent: "extern type Money { go \"github.com/acme/money.Money\"; ts \"@acme/money#Money\" }",
+			},
+			want: []middleware{
+				&externType{
+					name: "Money",
+					languageNames: map[languageType]string{
+						LanguageTypeGo:         "github.com/acme/money.Money",
+						LanguageTypeTypescript: "@acme/money#Money",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Extern type with unknown language",
+			args: args{
+				fileContent: "extern type Money\n{\ncobol \"MONEY\"\n}",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Channels with and without block",
+			args: args{
+				fileContent: "channel orders.created order @key(orderId)\n{\ntraceId string\n}\n" +
+					"channel orders.closed order\nchannel orders.paid order",
+			},
+			want: []middleware{
+				&channel{
+					topic:       "orders.created",
+					payloadType: "order",
+					keyField:    "orderId",
+					headers:     []*channelHeader{{name: "traceId", headerType: "string"}},
+					annotations: []*annotation{{name: "key", arguments: []string{"orderId"}}},
+				},
+				&channel{topic: "orders.closed", payloadType: "order", headers: []*channelHeader{}},
+				&channel{topic: "orders.paid", payloadType: "order", headers: []*channelHeader{}},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -188,6 +231,40 @@ func Test_readMiddlewareDeclare(t *testing.T) {
 			args:    args{line: "SOME_VALUE 5"},
 			want:    nil,
 			wantErr: true},
+		{name: "Creating valid service",
+			args:    args{line: "service userService"},
+			want:    newService("userService"),
+			wantErr: false},
+		{name: "Creating valid extern type",
+			args:    args{line: "extern type Money"},
+			want:    newExternType("Money"),
+			wantErr: false},
+		{name: "Creating valid channel",
+			args:    args{line: "channel orders.created order"},
+			want:    newChannel("orders.created", "order", nil),
+			wantErr: false},
+		{name: "Channel without payload",
+			args:    args{line: "channel orders.created"},
+			want:    nil,
+			wantErr: true},
+		{name: "Extern without type keyword",
+			args:    args{line: "extern Money"},
+			want:    nil,
+			wantErr: true},
+		{name: "Class with annotations",
+			args: args{line: "class bla @go.name(\"Bla\")"},
+			want: &class{
+				name:        "bla",
+				dataMembers: []*dataMember{},
+				annotations: []*annotation{
+					{namespace: "go", name: "name", arguments: []string{"Bla"}},
+				},
+			},
+			wantErr: false},
+		{name: "Class with invalid annotation",
+			args:    args{line: "class bla go.name"},
+			want:    nil,
+			wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -206,6 +283,8 @@ func Test_readMiddlewareDeclare(t *testing.T) {
 func Test_readMiddlewareValue(t *testing.T) {
 	classArg := newClass("testClass")
 	enumArg := newEnum("testEnum")
+	annotatedClassArg := newClass("annotatedClass")
+	serviceArg := newService("testService")
 
 	type args struct {
 		middleware middleware
@@ -235,6 +314,38 @@ func Test_readMiddlewareValue(t *testing.T) {
 		{name: "Empty line",
 			args:    args{middleware: enumArg, line: ""},
 			wantErr: true},
+
+		{name: "Valid service method",
+			args:    args{middleware: serviceArg, line: "getUser(GetUserRequest) GetUserResponse @http(GET, \"/users/{id}\")"},
+			wantErr: false},
+
+		{name: "Service method without request",
+			args:    args{middleware: serviceArg, line: "ping() PingResponse @http(get, \"/ping\")"},
+			wantErr: false},
+
+		{name: "Service method without http annotation",
+			args:    args{middleware: serviceArg, line: "deleteUser(DeleteUserRequest) DeleteUserResponse"},
+			wantErr: true},
+
+		{name: "Service method with unsupported http method",
+			args:    args{middleware: serviceArg, line: "headUser(HeadUserRequest) HeadUserResponse @http(HEAD, \"/users\")"},
+			wantErr: true},
+
+		{name: "Service method without parentheses",
+			args:    args{middleware: serviceArg, line: "getUsers GetUsersResponse @http(GET, \"/users\")"},
+			wantErr: true},
+
+		{name: "Duplicate service method",
+			args:    args{middleware: serviceArg, line: "getUser(GetUserRequest) GetUserResponse @http(GET, \"/users\")"},
+			wantErr: true},
+
+		{name: "Data member with annotations",
+			args:    args{middleware: annotatedClassArg, line: "createdAt date @go.type(\"time.Time\") @kotlin.type(\"java.time.Instant\")"},
+			wantErr: false},
+
+		{name: "Data member with unknown annotation language",
+			args:    args{middleware: annotatedClassArg, line: "updatedAt date @cobol.type(\"PIC\")"},
+			wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -274,6 +385,174 @@ func Test_readMiddlewareValue(t *testing.T) {
 	if !reflect.DeepEqual(enumArg, expectedEnum) {
 		t.Errorf("readMiddlewareDeclare() got unexpected class data members")
 	}
+
+	expectedMethods := []*serviceMethod{
+		{
+			name:         "getUser",
+			requestType:  "GetUserRequest",
+			responseType: "GetUserResponse",
+			httpMethod:   "GET",
+			path:         "/users/{id}",
+			annotations:  []*annotation{{name: "http", arguments: []string{"GET", "/users/{id}"}}},
+		},
+		{
+			name:         "ping",
+			requestType:  "",
+			responseType: "PingResponse",
+			httpMethod:   "GET",
+			path:         "/ping",
+			annotations:  []*annotation{{name: "http", arguments: []string{"get", "/ping"}}},
+		},
+	}
+
+	if !reflect.DeepEqual(serviceArg.methods, expectedMethods) {
+		t.Errorf("readMiddlewareValue() got unexpected service methods")
+	}
+
+	expectedAnnotations := []*annotation{
+		{namespace: "go", name: "type", arguments: []string{"time.Time"}},
+		{namespace: "kotlin", name: "type", arguments: []string{"java.time.Instant"}},
+	}
+
+	if len(annotatedClassArg.dataMembers) != 1 ||
+		!reflect.DeepEqual(annotatedClassArg.dataMembers[0].annotations, expectedAnnotations) {
+		t.Errorf("readMiddlewareValue() got unexpected data member annotations")
+	}
+}
+
+func Test_splitStatements(t *testing.T) {
+	type args struct {
+		line string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{name: "Single statement", args: args{line: "class test"}, want: []string{"class test"}},
+		{name: "Brace only", args: args{line: "{"}, want: []string{"{"}},
+		{
+			name: "Whole declaration",
+			args: args{line: "extern type Money { go \"money.Money\"; ts \"money#Money\" }"},
+			want: []string{"extern type Money", "{", "go \"money.Money\"", "ts \"money#Money\"", "}"},
+		},
+		{
+			name: "Braces inside quotes",
+			args: args{line: "a string @http(GET, \"/users/{id}\")"},
+			want: []string{"a string @http(GET, \"/users/{id}\")"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.args.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitTokens(t *testing.T) {
+	type args struct {
+		line string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{name: "Simple line", args: args{line: "name string"}, want: []string{"name", "string"}},
+		{name: "Empty line", args: args{line: ""}, want: []string{}},
+		{
+			name: "Spaces inside annotation",
+			args: args{line: "getUser(GetUserRequest) GetUserResponse @http(GET, \"/users/{id}\")"},
+			want: []string{"getUser(GetUserRequest)", "GetUserResponse", "@http(GET, \"/users/{id}\")"},
+		},
+		{
+			name: "Spaces inside quotes",
+			args: args{line: "a string @ts.type(\"string | null\")"},
+			want: []string{"a", "string", "@ts.type(\"string | null\")"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitTokens(tt.args.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitTokens() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseAnnotation(t *testing.T) {
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *annotation
+		wantErr bool
+	}{
+		{
+			name:    "Without arguments",
+			args:    args{token: "@key"},
+			want:    &annotation{name: "key"},
+			wantErr: false,
+		},
+		{
+			name:    "Language annotation",
+			args:    args{token: "@csharp.type(\"DateTimeOffset\")"},
+			want:    &annotation{namespace: "csharp", name: "type", arguments: []string{"DateTimeOffset"}},
+			wantErr: false,
+		},
+		{
+			name:    "Few arguments",
+			args:    args{token: "@http(GET, \"/users/{id}\")"},
+			want:    &annotation{name: "http", arguments: []string{"GET", "/users/{id}"}},
+			wantErr: false,
+		},
+		{
+			name:    "Comma inside quotes",
+			args:    args{token: "@go.type(\"map[string]int\", \"a,b\")"},
+			want:    &annotation{namespace: "go", name: "type", arguments: []string{"map[string]int", "a,b"}},
+			wantErr: false,
+		},
+		{
+			name:    "Missing @",
+			args:    args{token: "go.type(\"int\")"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown language",
+			args:    args{token: "@cobol.type(\"int\")"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Unclosed arguments",
+			args:    args{token: "@go.type(\"int\""},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Without name",
+			args:    args{token: "@go."},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAnnotation(tt.args.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseAnnotation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAnnotation() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
 
 func Test_trimContent(t *testing.T) {
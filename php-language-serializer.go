@@ -0,0 +1,369 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+/**
+Generate PHP 8.1 classes with readonly promoted properties, JsonSerializable and fromArray,
+and backed enums. Every class and enum is generated into its own PSR-4 file, like "OrderItem.php".
+*/
+type phpLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newPhpLanguageSerializer() *phpLanguageSerializer {
+	result := &phpLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "int"
+	result.typesMap["string"] = "string"
+	result.typesMap["double"] = "float"
+	result.typesMap["float"] = "float"
+	result.typesMap["char"] = "string"
+	result.typesMap["byte"] = "int"
+	result.typesMap["date"] = "\\DateTimeImmutable"
+
+	return result
+}
+
+func (p *phpLanguageSerializer) getType() languageType {
+	return LanguageTypePhp
+}
+
+func (p *phpLanguageSerializer) getTypeName() string {
+	return "php"
+}
+
+func (p *phpLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for PHP
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := p.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result, nil
+}
+
+func (p *phpLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return p.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return p.serializeEnum(enum, serializerInfo)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+/**
+Serialize the beginning of a PHP file, with the namespace of the package, like "Acme\Billing" for "Acme.Billing".
+*/
+func (p *phpLanguageSerializer) serializeDeclaration(serializerInfo *serializerInfo) string {
+	result := "<?php\n\n" +
+		"// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n" +
+		"declare(strict_types=1);\n\n"
+
+	if serializerInfo.packageName == "" {
+		return result
+	}
+
+	return result + fmt.Sprintf("namespace %s;\n\n", strings.Replace(serializerInfo.packageName, ".", "\\", -1))
+}
+
+func (p *phpLanguageSerializer) serializeImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	sort.Strings(imports)
+
+	result := ""
+	for _, imp := range imports {
+		result += fmt.Sprintf("use %s;\n", imp)
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a PHP type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (p *phpLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := p.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypePhp)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "Acme\Money\Money" is imported as is and used as "Money"
+	separator := strings.LastIndex(externName, "\\")
+	if separator == -1 {
+		return externName, "", true
+	}
+
+	return externName[separator+1:], externName, true
+}
+
+/**
+Get the PHP class name of a class or an enum, taking the name annotation into account.
+*/
+func (p *phpLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypePhp, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Serialize the PHP type of a gen file type. Lists and maps are arrays, so the doc type describes them,
+like "list<OrderItem>" and "array<string, OrderStatus>".
+Return the doc type and the imports the type needs.
+*/
+func (p *phpLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) (string, string, []string) {
+	if isList, listType := isList(typeName); isList {
+		_, itemDocType, imports := p.typeName(listType, serializerInfo)
+
+		return "array", fmt.Sprintf("list<%s>", itemDocType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		_, keyDocType, imports := p.typeName(mapKeyType, serializerInfo)
+		_, valueDocType, valueImports := p.typeName(mapValueType, serializerInfo)
+
+		for _, imp := range valueImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		return "array", fmt.Sprintf("array<%s, %s>", keyDocType, valueDocType), imports
+	}
+
+	if knownType, imp, isKnown := p.mapType(typeName, serializerInfo); isKnown {
+		return knownType, knownType, appendImport([]string{}, imp)
+	}
+
+	// The models are generated into the same namespace, so they don't need imports
+	className := p.className(typeName, serializerInfo)
+
+	return className, className, []string{}
+}
+
+/**
+Serialize the expression that creates a value of a gen file type from a decoded JSON value.
+Classes and extern types are created with fromArray, enums with from, and dates are parsed.
+*/
+func (p *phpLanguageSerializer) fromArrayValue(typeName string, value string, serializerInfo *serializerInfo) string {
+	if isList, listType := isList(typeName); isList {
+		return p.mapArrayValue(p.fromArrayValue(listType, "$item", serializerInfo), value)
+	}
+
+	if isMap, _, mapValueType := isMap(typeName); isMap {
+		return p.mapArrayValue(p.fromArrayValue(mapValueType, "$item", serializerInfo), value)
+	}
+
+	if typeName == "date" {
+		return fmt.Sprintf("new \\DateTimeImmutable(%s)", value)
+	}
+
+	if _, isPrimitive := p.typesMap[typeName]; isPrimitive {
+		return value
+	}
+
+	phpType, _, _ := p.typeName(typeName, serializerInfo)
+
+	// Extern types without a PHP name are referenced by their gen name, and are still created with fromArray
+	_, isClass := serializerInfo.classes[typeName]
+	_, isExtern := serializerInfo.externTypes[typeName]
+	if isClass || isExtern {
+		return fmt.Sprintf("%s::fromArray(%s)", phpType, value)
+	}
+
+	return fmt.Sprintf("%s::from(%s)", phpType, value)
+}
+
+/**
+Serialize the expression that writes a value of a gen file type to JSON.
+Classes are JsonSerializable and backed enums are written by their values, so only dates are formatted,
+and maps are written as objects, since empty arrays are written as JSON lists.
+*/
+func (p *phpLanguageSerializer) jsonValue(typeName string, value string) string {
+	if isList, listType := isList(typeName); isList {
+		return p.mapArrayValue(p.jsonValue(listType, "$item"), value)
+	}
+
+	if isMap, _, mapValueType := isMap(typeName); isMap {
+		return fmt.Sprintf("(object) %s", p.mapArrayValue(p.jsonValue(mapValueType, "$item"), value))
+	}
+
+	if typeName == "date" {
+		return fmt.Sprintf("%s->format(\\DateTimeInterface::ATOM)", value)
+	}
+
+	return value
+}
+
+/**
+Map the items of an array with array_map, which keeps the keys of maps.
+Arrays which items don't need a conversion are used as they are.
+*/
+func (p *phpLanguageSerializer) mapArrayValue(itemValue string, value string) string {
+	if itemValue == "$item" {
+		return value
+	}
+
+	return fmt.Sprintf("array_map(fn ($item) => %s, %s)", itemValue, value)
+}
+
+/**
+Get the property name of a data member, taking the name annotation into account.
+*/
+func (p *phpLanguageSerializer) propertyName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypePhp, "name"); ok {
+		return name
+	}
+
+	return toCamelCase(member.name)
+}
+
+func (p *phpLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypePhp, "name"); ok {
+		className = name
+	}
+
+	imports := findLanguageImports(class.annotations, LanguageTypePhp)
+
+	docTypes := ""
+	properties := ""
+	arguments := ""
+	jsonValues := ""
+
+	for _, member := range class.dataMembers {
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypePhp) {
+			imports = appendUnique(imports, imp)
+		}
+
+		propertyName := p.propertyName(member)
+		jsonName := toCamelCase(member.name)
+		data := fmt.Sprintf("$data['%s']", jsonName)
+
+		memberType, docType, memberImports := p.typeName(member.memberType, serializerInfo)
+		fromArrayValue := p.fromArrayValue(member.memberType, data, serializerInfo)
+		jsonValue := p.jsonValue(member.memberType, "$this->"+propertyName)
+
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypePhp, "type"); ok {
+			// The overridden type is trusted to be created from the JSON value and written as is
+			memberType, docType, memberImports = overrideType, overrideType, []string{}
+			fromArrayValue, jsonValue = data, "$this->"+propertyName
+		}
+
+		for _, imp := range memberImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		// Arrays don't have typed items, so the doc comment describes them
+		if memberType != docType {
+			docTypes += fmt.Sprintf("     * @param %s $%s\n", docType, propertyName)
+		}
+
+		properties += fmt.Sprintf("        public readonly %s $%s,\n", memberType, propertyName)
+		arguments += fmt.Sprintf("            %s: %s,\n", propertyName, fromArrayValue)
+		jsonValues += fmt.Sprintf("            '%s' => %s,\n", jsonName, jsonValue)
+	}
+
+	serializedCode := fmt.Sprintf("final class %s implements \\JsonSerializable\n{\n", className)
+
+	if len(class.dataMembers) > 0 {
+		if docTypes != "" {
+			serializedCode += fmt.Sprintf("    /**\n%s     */\n", docTypes)
+		}
+
+		serializedCode += "    public function __construct(\n"
+		serializedCode += properties
+		serializedCode += "    ) {\n    }\n\n"
+	}
+
+	serializedCode += "    /**\n     * @param array<string, mixed> $data\n     */\n"
+	serializedCode += "    public static function fromArray(array $data): self\n    {\n"
+
+	if len(class.dataMembers) > 0 {
+		serializedCode += fmt.Sprintf("        return new self(\n%s        );\n", arguments)
+	} else {
+		serializedCode += "        return new self();\n"
+	}
+
+	serializedCode += "    }\n\n"
+
+	// An empty array is written as a JSON list, so an empty class is written as an empty object
+	if len(class.dataMembers) > 0 {
+		serializedCode += "    public function jsonSerialize(): array\n    {\n"
+		serializedCode += fmt.Sprintf("        return [\n%s        ];\n", jsonValues)
+	} else {
+		serializedCode += "    public function jsonSerialize(): object\n    {\n"
+		serializedCode += "        return new \\stdClass();\n"
+	}
+
+	serializedCode += "    }\n}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.php", className),
+		p.serializeDeclaration(serializerInfo)+p.serializeImports(imports)+serializedCode), nil
+}
+
+func (p *phpLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypePhp, "name"); ok {
+		enumName = name
+	}
+
+	// Backed enums are written and read by their values, which must be unique
+	serializedCode := fmt.Sprintf("enum %s: int\n{\n", enumName)
+	values := make(map[int]string)
+
+	for _, value := range enum.enumValues {
+		if name, ok := values[value.value]; ok {
+			return nil, errors.New(fmt.Sprintf("%s.%s and %s.%s have the same value, which PHP enums don't allow",
+				enum.name, name, enum.name, value.name))
+		}
+
+		values[value.value] = value.name
+
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypePhp, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("    case %s = %v;\n", valueName, value.value)
+	}
+
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.php", enumName), p.serializeDeclaration(serializerInfo)+serializedCode), nil
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_phpLanguageSerializer_getType(t *testing.T) {
+	if got := newPhpLanguageSerializer().getType(); got != LanguageTypePhp {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypePhp)
+	}
+}
+
+func Test_phpLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newPhpLanguageSerializer().getTypeName(); got != "php" {
+		t.Errorf("getTypeName() = %v, want %v", got, "php")
+	}
+}
+
+func Test_phpLanguageSerializer_generateCode(t *testing.T) {
+	testClass := newClass("orderItem")
+	_ = testClass.addValue("price", "double", nil)
+
+	testEnum := newEnum("orderStatus")
+	_ = testEnum.addValue("active", "1", nil)
+
+	testService, _ := getTestService()
+
+	generatedCode, err := newPhpLanguageSerializer().generateCode(
+		[]middleware{testClass, testEnum, testService}, &serializerInfo{packageName: "Acme.Billing"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(generatedCode) != 2 {
+		t.Errorf("generateCode() generated %v files. expected 2", len(generatedCode))
+		return
+	}
+
+	if generatedCode[0].fileName != "OrderItem.php" || generatedCode[1].fileName != "OrderStatus.php" {
+		t.Errorf("generateCode() file names = %v, %v", generatedCode[0].fileName, generatedCode[1].fileName)
+	}
+
+	if !strings.Contains(generatedCode[0].code, "declare(strict_types=1);\n\nnamespace Acme\\Billing;\n\n") {
+		t.Errorf("generateCode() code doesn't declare the namespace.\ncode: %v", generatedCode[0].code)
+	}
+}
+
+func Test_phpLanguageSerializer_serializeClass(t *testing.T) {
+	orderItem := newClass("orderItem")
+
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "int", name: "id"},
+						{memberType: "date", name: "createdAt"},
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "map<string,orderStatus>", name: "statuses"},
+					},
+				},
+				serializerInfo: &serializerInfo{classes: map[string]*class{"orderItem": orderItem}},
+			},
+			want: &generatedCode{
+				fileName: "Order.php",
+				code: "final class Order implements \\JsonSerializable\n" +
+					"{\n" +
+					"    /**\n" +
+					"     * @param list<OrderItem> $items\n" +
+					"     * @param array<string, OrderStatus> $statuses\n" +
+					"     */\n" +
+					"    public function __construct(\n" +
+					"        public readonly int $id,\n" +
+					"        public readonly \\DateTimeImmutable $createdAt,\n" +
+					"        public readonly array $items,\n" +
+					"        public readonly array $statuses,\n" +
+					"    ) {\n" +
+					"    }\n\n" +
+					"    /**\n" +
+					"     * @param array<string, mixed> $data\n" +
+					"     */\n" +
+					"    public static function fromArray(array $data): self\n" +
+					"    {\n" +
+					"        return new self(\n" +
+					"            id: $data['id'],\n" +
+					"            createdAt: new \\DateTimeImmutable($data['createdAt']),\n" +
+					"            items: array_map(fn ($item) => OrderItem::fromArray($item), $data['items']),\n" +
+					"            statuses: array_map(fn ($item) => OrderStatus::from($item), $data['statuses']),\n" +
+					"        );\n" +
+					"    }\n\n" +
+					"    public function jsonSerialize(): array\n" +
+					"    {\n" +
+					"        return [\n" +
+					"            'id' => $this->id,\n" +
+					"            'createdAt' => $this->createdAt->format(\\DateTimeInterface::ATOM),\n" +
+					"            'items' => $this->items,\n" +
+					"            'statuses' => (object) $this->statuses,\n" +
+					"        ];\n" +
+					"    }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Test.php",
+				code: "final class Test implements \\JsonSerializable\n" +
+					"{\n" +
+					"    /**\n" +
+					"     * @param array<string, mixed> $data\n" +
+					"     */\n" +
+					"    public static function fromArray(array $data): self\n" +
+					"    {\n" +
+					"        return new self();\n" +
+					"    }\n\n" +
+					"    public function jsonSerialize(): object\n" +
+					"    {\n" +
+					"        return new \\stdClass();\n" +
+					"    }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides and extern types",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+							annotations: []*annotation{
+								{namespace: "php", name: "name", arguments: []string{"cost"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "php", name: "type", arguments: []string{"Uuid"}},
+								{namespace: "php", name: "import", arguments: []string{"Ramsey\\Uuid\\Uuid"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "php", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypePhp: "Acme\\Money\\Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "Renamed.php",
+				code: "use Acme\\Money\\Money;\n" +
+					"use Ramsey\\Uuid\\Uuid;\n\n" +
+					"final class Renamed implements \\JsonSerializable\n" +
+					"{\n" +
+					"    public function __construct(\n" +
+					"        public readonly Money $cost,\n" +
+					"        public readonly Uuid $id,\n" +
+					"    ) {\n" +
+					"    }\n\n" +
+					"    /**\n" +
+					"     * @param array<string, mixed> $data\n" +
+					"     */\n" +
+					"    public static function fromArray(array $data): self\n" +
+					"    {\n" +
+					"        return new self(\n" +
+					"            cost: Money::fromArray($data['price']),\n" +
+					"            id: $data['id'],\n" +
+					"        );\n" +
+					"    }\n\n" +
+					"    public function jsonSerialize(): array\n" +
+					"    {\n" +
+					"        return [\n" +
+					"            'price' => $this->cost,\n" +
+					"            'id' => $this->id,\n" +
+					"        ];\n" +
+					"    }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type without a PHP name",
+			args: args{
+				class: &class{
+					name:        "test",
+					dataMembers: []*dataMember{{memberType: "Money", name: "price"}},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {name: "Money", languageNames: map[languageType]string{}},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "Test.php",
+				code: "final class Test implements \\JsonSerializable\n" +
+					"{\n" +
+					"    public function __construct(\n" +
+					"        public readonly Money $price,\n" +
+					"    ) {\n" +
+					"    }\n\n" +
+					"    /**\n" +
+					"     * @param array<string, mixed> $data\n" +
+					"     */\n" +
+					"    public static function fromArray(array $data): self\n" +
+					"    {\n" +
+					"        return new self(\n" +
+					"            price: Money::fromArray($data['price']),\n" +
+					"        );\n" +
+					"    }\n\n" +
+					"    public function jsonSerialize(): array\n" +
+					"    {\n" +
+					"        return [\n" +
+					"            'price' => $this->price,\n" +
+					"        ];\n" +
+					"    }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "Holder.php",
+				code: "final class Holder implements \\JsonSerializable\n" +
+					"{\n" +
+					"    public function __construct(\n" +
+					"        public readonly Evt $e,\n" +
+					"        public readonly Category $kind,\n" +
+					"    ) {\n" +
+					"    }\n\n" +
+					"    /**\n" +
+					"     * @param array<string, mixed> $data\n" +
+					"     */\n" +
+					"    public static function fromArray(array $data): self\n" +
+					"    {\n" +
+					"        return new self(\n" +
+					"            e: Evt::fromArray($data['e']),\n" +
+					"            kind: Category::from($data['kind']),\n" +
+					"        );\n" +
+					"    }\n\n" +
+					"    public function jsonSerialize(): array\n" +
+					"    {\n" +
+					"        return [\n" +
+					"            'e' => $this->e,\n" +
+					"            'kind' => $this->kind,\n" +
+					"        ];\n" +
+					"    }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPhpLanguageSerializer()
+			got, err := p.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, p.serializeDeclaration(tt.args.serializerInfo), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_phpLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{name: "active", value: 5},
+						{name: "onHold", value: 8, annotations: []*annotation{
+							{namespace: "php", name: "name", arguments: []string{"Paused"}},
+						}},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "OrderStatus.php",
+				code: "enum OrderStatus: int\n" +
+					"{\n" +
+					"    case Active = 5;\n" +
+					"    case Paused = 8;\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Duplicate values",
+			args: args{
+				enum: &enum{
+					name: "test",
+					enumValues: []*enumValue{
+						{name: "a", value: 1},
+						{name: "b", value: 1},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPhpLanguageSerializer()
+			got, err := p.serializeEnum(tt.args.enum, &serializerInfo{})
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, p.serializeDeclaration(&serializerInfo{}), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
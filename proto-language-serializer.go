@@ -0,0 +1,308 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var protoMapKeyTypes = []string{"int32", "uint32", "string", "bool"}
+
+/**
+Generate a proto3 file with a message for every class and an enum for every enum.
+The field numbers are kept stable with @field annotations and a lock file, see fieldNumbers.
+*/
+type protoLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newProtoLanguageSerializer() *protoLanguageSerializer {
+	result := &protoLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "int32"
+	result.typesMap["string"] = "string"
+	result.typesMap["double"] = "double"
+	result.typesMap["float"] = "float"
+	result.typesMap["char"] = "string"
+	result.typesMap["byte"] = "uint32"
+	result.typesMap["date"] = "google.protobuf.Timestamp"
+
+	return result
+}
+
+func (p *protoLanguageSerializer) getType() languageType {
+	return LanguageTypeProto
+}
+
+func (p *protoLanguageSerializer) getTypeName() string {
+	return "proto"
+}
+
+func (p *protoLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	numbers, err := loadFieldNumbers(serializerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]string, 0)
+	definitions := make([]string, 0)
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			serialized, classImports, err := p.serializeClass(o, serializerInfo, numbers)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, imp := range classImports {
+				imports = appendUnique(imports, imp)
+			}
+
+			definitions = append(definitions, serialized)
+		case *enum:
+			definitions = append(definitions, p.serializeEnum(o))
+		}
+
+		// Extern types are hand-written, and services and channels aren't generated for proto
+	}
+
+	if err := numbers.save(); err != nil {
+		return nil, err
+	}
+
+	return []*generatedCode{newGeneratedCode("models.proto",
+		p.serializeDeclaration(serializerInfo, imports)+strings.Join(definitions, "\n"))}, nil
+}
+
+func (p *protoLanguageSerializer) serializeDeclaration(serializerInfo *serializerInfo, imports []string) string {
+	result := "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n" +
+		"syntax = \"proto3\";\n\n"
+
+	if serializerInfo.packageName != "" {
+		result += fmt.Sprintf("package %s;\n\n", serializerInfo.packageName)
+	}
+
+	if len(imports) == 0 {
+		return result
+	}
+
+	sorted := append([]string{}, imports...)
+	sort.Strings(sorted)
+
+	for _, imp := range sorted {
+		result += fmt.Sprintf("import \"%s\";\n", imp)
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a proto type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (p *protoLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := p.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			return primitiveType, "google/protobuf/timestamp.proto", true
+		}
+
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeProto)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "acme/money.proto#acme.Money" is imported from "acme/money.proto" and used as "acme.Money"
+	if file, message, ok := strings.Cut(externName, "#"); ok {
+		return message, file, true
+	}
+
+	return externName, "", true
+}
+
+/**
+Map a gen file type to a proto type, including other messages and enums.
+*/
+func (p *protoLanguageSerializer) elementType(typeName string, serializerInfo *serializerInfo) (string, string) {
+	if knownType, imp, isKnown := p.mapType(typeName, serializerInfo); isKnown {
+		return knownType, imp
+	}
+
+	return p.className(typeName, serializerInfo), ""
+}
+
+/**
+Get the proto message or enum name of a class or an enum, taking the name annotation into account.
+*/
+func (p *protoLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeProto, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Proto fields are snake case.
+*/
+func (p *protoLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeProto, "name"); ok {
+		return name
+	}
+
+	return toSnakeCase(member.name)
+}
+
+/**
+Serialize the proto type of a data member, with repeated for lists and map<K, V> for maps.
+Return the imports the type needs.
+*/
+func (p *protoLanguageSerializer) memberType(class *class, member *dataMember, serializerInfo *serializerInfo) (string, []string, error) {
+	imports := findLanguageImports(member.annotations, LanguageTypeProto)
+
+	if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeProto, "type"); ok {
+		return overrideType, imports, nil
+	}
+
+	if isList, listType := isList(member.memberType); isList {
+		itemType, imp := p.elementType(listType, serializerInfo)
+
+		return "repeated " + itemType, appendImport(imports, imp), nil
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
+		keyType, keyImport := p.elementType(mapKeyType, serializerInfo)
+		valueType, valueImport := p.elementType(mapValueType, serializerInfo)
+
+		isValidKey := false
+		for _, validKey := range protoMapKeyTypes {
+			isValidKey = isValidKey || keyType == validKey
+		}
+
+		if !isValidKey {
+			return "", nil, errors.New(fmt.Sprintf(
+				"data member %s of class %s is a map with %s keys, but proto map keys must be integers, strings or bools",
+				member.name, class.name, mapKeyType))
+		}
+
+		return fmt.Sprintf("map<%s, %s>", keyType, valueType), appendImport(appendImport(imports, keyImport), valueImport), nil
+	}
+
+	memberType, imp := p.elementType(member.memberType, serializerInfo)
+
+	return memberType, appendImport(imports, imp), nil
+}
+
+func (p *protoLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo, numbers *fieldNumbers) (string, []string, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeProto, "name"); ok {
+		className = name
+	}
+
+	memberNumbers, err := numbers.assign(class)
+	if err != nil {
+		return "", nil, err
+	}
+
+	imports := findLanguageImports(class.annotations, LanguageTypeProto)
+	serializedCode := fmt.Sprintf("message %s {\n", className)
+
+	for _, member := range class.dataMembers {
+		memberType, memberImports, err := p.memberType(class, member, serializerInfo)
+		if err != nil {
+			return "", nil, err
+		}
+
+		for _, imp := range memberImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		fieldName := p.fieldName(member)
+
+		// protoc gives every field a JSON name from its snake case name, which isn't always the gen file name
+		options := ""
+		if jsonName := toCamelCase(member.name); snakeToCamelCase(fieldName) != jsonName {
+			options = fmt.Sprintf(" [json_name = \"%s\"]", jsonName)
+		}
+
+		serializedCode += fmt.Sprintf("  %s %s = %v%s;\n", memberType, fieldName, memberNumbers[member.name], options)
+	}
+
+	// Removed data members keep their numbers and names, so they won't be used again
+	reservedNumbers, reservedNames := numbers.reserved(class)
+	if len(reservedNumbers) > 0 {
+		numbersText := make([]string, 0)
+		namesText := make([]string, 0)
+
+		for i := range reservedNumbers {
+			numbersText = append(numbersText, fmt.Sprint(reservedNumbers[i]))
+			namesText = append(namesText, fmt.Sprintf("\"%s\"", toSnakeCase(reservedNames[i])))
+		}
+
+		if len(class.dataMembers) > 0 {
+			serializedCode += "\n"
+		}
+
+		serializedCode += fmt.Sprintf("  reserved %s;\n  reserved %s;\n",
+			strings.Join(numbersText, ", "), strings.Join(namesText, ", "))
+	}
+
+	return serializedCode + "}\n", imports, nil
+}
+
+/**
+Serialize an enum. Proto enum values are scoped by the package, so they are prefixed with the enum name.
+proto3 enums must start with a zero value, so enums without one get an UNSPECIFIED value.
+Values which share a number are aliases, which proto allows only with the allow_alias option.
+*/
+func (p *protoLanguageSerializer) serializeEnum(enum *enum) string {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeProto, "name"); ok {
+		enumName = name
+	}
+
+	prefix := strings.ToUpper(toSnakeCase(enumName)) + "_"
+	zeroValues := ""
+	values := ""
+	counts := make(map[int]int)
+	options := ""
+
+	for _, value := range enum.enumValues {
+		valueName := prefix + strings.ToUpper(toSnakeCase(value.name))
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeProto, "name"); ok {
+			valueName = name
+		}
+
+		counts[value.value]++
+		if counts[value.value] == 2 {
+			options = "  option allow_alias = true;\n"
+		}
+
+		if value.value == 0 {
+			zeroValues += fmt.Sprintf("  %s = 0;\n", valueName)
+			continue
+		}
+
+		values += fmt.Sprintf("  %s = %v;\n", valueName, value.value)
+	}
+
+	if zeroValues == "" {
+		zeroValues = fmt.Sprintf("  %sUNSPECIFIED = 0;\n", prefix)
+	}
+
+	return fmt.Sprintf("enum %s {\n%s%s%s}\n", enumName, options, zeroValues, values)
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_protoLanguageSerializer_getType(t *testing.T) {
+	if got := newProtoLanguageSerializer().getType(); got != LanguageTypeProto {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeProto)
+	}
+}
+
+func Test_protoLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newProtoLanguageSerializer().getTypeName(); got != "proto" {
+		t.Errorf("getTypeName() = %v, want %v", got, "proto")
+	}
+}
+
+func Test_protoLanguageSerializer_generateCode(t *testing.T) {
+	p := newProtoLanguageSerializer()
+	lock := filepath.Join(t.TempDir(), "fields.lock")
+
+	money := newExternType("money")
+	_ = money.addValue("proto", "acme/money.proto#acme.Money", nil)
+
+	testClass := &class{
+		name: "order",
+		dataMembers: []*dataMember{
+			{memberType: "int", name: "id"},
+			{memberType: "date", name: "createdAt"},
+			{memberType: "money", name: "price"},
+		},
+	}
+
+	testEnum := &enum{
+		name:       "orderStatus",
+		enumValues: []*enumValue{{name: "active", value: 1}},
+	}
+
+	testService, _ := getTestService()
+	info := &serializerInfo{packageName: "shop", options: map[string]string{"lock": lock}}
+	generatedCode, err := p.generateCode([]middleware{money, testClass, testEnum, testService}, info)
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	if len(generatedCode) != 1 || generatedCode[0].fileName != "models.proto" {
+		t.Errorf("generateCode() should generate only models.proto")
+		return
+	}
+
+	want := "syntax = \"proto3\";\n\n" +
+		"package shop;\n\n" +
+		"import \"acme/money.proto\";\n" +
+		"import \"google/protobuf/timestamp.proto\";\n\n" +
+		"message Order {\n" +
+		"  int32 id = 1;\n" +
+		"  google.protobuf.Timestamp created_at = 2;\n" +
+		"  acme.Money price = 3;\n" +
+		"}\n\n" +
+		"enum OrderStatus {\n" +
+		"  ORDER_STATUS_UNSPECIFIED = 0;\n" +
+		"  ORDER_STATUS_ACTIVE = 1;\n" +
+		"}\n"
+
+	if !strings.HasSuffix(generatedCode[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", generatedCode[0].code, want)
+	}
+
+	// The next generation reads the lock, so reordered and removed data members keep their numbers
+	testClass.dataMembers = []*dataMember{
+		{memberType: "money", name: "price"},
+		{memberType: "int", name: "id"},
+		{memberType: "string", name: "note"},
+	}
+
+	generatedCode, err = p.generateCode([]middleware{money, testClass}, info)
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	want = "message Order {\n" +
+		"  acme.Money price = 3;\n" +
+		"  int32 id = 1;\n" +
+		"  string note = 4;\n\n" +
+		"  reserved 2;\n" +
+		"  reserved \"created_at\";\n" +
+		"}\n"
+
+	if !strings.HasSuffix(generatedCode[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", generatedCode[0].code, want)
+	}
+}
+
+func Test_protoLanguageSerializer_serializeClass(t *testing.T) {
+	tests := []struct {
+		name           string
+		class          *class
+		serializerInfo *serializerInfo
+		want           string
+		wantImports    []string
+		wantErr        bool
+	}{
+		{
+			name: "Class serialize",
+			class: &class{
+				name: "orderItem",
+				dataMembers: []*dataMember{
+					{memberType: "int", name: "orderId"},
+					{memberType: "list<product>", name: "products"},
+					{memberType: "map<string,double>", name: "prices"},
+					{memberType: "byte", name: "count"},
+				},
+			},
+			want: "message OrderItem {\n" +
+				"  int32 order_id = 1;\n" +
+				"  repeated Product products = 2;\n" +
+				"  map<string, double> prices = 3;\n" +
+				"  uint32 count = 4;\n" +
+				"}\n",
+			wantImports: []string{},
+		},
+		{
+			name: "Class with JSON names",
+			class: &class{
+				name: "user",
+				dataMembers: []*dataMember{
+					{memberType: "string", name: "userID"},
+					{memberType: "list<date>", name: "logins"},
+				},
+			},
+			want: "message User {\n" +
+				"  string user_id = 1 [json_name = \"userID\"];\n" +
+				"  repeated google.protobuf.Timestamp logins = 2;\n" +
+				"}\n",
+			wantImports: []string{"google/protobuf/timestamp.proto"},
+		},
+		{
+			name: "Class with explicit field numbers",
+			class: &class{
+				name: "user",
+				dataMembers: []*dataMember{
+					{memberType: "string", name: "name"},
+					{memberType: "int", name: "id", annotations: fieldAnnotation("1")},
+				},
+			},
+			want: "message User {\n" +
+				"  string name = 2;\n" +
+				"  int32 id = 1;\n" +
+				"}\n",
+			wantImports: []string{},
+		},
+		{
+			name: "Class with language overrides",
+			class: &class{
+				name:        "event",
+				annotations: []*annotation{{namespace: "proto", name: "name", arguments: []string{"UserEvent"}}},
+				dataMembers: []*dataMember{
+					{
+						memberType: "string",
+						name:       "payload",
+						annotations: []*annotation{
+							{namespace: "proto", name: "type", arguments: []string{"google.protobuf.Any"}},
+							{namespace: "proto", name: "import", arguments: []string{"google/protobuf/any.proto"}},
+							{namespace: "proto", name: "name", arguments: []string{"body"}},
+						},
+					},
+				},
+			},
+			want: "message UserEvent {\n" +
+				"  google.protobuf.Any body = 1 [json_name = \"payload\"];\n" +
+				"}\n",
+			wantImports: []string{"google/protobuf/any.proto"},
+		},
+		{
+			name: "Class with renamed types",
+			class: &class{
+				name: "holder",
+				dataMembers: []*dataMember{
+					{memberType: "event", name: "e"},
+					{memberType: "kind", name: "kind"},
+				},
+			},
+			serializerInfo: getTestRenamedTypesInfo(),
+			want: "message Holder {\n" +
+				"  Evt e = 1;\n" +
+				"  Category kind = 2;\n" +
+				"}\n",
+			wantImports: []string{},
+		},
+		{
+			name: "Map with an invalid key",
+			class: &class{
+				name:        "prices",
+				dataMembers: []*dataMember{{memberType: "map<double,string>", name: "names"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProtoLanguageSerializer()
+			numbers := &fieldNumbers{classes: make(map[string]map[string]int)}
+
+			info := tt.serializerInfo
+			if info == nil {
+				info = &serializerInfo{}
+			}
+
+			got, imports, err := p.serializeClass(tt.class, info, numbers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+			if strings.Join(imports, ",") != strings.Join(tt.wantImports, ",") {
+				t.Errorf("serializeClass() imports = %v, want %v", imports, tt.wantImports)
+			}
+		})
+	}
+}
+
+func Test_protoLanguageSerializer_serializeEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		enum *enum
+		want string
+	}{
+		{
+			name: "Enum without zero value",
+			enum: &enum{
+				name: "orderStatus",
+				enumValues: []*enumValue{
+					{name: "active", value: 1},
+					{name: "cancelledByUser", value: 2},
+				},
+			},
+			want: "enum OrderStatus {\n" +
+				"  ORDER_STATUS_UNSPECIFIED = 0;\n" +
+				"  ORDER_STATUS_ACTIVE = 1;\n" +
+				"  ORDER_STATUS_CANCELLED_BY_USER = 2;\n" +
+				"}\n",
+		},
+		{
+			name: "Enum with zero value",
+			enum: &enum{
+				name: "color",
+				enumValues: []*enumValue{
+					{name: "red", value: 1},
+					{name: "none", value: 0},
+				},
+			},
+			want: "enum Color {\n" +
+				"  COLOR_NONE = 0;\n" +
+				"  COLOR_RED = 1;\n" +
+				"}\n",
+		},
+		{
+			name: "Enum with aliases",
+			enum: &enum{
+				name: "color",
+				enumValues: []*enumValue{
+					{name: "none", value: 0},
+					{name: "red", value: 1},
+					{name: "unknown", value: 0},
+					{name: "crimson", value: 1},
+				},
+			},
+			want: "enum Color {\n" +
+				"  option allow_alias = true;\n" +
+				"  COLOR_NONE = 0;\n" +
+				"  COLOR_UNKNOWN = 0;\n" +
+				"  COLOR_RED = 1;\n" +
+				"  COLOR_CRIMSON = 1;\n" +
+				"}\n",
+		},
+		{
+			name: "Enum with language overrides",
+			enum: &enum{
+				name:        "color",
+				annotations: []*annotation{{namespace: "proto", name: "name", arguments: []string{"Colour"}}},
+				enumValues: []*enumValue{
+					{name: "red", value: 1, annotations: []*annotation{{namespace: "proto", name: "name", arguments: []string{"COLOUR_RED"}}}},
+				},
+			},
+			want: "enum Colour {\n" +
+				"  COLOUR_UNSPECIFIED = 0;\n" +
+				"  COLOUR_RED = 1;\n" +
+				"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newProtoLanguageSerializer().serializeEnum(tt.enum); got != tt.want {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var pythonKeywords = []string{
+	"False", "None", "True", "and", "as", "assert", "async", "await", "break", "class", "continue",
+	"def", "del", "elif", "else", "except", "finally", "for", "from", "global", "if", "import", "in",
+	"is", "lambda", "nonlocal", "not", "or", "pass", "raise", "return", "try", "while", "with", "yield",
+}
+
+var pythonStandardModules = []string{"dataclasses", "datetime", "decimal", "enum", "typing", "uuid"}
+
+/**
+Generate Pydantic v2 models, or standard dataclasses with the "dataclass" option.
+Every class and enum is generated into its own module, and the package gets an __init__.py
+which exports all of them. Classes import each other only for type checking, so classes
+which use each other don't import each other in a cycle.
+*/
+type pythonLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newPythonLanguageSerializer() *pythonLanguageSerializer {
+	result := &pythonLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "int"
+	result.typesMap["string"] = "str"
+	result.typesMap["double"] = "float"
+	result.typesMap["float"] = "float"
+	result.typesMap["char"] = "str"
+	result.typesMap["byte"] = "int"
+	result.typesMap["date"] = "datetime"
+
+	return result
+}
+
+func (p *pythonLanguageSerializer) getType() languageType {
+	return LanguageTypePython
+}
+
+func (p *pythonLanguageSerializer) getTypeName() string {
+	return "python"
+}
+
+func (p *pythonLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+	exports := make([]string, 0)
+	models := make([]string, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Python
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := p.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+		exports = append(exports, p.localImport(middlewareName(object), serializerInfo))
+
+		if _, isDataclass := findOption(serializerInfo, "dataclass"); !isDataclass && object.getType() == middlewareTypeClass {
+			models = append(models, p.className(middlewareName(object), serializerInfo))
+		}
+	}
+
+	return append(result, p.serializePackage(exports, models)), nil
+}
+
+func (p *pythonLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return p.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return p.serializeEnum(enum)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (p *pythonLanguageSerializer) serializeDeclaration() string {
+	return "# **********************************\n" +
+		"#\tGenerated by ModelsGenerator\n#\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n# **********************************\n\n"
+}
+
+/**
+Serialize the imports. Every import is a full name like "typing.List" or ".user.User",
+and they are grouped to future, standard library, third party and local imports.
+*/
+func (p *pythonLanguageSerializer) serializeImports(imports []string) string {
+	groups := make([]map[string][]string, 4)
+	for i := range groups {
+		groups[i] = make(map[string][]string)
+	}
+
+	for _, imp := range imports {
+		module, symbol := "", imp
+		if dot := strings.LastIndex(imp, "."); dot > 0 {
+			module, symbol = imp[:dot], imp[dot+1:]
+		}
+
+		group := 2
+		switch {
+		case module == "__future__":
+			group = 0
+		case strings.HasPrefix(imp, "."):
+			group = 3
+		case p.isStandardModule(module):
+			group = 1
+		}
+
+		groups[group][module] = appendUnique(groups[group][module], symbol)
+	}
+
+	result := ""
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		modules := make([]string, 0, len(group))
+		for module := range group {
+			modules = append(modules, module)
+		}
+		sort.Strings(modules)
+
+		for _, module := range modules {
+			symbols := group[module]
+			sort.Strings(symbols)
+
+			// A plain module import, like "import decimal"
+			if module == "" {
+				for _, symbol := range symbols {
+					result += fmt.Sprintf("import %s\n", symbol)
+				}
+
+				continue
+			}
+
+			result += fmt.Sprintf("from %s import %s\n", module, strings.Join(symbols, ", "))
+		}
+
+		result += "\n"
+	}
+
+	if result == "" {
+		return ""
+	}
+
+	return result + "\n"
+}
+
+/**
+Serialize the imports which are needed only for type checking, under "if TYPE_CHECKING:".
+*/
+func (p *pythonLanguageSerializer) serializeTypeCheckingImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	result := "if TYPE_CHECKING:\n"
+	for _, line := range strings.Split(strings.TrimSpace(p.serializeImports(imports)), "\n") {
+		result += fmt.Sprintf("    %s\n", line)
+	}
+
+	return result + "\n\n"
+}
+
+func (p *pythonLanguageSerializer) isStandardModule(module string) bool {
+	root := strings.Split(module, ".")[0]
+	for _, standard := range pythonStandardModules {
+		if root == standard {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Python type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (p *pythonLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := p.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			return primitiveType, "datetime.datetime", true
+		}
+
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypePython)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "acme.money.Money" is imported from "acme.money" and used as "Money"
+	if dot := strings.LastIndex(externName, "."); dot != -1 {
+		return externName[dot+1:], externName, true
+	}
+
+	return externName, "", true
+}
+
+/**
+Map a gen file type to a Python type, including lists, maps and other classes and enums.
+Return the imports the type needs.
+*/
+func (p *pythonLanguageSerializer) memberType(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, imports := p.memberType(listType, serializerInfo)
+
+		return fmt.Sprintf("List[%s]", itemType), appendUnique(imports, "typing.List")
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, imports := p.memberType(mapKeyType, serializerInfo)
+		valueType, valueImports := p.memberType(mapValueType, serializerInfo)
+
+		for _, imp := range valueImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		return fmt.Sprintf("Dict[%s, %s]", keyType, valueType), appendUnique(imports, "typing.Dict")
+	}
+
+	if knownType, imp, isKnown := p.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, imp)
+	}
+
+	return p.className(typeName, serializerInfo), []string{p.localImport(typeName, serializerInfo)}
+}
+
+/**
+Get the Python class name of a class or an enum, taking the name annotation into account.
+*/
+func (p *pythonLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypePython, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the import of a generated class or enum from its module, like ".order_item.OrderItem".
+*/
+func (p *pythonLanguageSerializer) localImport(typeName string, serializerInfo *serializerInfo) string {
+	return fmt.Sprintf(".%s.%s", toSnakeCase(typeName), p.className(typeName, serializerInfo))
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Python fields are snake case, and keywords get a _ suffix.
+*/
+func (p *pythonLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypePython, "name"); ok {
+		return name
+	}
+
+	name := toSnakeCase(member.name)
+	for _, keyword := range pythonKeywords {
+		if name == keyword {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+func (p *pythonLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.py", toSnakeCase(class.name))
+	_, isDataclass := findOption(serializerInfo, "dataclass")
+
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypePython, "name"); ok {
+		className = name
+	}
+
+	selfImport := fmt.Sprintf(".%s.%s", toSnakeCase(class.name), className)
+
+	// Postponed annotations allow classes to reference themselves, and other classes
+	// which are imported only for type checking
+	imports := []string{"__future__.annotations"}
+	typeCheckingImports := make([]string, 0)
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypePython) {
+		imports = appendUnique(imports, imp)
+	}
+
+	serializedCode := ""
+	if isDataclass {
+		imports = appendUnique(imports, "dataclasses.dataclass")
+		serializedCode += fmt.Sprintf("@dataclass\nclass %s:\n", className)
+	} else {
+		imports = appendUnique(imports, "pydantic.BaseModel")
+		imports = appendUnique(imports, "pydantic.ConfigDict")
+		serializedCode += fmt.Sprintf("class %s(BaseModel):\n", className)
+		serializedCode += "    model_config = ConfigDict(populate_by_name=True)\n"
+
+		if len(class.dataMembers) > 0 {
+			serializedCode += "\n"
+		}
+	}
+
+	for _, member := range class.dataMembers {
+		fieldName := p.fieldName(member)
+		jsonName := toCamelCase(member.name)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypePython) {
+			imports = appendUnique(imports, imp)
+		}
+
+		memberType, memberImports := p.memberType(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypePython, "type"); ok {
+			memberType, memberImports = overrideType, []string{}
+		}
+
+		for _, imp := range memberImports {
+			if imp == selfImport {
+				continue
+			}
+
+			if p.isClassImport(imp, serializerInfo) {
+				typeCheckingImports = appendUnique(typeCheckingImports, imp)
+				continue
+			}
+
+			imports = appendUnique(imports, imp)
+		}
+
+		// Fields which are named differently than their JSON name get an alias
+		if fieldName == jsonName {
+			serializedCode += fmt.Sprintf("    %s: %s\n", fieldName, memberType)
+			continue
+		}
+
+		// Dataclasses don't read the alias, it only records the JSON name for hand-written converters
+		if isDataclass {
+			imports = appendUnique(imports, "dataclasses.field")
+			serializedCode += fmt.Sprintf("    %s: %s = field(metadata={\"alias\": \"%s\"})\n",
+				fieldName, memberType, jsonName)
+
+			continue
+		}
+
+		imports = appendUnique(imports, "pydantic.Field")
+		serializedCode += fmt.Sprintf("    %s: %s = Field(alias=\"%s\")\n", fieldName, memberType, jsonName)
+	}
+
+	if isDataclass && len(class.dataMembers) == 0 {
+		serializedCode += "    pass\n"
+	}
+
+	if len(typeCheckingImports) == 0 {
+		return newGeneratedCode(fileName,
+			p.serializeDeclaration()+p.serializeImports(imports)+serializedCode), nil
+	}
+
+	imports = appendUnique(imports, "typing.TYPE_CHECKING")
+
+	return newGeneratedCode(fileName, p.serializeDeclaration()+strings.TrimSuffix(p.serializeImports(imports), "\n")+
+		p.serializeTypeCheckingImports(typeCheckingImports)+serializedCode), nil
+}
+
+/**
+Check if an import is of a generated class, and not of an enum or an extern type.
+*/
+func (p *pythonLanguageSerializer) isClassImport(imp string, serializerInfo *serializerInfo) bool {
+	for name := range serializerInfo.classes {
+		if imp == p.localImport(name, serializerInfo) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *pythonLanguageSerializer) serializeEnum(enum *enum) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.py", toSnakeCase(enum.name))
+
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypePython, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := fmt.Sprintf("class %s(IntEnum):\n", enumName)
+
+	for _, value := range enum.enumValues {
+		valueName := strings.ToUpper(toSnakeCase(value.name))
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypePython, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("    %s = %v\n", valueName, value.value)
+	}
+
+	if len(enum.enumValues) == 0 {
+		serializedCode += "    pass\n"
+	}
+
+	return newGeneratedCode(fileName,
+		p.serializeDeclaration()+p.serializeImports([]string{"enum.IntEnum"})+serializedCode), nil
+}
+
+/**
+Serialize the __init__.py of the package, which exports all the classes and enums.
+The Pydantic models are rebuilt once all of them are imported, to resolve the classes they import only for type checking.
+*/
+func (p *pythonLanguageSerializer) serializePackage(exports []string, models []string) *generatedCode {
+	names := ""
+	for _, export := range exports {
+		names += fmt.Sprintf("    \"%s\",\n", export[strings.LastIndex(export, ".")+1:])
+	}
+
+	rebuilds := ""
+	for _, model := range models {
+		rebuilds += fmt.Sprintf("%s.model_rebuild()\n", model)
+	}
+
+	if rebuilds != "" {
+		rebuilds += "\n"
+	}
+
+	return newGeneratedCode("__init__.py",
+		p.serializeDeclaration()+p.serializeImports(exports)+rebuilds+fmt.Sprintf("__all__ = [\n%s]\n", names))
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_pythonLanguageSerializer_generateCode(t *testing.T) {
+	p := newPythonLanguageSerializer()
+
+	testClass := &class{
+		name: "test",
+		dataMembers: []*dataMember{
+			{
+				memberType: "int",
+				name:       "a",
+			},
+		},
+	}
+
+	testEnum := &enum{
+		name: "testStatus",
+		enumValues: []*enumValue{
+			{
+				name:  "a",
+				value: 5,
+			},
+		},
+	}
+
+	userService, _ := getTestService()
+
+	meddlers := []middleware{testClass, testEnum, userService}
+
+	generatedCode, err := p.generateCode(meddlers, &serializerInfo{packageName: "bla"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	// The class, the enum and __init__.py. Services aren't generated for Python
+	if len(generatedCode) != 3 {
+		t.Errorf("generateCode() generated %v files. expected 3", len(generatedCode))
+		return
+	}
+
+	init := generatedCode[2]
+	if init.fileName != "__init__.py" {
+		t.Errorf("generateCode() last file = %v, want __init__.py", init.fileName)
+	}
+
+	expectedInit := "from .test import Test\nfrom .test_status import TestStatus\n\n\n" +
+		"Test.model_rebuild()\n\n" +
+		"__all__ = [\n    \"Test\",\n    \"TestStatus\",\n]\n"
+	if !strings.HasSuffix(init.code, expectedInit) {
+		t.Errorf("generateCode() __init__.py = %v, want %v", init.code, expectedInit)
+	}
+}
+
+func Test_pythonLanguageSerializer_generateCode_mutualReferences(t *testing.T) {
+	p := newPythonLanguageSerializer()
+
+	a := &class{name: "a", dataMembers: []*dataMember{{memberType: "b", name: "b"}}}
+	b := &class{name: "b", dataMembers: []*dataMember{{memberType: "list<a>", name: "items"}}}
+
+	generatedCode, err := p.generateCode([]middleware{a, b}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	// The classes import each other only for type checking, so the modules don't import each other in a cycle
+	expectedA := "from __future__ import annotations\n\n" +
+		"from typing import TYPE_CHECKING\n\n" +
+		"from pydantic import BaseModel, ConfigDict\n\n" +
+		"if TYPE_CHECKING:\n" +
+		"    from .b import B\n\n\n" +
+		"class A(BaseModel):\n"
+	if !strings.Contains(generatedCode[0].code, expectedA) {
+		t.Errorf("generateCode() a.py = %v, want %v", generatedCode[0].code, expectedA)
+	}
+
+	expectedB := "from typing import List, TYPE_CHECKING\n\n" +
+		"from pydantic import BaseModel, ConfigDict\n\n" +
+		"if TYPE_CHECKING:\n" +
+		"    from .a import A\n\n\n"
+	if !strings.Contains(generatedCode[1].code, expectedB) {
+		t.Errorf("generateCode() b.py = %v, want %v", generatedCode[1].code, expectedB)
+	}
+
+	// The models are rebuilt once both of them are imported
+	expectedInit := "from .a import A\nfrom .b import B\n\n\n" +
+		"A.model_rebuild()\nB.model_rebuild()\n\n"
+	if !strings.Contains(generatedCode[2].code, expectedInit) {
+		t.Errorf("generateCode() __init__.py = %v, want %v", generatedCode[2].code, expectedInit)
+	}
+
+	// Dataclasses don't resolve their annotations, so they don't need a rebuild
+	generatedCode, err = p.generateCode([]middleware{a, b}, &serializerInfo{options: map[string]string{"dataclass": ""}})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	if strings.Contains(generatedCode[2].code, "model_rebuild") {
+		t.Errorf("generateCode() __init__.py = %v, want no rebuilds for dataclasses", generatedCode[2].code)
+	}
+}
+
+func Test_pythonLanguageSerializer_generateCode_renamedEnum(t *testing.T) {
+	p := newPythonLanguageSerializer()
+
+	status := &enum{
+		name:        "orderStatus",
+		annotations: []*annotation{{namespace: "python", name: "name", arguments: []string{"Status"}}},
+		enumValues:  []*enumValue{{name: "active", value: 1}},
+	}
+	order := &class{name: "order", dataMembers: []*dataMember{{memberType: "orderStatus", name: "status"}}}
+
+	generatedCode, err := p.generateCode([]middleware{order, status}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	// The class and the package import the enum by its Python name
+	for _, expected := range []string{"from .order_status import Status\n", "    status: Status\n"} {
+		if !strings.Contains(generatedCode[0].code, expected) {
+			t.Errorf("generateCode() order.py = %v, want %v", generatedCode[0].code, expected)
+		}
+	}
+
+	if !strings.Contains(generatedCode[1].code, "class Status(IntEnum):") {
+		t.Errorf("generateCode() order_status.py = %v, want class Status", generatedCode[1].code)
+	}
+
+	expectedInit := "from .order import Order\nfrom .order_status import Status\n"
+	if !strings.Contains(generatedCode[2].code, expectedInit) {
+		t.Errorf("generateCode() __init__.py = %v, want %v", generatedCode[2].code, expectedInit)
+	}
+}
+
+func Test_pythonLanguageSerializer_getType(t *testing.T) {
+	if got := newPythonLanguageSerializer().getType(); got != LanguageTypePython {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypePython)
+	}
+}
+
+func Test_pythonLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newPythonLanguageSerializer().getTypeName(); got != "python" {
+		t.Errorf("getTypeName() = %v, want %v", got, "python")
+	}
+}
+
+func Test_pythonLanguageSerializer_serializeClass(t *testing.T) {
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Primitive class serialize",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "a",
+						},
+						{
+							memberType: "date",
+							name:       "createdAt",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "test.py",
+				code: "from __future__ import annotations\n\n" +
+					"from datetime import datetime\n\n" +
+					"from pydantic import BaseModel, ConfigDict, Field\n\n\n" +
+					"class Test(BaseModel):\n" +
+					"    model_config = ConfigDict(populate_by_name=True)\n\n" +
+					"    a: str\n" +
+					"    created_at: datetime = Field(alias=\"createdAt\")\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with list, map and other classes",
+			args: args{
+				class: &class{
+					name: "orderItem",
+					dataMembers: []*dataMember{
+						{
+							memberType: "list<orderItem>",
+							name:       "children",
+						},
+						{
+							memberType: "map<string,orderStatus>",
+							name:       "statuses",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "order_item.py",
+				code: "from __future__ import annotations\n\n" +
+					"from typing import Dict, List\n\n" +
+					"from pydantic import BaseModel, ConfigDict\n\n" +
+					"from .order_status import OrderStatus\n\n\n" +
+					"class OrderItem(BaseModel):\n" +
+					"    model_config = ConfigDict(populate_by_name=True)\n\n" +
+					"    children: List[OrderItem]\n" +
+					"    statuses: Dict[str, OrderStatus]\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Dataclass",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "int",
+							name:       "from",
+						},
+						{
+							memberType: "bool",
+							name:       "ok",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{options: map[string]string{"dataclass": ""}},
+			},
+			want: &generatedCode{
+				fileName: "test.py",
+				code: "from __future__ import annotations\n\n" +
+					"from dataclasses import dataclass, field\n\n\n" +
+					"@dataclass\n" +
+					"class Test:\n" +
+					"    from_: int = field(metadata={\"alias\": \"from\"})\n" +
+					"    ok: bool\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty dataclass",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{options: map[string]string{"dataclass": ""}},
+			},
+			want: &generatedCode{
+				fileName: "test.py",
+				code: "from __future__ import annotations\n\n" +
+					"from dataclasses import dataclass\n\n\n" +
+					"@dataclass\n" +
+					"class Test:\n" +
+					"    pass\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "python", name: "type", arguments: []string{"UUID"}},
+								{namespace: "python", name: "import", arguments: []string{"uuid.UUID"}},
+							},
+						},
+						{
+							memberType: "int",
+							name:       "count",
+							annotations: []*annotation{
+								{namespace: "python", name: "name", arguments: []string{"total"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "python", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "test.py",
+				code: "from __future__ import annotations\n\n" +
+					"from uuid import UUID\n\n" +
+					"from pydantic import BaseModel, ConfigDict, Field\n\n\n" +
+					"class Renamed(BaseModel):\n" +
+					"    model_config = ConfigDict(populate_by_name=True)\n\n" +
+					"    id: UUID\n" +
+					"    total: int = Field(alias=\"count\")\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypePython: "acme.money.Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "test.py",
+				code: "from __future__ import annotations\n\n" +
+					"from acme.money import Money\n" +
+					"from pydantic import BaseModel, ConfigDict\n\n\n" +
+					"class Test(BaseModel):\n" +
+					"    model_config = ConfigDict(populate_by_name=True)\n\n" +
+					"    price: Money\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPythonLanguageSerializer()
+			got, err := p.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, p.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pythonLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{
+							name:  "active",
+							value: 5,
+						},
+						{
+							name:  "onHold",
+							value: 8,
+						},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order_status.py",
+				code: "from enum import IntEnum\n\n\n" +
+					"class OrderStatus(IntEnum):\n" +
+					"    ACTIVE = 5\n" +
+					"    ON_HOLD = 8\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty enum",
+			args: args{enum: &enum{name: "test", enumValues: []*enumValue{}}},
+			want: &generatedCode{
+				fileName: "test.py",
+				code:     "from enum import IntEnum\n\n\nclass Test(IntEnum):\n    pass\n",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPythonLanguageSerializer()
+			got, err := p.serializeEnum(tt.args.enum)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, p.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/**
+Ruby keywords and methods every object has, which props can't override.
+*/
+var rubyReservedNames = []string{
+	"alias", "and", "begin", "break", "case", "class", "def", "defined?", "do", "else", "elsif", "end",
+	"ensure", "false", "for", "if", "in", "module", "next", "nil", "not", "or", "redo", "rescue", "retry",
+	"return", "self", "super", "then", "true", "undef", "unless", "until", "when", "while", "yield",
+	"display", "freeze", "hash", "method", "object_id", "send", "serialize",
+}
+
+/**
+The custom type of dates, which Sorbet writes and reads as ISO 8601 strings.
+*/
+const rubyTimeTemplate = `module ISO8601Time
+  extend T::Sig
+  extend T::Props::CustomType
+
+  sig { override.params(value: T.untyped).returns(T::Boolean) }
+  def self.instance?(value)
+    value.is_a?(Time)
+  end
+
+  sig { override.params(instance: Time).returns(String) }
+  def self.serialize(instance)
+    instance.iso8601(3)
+  end
+
+  sig { override.params(scalar: String).returns(Time) }
+  def self.deserialize(scalar)
+    Time.iso8601(scalar)
+  end
+end
+`
+
+/**
+Generate Sorbet T::Struct classes and T::Enum enums. Every class and enum is generated into its own file,
+like "order_item.rb", and models.rb requires all of them.
+*/
+type rubyLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newRubyLanguageSerializer() *rubyLanguageSerializer {
+	result := &rubyLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "T::Boolean"
+	result.typesMap["int"] = "Integer"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "Float"
+	result.typesMap["float"] = "Float"
+	result.typesMap["char"] = "String"
+	result.typesMap["byte"] = "Integer"
+	result.typesMap["date"] = "ISO8601Time"
+
+	return result
+}
+
+func (r *rubyLanguageSerializer) getType() languageType {
+	return LanguageTypeRuby
+}
+
+func (r *rubyLanguageSerializer) getTypeName() string {
+	return "ruby"
+}
+
+func (r *rubyLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	names := make([]string, 0)
+	generated := make(map[string]bool)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Ruby
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		names = append(names, middlewareName(object))
+		generated[middlewareName(object)] = true
+	}
+
+	usesDates := false
+	dependencies := make(map[string][]string)
+
+	for _, name := range names {
+		class, ok := serializerInfo.classes[name]
+		if !ok {
+			continue
+		}
+
+		dependencies[name] = make([]string, 0)
+		for _, member := range class.dataMembers {
+			for _, typeName := range elementTypes(member.memberType) {
+				if generated[typeName] {
+					dependencies[name] = appendUnique(dependencies[name], typeName)
+				}
+
+				usesDates = usesDates || typeName == "date"
+			}
+		}
+	}
+
+	// The props reference the constants of their types when the class is loaded
+	sortedNames, err := sortByDependencies(names, dependencies)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("can't generate files that require each other: %v", err))
+	}
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			requires := make([]string, 0)
+			for _, dependency := range dependencies[o.name] {
+				if dependency != o.name {
+					requires = append(requires, toSnakeCase(dependency))
+				}
+			}
+
+			serialized, err := r.serializeClass(o, requires, serializerInfo)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, serialized)
+		case *enum:
+			serialized, err := r.serializeEnum(o, serializerInfo)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, serialized)
+		}
+	}
+
+	if usesDates {
+		result = append(result, newGeneratedCode("iso8601_time.rb",
+			r.serializeDeclaration([]string{"sorbet-runtime", "time"}, nil)+r.serializeModule(rubyTimeTemplate, serializerInfo)))
+	}
+
+	return append(result, r.serializeModels(sortedNames, usesDates)), nil
+}
+
+/**
+Serialize the beginning of a Ruby file, with the Sorbet sigil and the required libraries and files.
+*/
+func (r *rubyLanguageSerializer) serializeDeclaration(requires []string, relativeRequires []string) string {
+	result := "# typed: strict\n" +
+		"# frozen_string_literal: true\n\n" +
+		"# **********************************\n" +
+		"#\tGenerated by ModelsGenerator\n#\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n# **********************************\n\n"
+
+	for _, require := range requires {
+		result += fmt.Sprintf("require '%s'\n", require)
+	}
+
+	if len(requires) > 0 {
+		result += "\n"
+	}
+
+	for _, require := range relativeRequires {
+		result += fmt.Sprintf("require_relative '%s'\n", require)
+	}
+
+	if len(relativeRequires) > 0 {
+		result += "\n"
+	}
+
+	return result
+}
+
+/**
+Wrap the code in the modules of the package, like "Shop::Models" for "shop.models".
+*/
+func (r *rubyLanguageSerializer) serializeModule(code string, serializerInfo *serializerInfo) string {
+	if serializerInfo.packageName == "" {
+		return code
+	}
+
+	modules := strings.Split(serializerInfo.packageName, ".")
+	result := ""
+
+	for i, module := range modules {
+		result += fmt.Sprintf("%smodule %s\n", strings.Repeat("  ", i), r.moduleName(module))
+	}
+
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(code, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			result += line
+		} else {
+			result += strings.Repeat("  ", len(modules)) + line
+		}
+	}
+
+	result += "\n"
+
+	for i := len(modules) - 1; i >= 0; i-- {
+		result += fmt.Sprintf("%send\n", strings.Repeat("  ", i))
+	}
+
+	return result
+}
+
+/**
+Convert a package part to a module name, like "Models" for "models" and "OrderService" for "order_service".
+*/
+func (r *rubyLanguageSerializer) moduleName(value string) string {
+	return toFirstCharUpper(snakeToCamelCase(value))
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Ruby type.
+Return the library the type needs, or an empty string if it doesn't need one.
+*/
+func (r *rubyLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := r.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeRuby)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "money#Money::Amount" requires "money" and is used as "Money::Amount"
+	library, externType, found := strings.Cut(externName, "#")
+	if !found {
+		return externName, "", true
+	}
+
+	return externType, library, true
+}
+
+/**
+Serialize the Sorbet type of a gen file type, with T::Array for lists and T::Hash for maps.
+JSON object keys are strings, so the keys of maps are strings too.
+Return the libraries the type needs.
+*/
+func (r *rubyLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, requires := r.typeName(listType, serializerInfo)
+
+		return fmt.Sprintf("T::Array[%s]", itemType), requires
+	}
+
+	if isMap, _, mapValueType := isMap(typeName); isMap {
+		valueType, requires := r.typeName(mapValueType, serializerInfo)
+
+		return fmt.Sprintf("T::Hash[String, %s]", valueType), requires
+	}
+
+	if knownType, require, isKnown := r.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, require)
+	}
+
+	// The files of the generated types are required by their dependencies order
+	return r.className(typeName, serializerInfo), []string{}
+}
+
+/**
+Get the Ruby class name of a class or an enum, taking the name annotation into account.
+*/
+func (r *rubyLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeRuby, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the prop name of a data member, taking the name annotation into account.
+Props are snake case, and reserved names get an underscore suffix, like "class_".
+*/
+func (r *rubyLanguageSerializer) propName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeRuby, "name"); ok {
+		return name
+	}
+
+	name := toSnakeCase(member.name)
+	for _, reserved := range rubyReservedNames {
+		if name == reserved {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+func (r *rubyLanguageSerializer) serializeClass(class *class, relativeRequires []string, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeRuby, "name"); ok {
+		className = name
+	}
+
+	requires := []string{"sorbet-runtime"}
+	for _, require := range findLanguageImports(class.annotations, LanguageTypeRuby) {
+		requires = appendUnique(requires, require)
+	}
+
+	props := ""
+	for _, member := range class.dataMembers {
+		for _, require := range findLanguageImports(member.annotations, LanguageTypeRuby) {
+			requires = appendUnique(requires, require)
+		}
+
+		memberType, memberRequires := r.typeName(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeRuby, "type"); ok {
+			memberType, memberRequires = overrideType, []string{}
+		}
+
+		for _, require := range memberRequires {
+			requires = appendUnique(requires, require)
+		}
+
+		for _, typeName := range elementTypes(member.memberType) {
+			if typeName == "date" {
+				relativeRequires = appendUnique(relativeRequires, "iso8601_time")
+			}
+		}
+
+		// Sorbet writes and reads the props by their names, unless they are given another name
+		propName := r.propName(member)
+		if jsonName := toCamelCase(member.name); propName != jsonName {
+			props += fmt.Sprintf("  prop :%s, %s, name: '%s'\n", propName, memberType, jsonName)
+		} else {
+			props += fmt.Sprintf("  prop :%s, %s\n", propName, memberType)
+		}
+	}
+
+	serializedCode := fmt.Sprintf("class %s < T::Struct\n", className)
+	serializedCode += "  extend T::Sig\n\n"
+
+	if props != "" {
+		serializedCode += props + "\n"
+	}
+
+	serializedCode += fmt.Sprintf("  sig { params(hash: T::Hash[String, T.untyped]).returns(%s) }\n", className)
+	serializedCode += "  def self.from_h(hash)\n"
+	serializedCode += "    from_hash(hash)\n"
+	serializedCode += "  end\n"
+	serializedCode += "end\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.rb", toSnakeCase(class.name)),
+		r.serializeDeclaration(requires, relativeRequires)+r.serializeModule(serializedCode, serializerInfo)), nil
+}
+
+func (r *rubyLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeRuby, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := fmt.Sprintf("class %s < T::Enum\n", enumName)
+	serializedCode += "  enums do\n"
+
+	// T::Enum values are written and read by their serialized values, which must be unique
+	values := make(map[int]string)
+	for _, value := range enum.enumValues {
+		if name, ok := values[value.value]; ok {
+			return nil, errors.New(fmt.Sprintf("%s.%s and %s.%s have the same value, which T::Enum doesn't allow",
+				enum.name, name, enum.name, value.name))
+		}
+
+		values[value.value] = value.name
+
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeRuby, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("    %s = new(%v)\n", valueName, value.value)
+	}
+
+	serializedCode += "  end\n"
+	serializedCode += "end\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.rb", toSnakeCase(enum.name)),
+		r.serializeDeclaration([]string{"sorbet-runtime"}, nil)+r.serializeModule(serializedCode, serializerInfo)), nil
+}
+
+/**
+Serialize models.rb, which requires the files of all the models in the order they depend on each other.
+*/
+func (r *rubyLanguageSerializer) serializeModels(sortedNames []string, usesDates bool) *generatedCode {
+	relativeRequires := make([]string, 0)
+	if usesDates {
+		relativeRequires = append(relativeRequires, "iso8601_time")
+	}
+
+	for _, name := range sortedNames {
+		relativeRequires = append(relativeRequires, toSnakeCase(name))
+	}
+
+	return newGeneratedCode("models.rb", strings.TrimSuffix(r.serializeDeclaration(nil, relativeRequires), "\n"))
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_rubyLanguageSerializer_getType(t *testing.T) {
+	if got := newRubyLanguageSerializer().getType(); got != LanguageTypeRuby {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeRuby)
+	}
+}
+
+func Test_rubyLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newRubyLanguageSerializer().getTypeName(); got != "ruby" {
+		t.Errorf("getTypeName() = %v, want %v", got, "ruby")
+	}
+}
+
+func Test_rubyLanguageSerializer_generateCode(t *testing.T) {
+	order := newClass("order")
+	_ = order.addValue("items", "list<orderItem>", nil)
+	_ = order.addValue("createdAt", "date", nil)
+
+	orderItem := newClass("orderItem")
+	_ = orderItem.addValue("status", "orderStatus", nil)
+
+	status := newEnum("orderStatus")
+	_ = status.addValue("active", "1", nil)
+
+	generatedCode, err := newRubyLanguageSerializer().generateCode(
+		[]middleware{order, orderItem, status}, &serializerInfo{packageName: "shop.models"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	fileNames := make([]string, 0)
+	for _, code := range generatedCode {
+		fileNames = append(fileNames, code.fileName)
+	}
+
+	wantFileNames := []string{"order.rb", "order_item.rb", "order_status.rb", "iso8601_time.rb", "models.rb"}
+	if !reflect.DeepEqual(fileNames, wantFileNames) {
+		t.Errorf("generateCode() file names = %v, want %v", fileNames, wantFileNames)
+		return
+	}
+
+	if !strings.Contains(generatedCode[0].code, "module Shop\n  module Models\n    class Order < T::Struct\n") {
+		t.Errorf("generateCode() order.rb isn't in the package modules.\ncode: %v", generatedCode[0].code)
+	}
+
+	wantModels := "require_relative 'iso8601_time'\n" +
+		"require_relative 'order_status'\n" +
+		"require_relative 'order_item'\n" +
+		"require_relative 'order'\n"
+	if !strings.HasSuffix(generatedCode[4].code, wantModels) {
+		t.Errorf("generateCode() models.rb = %v, want suffix %v", generatedCode[4].code, wantModels)
+	}
+
+	// Files can't require each other
+	_ = orderItem.addValue("order", "order", nil)
+	if _, err := newRubyLanguageSerializer().generateCode([]middleware{order, orderItem, status}, &serializerInfo{}); err == nil {
+		t.Errorf("generateCode() expected an error for files that require each other")
+	}
+}
+
+func Test_rubyLanguageSerializer_serializeClass(t *testing.T) {
+	renamed := getTestRenamedTypesInfo()
+	renamed.packageName = ""
+
+	type args struct {
+		class            *class
+		relativeRequires []string
+		serializerInfo   *serializerInfo
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		wantRequires         []string
+		wantRelativeRequires []string
+		want                 string
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "int", name: "id"},
+						{memberType: "date", name: "createdAt"},
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "map<int,orderStatus>", name: "statuses"},
+					},
+				},
+				relativeRequires: []string{"order_item", "order_status"},
+				serializerInfo:   &serializerInfo{},
+			},
+			wantRequires:         []string{"sorbet-runtime"},
+			wantRelativeRequires: []string{"order_item", "order_status", "iso8601_time"},
+			want: "class Order < T::Struct\n" +
+				"  extend T::Sig\n\n" +
+				"  prop :id, Integer\n" +
+				"  prop :created_at, ISO8601Time, name: 'createdAt'\n" +
+				"  prop :items, T::Array[OrderItem]\n" +
+				"  prop :statuses, T::Hash[String, OrderStatus]\n\n" +
+				"  sig { params(hash: T::Hash[String, T.untyped]).returns(Order) }\n" +
+				"  def self.from_h(hash)\n" +
+				"    from_hash(hash)\n" +
+				"  end\n" +
+				"end\n",
+		},
+		{
+			name: "Empty class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			wantRequires: []string{"sorbet-runtime"},
+			want: "class Test < T::Struct\n" +
+				"  extend T::Sig\n\n" +
+				"  sig { params(hash: T::Hash[String, T.untyped]).returns(Test) }\n" +
+				"  def self.from_h(hash)\n" +
+				"    from_hash(hash)\n" +
+				"  end\n" +
+				"end\n",
+		},
+		{
+			name: "Class with language overrides, reserved names and extern types",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{memberType: "string", name: "class"},
+						{
+							memberType: "Money",
+							name:       "price",
+							annotations: []*annotation{
+								{namespace: "ruby", name: "name", arguments: []string{"cost"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "ruby", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeRuby: "money#Money"},
+					},
+				}},
+			},
+			wantRequires: []string{"sorbet-runtime", "money"},
+			want: "class Renamed < T::Struct\n" +
+				"  extend T::Sig\n\n" +
+				"  prop :class_, String, name: 'class'\n" +
+				"  prop :cost, Money, name: 'price'\n\n" +
+				"  sig { params(hash: T::Hash[String, T.untyped]).returns(Renamed) }\n" +
+				"  def self.from_h(hash)\n" +
+				"    from_hash(hash)\n" +
+				"  end\n" +
+				"end\n",
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				relativeRequires: []string{"event", "kind"},
+				serializerInfo:   renamed,
+			},
+			wantRequires:         []string{"sorbet-runtime"},
+			wantRelativeRequires: []string{"event", "kind"},
+			want: "class Holder < T::Struct\n" +
+				"  extend T::Sig\n\n" +
+				"  prop :e, Evt\n" +
+				"  prop :kind, Category\n\n" +
+				"  sig { params(hash: T::Hash[String, T.untyped]).returns(Holder) }\n" +
+				"  def self.from_h(hash)\n" +
+				"    from_hash(hash)\n" +
+				"  end\n" +
+				"end\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRubyLanguageSerializer()
+			got, err := r.serializeClass(tt.args.class, tt.args.relativeRequires, tt.args.serializerInfo)
+			if err != nil {
+				t.Errorf("serializeClass() error = %v", err)
+				return
+			}
+
+			want := r.serializeDeclaration(tt.wantRequires, tt.wantRelativeRequires) + tt.want
+			if got.code != want {
+				t.Errorf("serializeClass() got = %v, want %v", got.code, want)
+			}
+		})
+	}
+}
+
+func Test_rubyLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{name: "active", value: 5},
+						{name: "onHold", value: 8},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order_status.rb",
+				code: "class OrderStatus < T::Enum\n" +
+					"  enums do\n" +
+					"    Active = new(5)\n" +
+					"    OnHold = new(8)\n" +
+					"  end\n" +
+					"end\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Duplicate values",
+			args: args{
+				enum: &enum{
+					name: "test",
+					enumValues: []*enumValue{
+						{name: "a", value: 1},
+						{name: "b", value: 1},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRubyLanguageSerializer()
+			got, err := r.serializeEnum(tt.args.enum, &serializerInfo{})
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, r.serializeDeclaration([]string{"sorbet-runtime"}, nil), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rubyLanguageSerializer_serializeModule(t *testing.T) {
+	code := "class Test < T::Struct\n  extend T::Sig\n\n  prop :id, Integer\nend\n"
+
+	want := "module Shop\n" +
+		"  module OrderModels\n" +
+		"    class Test < T::Struct\n" +
+		"      extend T::Sig\n\n" +
+		"      prop :id, Integer\n" +
+		"    end\n" +
+		"  end\n" +
+		"end\n"
+
+	r := newRubyLanguageSerializer()
+	if got := r.serializeModule(code, &serializerInfo{packageName: "shop.order_models"}); got != want {
+		t.Errorf("serializeModule() = %v, want %v", got, want)
+	}
+
+	if got := r.serializeModule(code, &serializerInfo{}); got != code {
+		t.Errorf("serializeModule() without a package = %v, want %v", got, code)
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var rustKeywords = []string{
+	"as", "async", "await", "break", "const", "continue", "crate", "dyn", "else", "enum", "extern",
+	"false", "fn", "for", "if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub",
+	"ref", "return", "static", "struct", "trait", "true", "type", "unsafe", "use", "where", "while",
+	"abstract", "become", "box", "do", "final", "macro", "override", "priv", "try", "typeof", "unsized",
+	"virtual", "yield",
+}
+
+/**
+Generate serde structs and enums. Every class and enum is generated into its own module,
+and mod.rs declares and re-exports all of them.
+*/
+type rustLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newRustLanguageSerializer() *rustLanguageSerializer {
+	result := &rustLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "i32"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "f64"
+	result.typesMap["float"] = "f32"
+	result.typesMap["char"] = "char"
+	result.typesMap["byte"] = "u8"
+	result.typesMap["date"] = "DateTime<Utc>"
+
+	return result
+}
+
+func (r *rustLanguageSerializer) getType() languageType {
+	return LanguageTypeRust
+}
+
+func (r *rustLanguageSerializer) getTypeName() string {
+	return "rust"
+}
+
+func (r *rustLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+	modules := make([]string, 0)
+	exports := make([]string, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Rust
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := r.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+
+		name := middlewareName(object)
+		modules = append(modules, toSnakeCase(name))
+		exports = append(exports, r.className(middlewareName(object), serializerInfo))
+	}
+
+	return append(result, r.serializeModule(modules, exports)), nil
+}
+
+func (r *rustLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return r.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return r.serializeEnum(enum)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (r *rustLanguageSerializer) serializeDeclaration() string {
+	return "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+}
+
+/**
+Serialize the use declarations sorted, grouping the items of the same path like rustfmt does.
+Every import is a full path like "std::collections::HashMap".
+*/
+func (r *rustLanguageSerializer) serializeImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	items := make(map[string][]string)
+	paths := make([]string, 0)
+
+	for _, imp := range imports {
+		path, item := "", imp
+		if separator := strings.LastIndex(imp, "::"); separator != -1 {
+			path, item = imp[:separator], imp[separator+2:]
+		}
+
+		if _, ok := items[path]; !ok {
+			paths = append(paths, path)
+		}
+
+		items[path] = appendUnique(items[path], item)
+	}
+
+	sort.Strings(paths)
+
+	result := ""
+	for _, path := range paths {
+		pathItems := items[path]
+		sort.Strings(pathItems)
+
+		switch {
+		case path == "":
+			result += fmt.Sprintf("use %s;\n", strings.Join(pathItems, ";\nuse "))
+		case len(pathItems) == 1:
+			result += fmt.Sprintf("use %s::%s;\n", path, pathItems[0])
+		default:
+			result += fmt.Sprintf("use %s::{%s};\n", path, strings.Join(pathItems, ", "))
+		}
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Rust type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (r *rustLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, []string, bool) {
+	if primitiveType, isPrimitive := r.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			return primitiveType, []string{"chrono::DateTime", "chrono::Utc"}, true
+		}
+
+		return primitiveType, []string{}, true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeRust)
+	if !isExtern {
+		return "", nil, false
+	}
+
+	// "rust_decimal::Decimal" is imported as is and used as "Decimal"
+	separator := strings.LastIndex(externName, "::")
+	if separator == -1 {
+		return externName, []string{}, true
+	}
+
+	return externName[separator+2:], []string{externName}, true
+}
+
+/**
+Serialize the Rust type of a gen file type, with Vec<T> for lists and HashMap<K, V> for maps.
+Return the imports the type needs.
+*/
+func (r *rustLanguageSerializer) memberType(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, imports := r.memberType(listType, serializerInfo)
+
+		return fmt.Sprintf("Vec<%s>", itemType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, imports := r.memberType(mapKeyType, serializerInfo)
+		valueType, valueImports := r.memberType(mapValueType, serializerInfo)
+
+		for _, imp := range valueImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		return fmt.Sprintf("HashMap<%s, %s>", keyType, valueType), appendUnique(imports, "std::collections::HashMap")
+	}
+
+	if knownType, imports, isKnown := r.mapType(typeName, serializerInfo); isKnown {
+		return knownType, imports
+	}
+
+	// Generated models are re-exported by mod.rs
+	className := r.className(typeName, serializerInfo)
+
+	return className, []string{"super::" + className}
+}
+
+/**
+Get the Rust type name of a class or an enum, taking the name annotation into account.
+*/
+func (r *rustLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeRust, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Fields are snake case, and keywords are written as raw identifiers.
+*/
+func (r *rustLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeRust, "name"); ok {
+		return name
+	}
+
+	name := toSnakeCase(member.name)
+	for _, keyword := range rustKeywords {
+		if name == keyword {
+			return "r#" + name
+		}
+	}
+
+	return name
+}
+
+func (r *rustLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeRust, "name"); ok {
+		className = name
+	}
+
+	imports := []string{"serde::Deserialize", "serde::Serialize"}
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypeRust) {
+		imports = appendUnique(imports, imp)
+	}
+
+	serializedCode := "#[derive(Serialize, Deserialize, Debug, Clone)]\n"
+	serializedCode += "#[serde(rename_all = \"camelCase\")]\n"
+	serializedCode += fmt.Sprintf("pub struct %s {\n", className)
+
+	for _, member := range class.dataMembers {
+		fieldName := r.fieldName(member)
+		jsonName := toCamelCase(member.name)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeRust) {
+			imports = appendUnique(imports, imp)
+		}
+
+		memberType, memberImports := r.memberType(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeRust, "type"); ok {
+			memberType, memberImports = overrideType, []string{}
+		} else if isRecursiveMember(class, member, serializerInfo.classes) {
+			// A struct can't contain itself, so the recursive members are allocated on the heap
+			memberType = fmt.Sprintf("Box<%s>", memberType)
+		}
+
+		for _, imp := range memberImports {
+			// A struct doesn't import itself
+			if imp != "super::"+className {
+				imports = appendUnique(imports, imp)
+			}
+		}
+
+		// rename_all doesn't give the JSON name of every field, like "user_id" for "userID"
+		if snakeToCamelCase(strings.TrimPrefix(fieldName, "r#")) != jsonName {
+			serializedCode += fmt.Sprintf("    #[serde(rename = \"%s\")]\n", jsonName)
+		}
+
+		serializedCode += fmt.Sprintf("    pub %s: %s,\n", fieldName, memberType)
+	}
+
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.rs", toSnakeCase(class.name)),
+		r.serializeDeclaration()+r.serializeImports(imports)+serializedCode), nil
+}
+
+func (r *rustLanguageSerializer) serializeEnum(enum *enum) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.rs", toSnakeCase(enum.name))
+
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeRust, "name"); ok {
+		enumName = name
+	}
+
+	// An enum without variants can't have a representation
+	if len(enum.enumValues) == 0 {
+		return newGeneratedCode(fileName, r.serializeDeclaration()+
+			r.serializeImports([]string{"serde::Deserialize", "serde::Serialize"})+
+			fmt.Sprintf("#[derive(Serialize, Deserialize, Debug, Clone, Copy, PartialEq, Eq)]\npub enum %s {}\n", enumName)), nil
+	}
+
+	serializedCode := r.serializeDeclaration() +
+		r.serializeImports([]string{"serde_repr::Deserialize_repr", "serde_repr::Serialize_repr"})
+
+	// serde_repr writes and reads the enums by their integer values
+	serializedCode += "#[derive(Serialize_repr, Deserialize_repr, Debug, Clone, Copy, PartialEq, Eq)]\n"
+	serializedCode += "#[repr(i32)]\n"
+	serializedCode += fmt.Sprintf("pub enum %s {\n", enumName)
+
+	for _, value := range enum.enumValues {
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeRust, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("    %s = %v,\n", valueName, value.value)
+	}
+
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fileName, serializedCode), nil
+}
+
+/**
+Serialize mod.rs, which declares the modules of the models and re-exports them.
+*/
+func (r *rustLanguageSerializer) serializeModule(modules []string, exports []string) *generatedCode {
+	serializedCode := r.serializeDeclaration()
+
+	for _, module := range modules {
+		serializedCode += fmt.Sprintf("mod %s;\n", module)
+	}
+
+	if len(modules) > 0 {
+		serializedCode += "\n"
+	}
+
+	for i, module := range modules {
+		serializedCode += fmt.Sprintf("pub use %s::%s;\n", module, exports[i])
+	}
+
+	return newGeneratedCode("mod.rs", serializedCode)
+}
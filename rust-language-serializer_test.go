@@ -0,0 +1,331 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_rustLanguageSerializer_generateCode(t *testing.T) {
+	r := newRustLanguageSerializer()
+
+	testClass := &class{
+		name: "orderItem",
+		dataMembers: []*dataMember{
+			{
+				memberType: "int",
+				name:       "a",
+			},
+		},
+	}
+
+	testEnum := &enum{
+		name: "orderStatus",
+		enumValues: []*enumValue{
+			{
+				name:  "a",
+				value: 5,
+			},
+		},
+		annotations: []*annotation{
+			{namespace: "rust", name: "name", arguments: []string{"Status"}},
+		},
+	}
+
+	generatedCode, err := r.generateCode([]middleware{testClass, testEnum}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	// The class, the enum and mod.rs
+	if len(generatedCode) != 3 {
+		t.Errorf("generateCode() generated %v files. expected 3", len(generatedCode))
+		return
+	}
+
+	module := generatedCode[2]
+	expectedModule := "mod order_item;\nmod order_status;\n\n" +
+		"pub use order_item::OrderItem;\npub use order_status::Status;\n"
+	if module.fileName != "mod.rs" || !strings.HasSuffix(module.code, expectedModule) {
+		t.Errorf("generateCode() mod.rs = %v, want %v", module.code, expectedModule)
+	}
+}
+
+func Test_rustLanguageSerializer_getType(t *testing.T) {
+	if got := newRustLanguageSerializer().getType(); got != LanguageTypeRust {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeRust)
+	}
+}
+
+func Test_rustLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newRustLanguageSerializer().getTypeName(); got != "rust" {
+		t.Errorf("getTypeName() = %v, want %v", got, "rust")
+	}
+}
+
+func Test_rustLanguageSerializer_serializeClass(t *testing.T) {
+	owner := newClass("owner")
+	_ = owner.addValue("favorite", "node", nil)
+
+	node := newClass("node")
+	_ = node.addValue("parent", "node", nil)
+	_ = node.addValue("children", "list<node>", nil)
+	_ = node.addValue("owner", "owner", nil)
+	_ = node.addValue("status", "orderStatus", nil)
+
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Primitive class serialize",
+			args: args{
+				class: &class{
+					name: "orderItem",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "itemName",
+						},
+						{
+							memberType: "date",
+							name:       "createdAt",
+						},
+						{
+							memberType: "map<string,double>",
+							name:       "prices",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "order_item.rs",
+				code: "use chrono::{DateTime, Utc};\n" +
+					"use serde::{Deserialize, Serialize};\n" +
+					"use std::collections::HashMap;\n\n" +
+					"#[derive(Serialize, Deserialize, Debug, Clone)]\n" +
+					"#[serde(rename_all = \"camelCase\")]\n" +
+					"pub struct OrderItem {\n" +
+					"    pub item_name: String,\n" +
+					"    pub created_at: DateTime<Utc>,\n" +
+					"    pub prices: HashMap<String, f64>,\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Recursive class serialize",
+			args: args{
+				class:          node,
+				serializerInfo: &serializerInfo{classes: collectClasses([]middleware{owner, node})},
+			},
+			want: &generatedCode{
+				fileName: "node.rs",
+				code: "use serde::{Deserialize, Serialize};\n" +
+					"use super::{OrderStatus, Owner};\n\n" +
+					"#[derive(Serialize, Deserialize, Debug, Clone)]\n" +
+					"#[serde(rename_all = \"camelCase\")]\n" +
+					"pub struct Node {\n" +
+					"    pub parent: Box<Node>,\n" +
+					"    pub children: Vec<Node>,\n" +
+					"    pub owner: Box<Owner>,\n" +
+					"    pub status: OrderStatus,\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed fields and keywords",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "userID",
+						},
+						{
+							memberType: "string",
+							name:       "type",
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "rust", name: "type", arguments: []string{"Uuid"}},
+								{namespace: "rust", name: "import", arguments: []string{"uuid::Uuid"}},
+							},
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "test.rs",
+				code: "use serde::{Deserialize, Serialize};\n" +
+					"use uuid::Uuid;\n\n" +
+					"#[derive(Serialize, Deserialize, Debug, Clone)]\n" +
+					"#[serde(rename_all = \"camelCase\")]\n" +
+					"pub struct Test {\n" +
+					"    #[serde(rename = \"userID\")]\n" +
+					"    pub user_id: String,\n" +
+					"    pub r#type: String,\n" +
+					"    pub id: Uuid,\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeRust: "rust_decimal::Decimal"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "test.rs",
+				code: "use rust_decimal::Decimal;\n" +
+					"use serde::{Deserialize, Serialize};\n\n" +
+					"#[derive(Serialize, Deserialize, Debug, Clone)]\n" +
+					"#[serde(rename_all = \"camelCase\")]\n" +
+					"pub struct Test {\n" +
+					"    pub price: Decimal,\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "list<event>", name: "es"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "holder.rs",
+				code: "use serde::{Deserialize, Serialize};\n" +
+					"use super::{Category, Evt};\n\n" +
+					"#[derive(Serialize, Deserialize, Debug, Clone)]\n" +
+					"#[serde(rename_all = \"camelCase\")]\n" +
+					"pub struct Holder {\n" +
+					"    pub e: Evt,\n" +
+					"    pub es: Vec<Evt>,\n" +
+					"    pub kind: Category,\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRustLanguageSerializer()
+			got, err := r.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, r.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rustLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{name: "active", value: 5},
+						{name: "onHold", value: 8},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order_status.rs",
+				code: "use serde_repr::{Deserialize_repr, Serialize_repr};\n\n" +
+					"#[derive(Serialize_repr, Deserialize_repr, Debug, Clone, Copy, PartialEq, Eq)]\n" +
+					"#[repr(i32)]\n" +
+					"pub enum OrderStatus {\n" +
+					"    Active = 5,\n" +
+					"    OnHold = 8,\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty enum",
+			args: args{enum: &enum{name: "test", enumValues: []*enumValue{}}},
+			want: &generatedCode{
+				fileName: "test.rs",
+				code: "use serde::{Deserialize, Serialize};\n\n" +
+					"#[derive(Serialize, Deserialize, Debug, Clone, Copy, PartialEq, Eq)]\n" +
+					"pub enum Test {}\n",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRustLanguageSerializer()
+			got, err := r.serializeEnum(tt.args.enum)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, r.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
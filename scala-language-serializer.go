@@ -0,0 +1,436 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var scalaKeywords = []string{
+	"abstract", "case", "catch", "class", "def", "do", "else", "enum", "export", "extends", "false",
+	"final", "finally", "for", "forSome", "given", "if", "implicit", "import", "lazy", "match", "new",
+	"null", "object", "override", "package", "private", "protected", "return", "sealed", "super",
+	"then", "this", "throw", "trait", "true", "try", "type", "val", "var", "while", "with", "yield",
+}
+
+/**
+Circe has key codecs for these types only, so other map keys can't be written to JSON objects.
+*/
+var scalaMapKeyTypes = []string{"string", "int", "byte", "double"}
+
+/**
+The most fields circe can encode and decode with forProductN.
+*/
+const scalaMaxProductFields = 22
+
+/**
+Generate case classes with circe codecs in their companion objects.
+Enums are sealed abstract classes with case objects, or Scala 3 enums with the scala3 option.
+*/
+type scalaLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newScalaLanguageSerializer() *scalaLanguageSerializer {
+	result := &scalaLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "Boolean"
+	result.typesMap["int"] = "Int"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "Double"
+	result.typesMap["float"] = "Float"
+	result.typesMap["char"] = "Char"
+	result.typesMap["byte"] = "Byte"
+	result.typesMap["date"] = "Instant"
+
+	return result
+}
+
+func (s *scalaLanguageSerializer) getType() languageType {
+	return LanguageTypeScala
+}
+
+func (s *scalaLanguageSerializer) getTypeName() string {
+	return "scala"
+}
+
+func (s *scalaLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Scala
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := s.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result, nil
+}
+
+func (s *scalaLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return s.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return s.serializeEnum(enum, serializerInfo)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (s *scalaLanguageSerializer) serializeDeclaration(serializerInfo *serializerInfo) string {
+	generatedMark := "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+
+	if serializerInfo.packageName == "" {
+		return generatedMark
+	}
+
+	return fmt.Sprintf("package %s\n\n", serializerInfo.packageName) + generatedMark
+}
+
+/**
+Serialize the imports sorted, grouping the names of the same package, like "import io.circe.{Decoder, Encoder}".
+Every import is a full name like "java.time.Instant".
+*/
+func (s *scalaLanguageSerializer) serializeImports(imports []string) string {
+	if len(imports) == 0 {
+		return ""
+	}
+
+	names := make(map[string][]string)
+	packages := make([]string, 0)
+
+	for _, imp := range imports {
+		dot := strings.LastIndex(imp, ".")
+		if dot == -1 {
+			continue
+		}
+
+		packageName, name := imp[:dot], imp[dot+1:]
+		if _, ok := names[packageName]; !ok {
+			packages = append(packages, packageName)
+		}
+
+		names[packageName] = appendUnique(names[packageName], name)
+	}
+
+	if len(packages) == 0 {
+		return ""
+	}
+
+	sort.Strings(packages)
+
+	result := ""
+	for _, packageName := range packages {
+		packageNames := names[packageName]
+		sort.Strings(packageNames)
+
+		if len(packageNames) == 1 {
+			result += fmt.Sprintf("import %s.%s\n", packageName, packageNames[0])
+		} else {
+			result += fmt.Sprintf("import %s.{%s}\n", packageName, strings.Join(packageNames, ", "))
+		}
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Scala type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (s *scalaLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := s.typesMap[typeName]; isPrimitive {
+		if typeName == "date" {
+			return primitiveType, "java.time.Instant", true
+		}
+
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeScala)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "com.acme.Money" is imported as is and used as "Money"
+	dot := strings.LastIndex(externName, ".")
+	if dot == -1 {
+		return externName, "", true
+	}
+
+	return externName[dot+1:], externName, true
+}
+
+/**
+Serialize the Scala type of a gen file type, with List[T] for lists and Map[K, V] for maps.
+Return the imports the type needs.
+*/
+func (s *scalaLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, imports := s.typeName(listType, serializerInfo)
+
+		return fmt.Sprintf("List[%s]", itemType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, imports := s.typeName(mapKeyType, serializerInfo)
+		valueType, valueImports := s.typeName(mapValueType, serializerInfo)
+
+		for _, imp := range valueImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		return fmt.Sprintf("Map[%s, %s]", keyType, valueType), imports
+	}
+
+	if knownType, imp, isKnown := s.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, imp)
+	}
+
+	// The models are generated into the same package, so they don't need imports
+	return s.className(typeName, serializerInfo), []string{}
+}
+
+/**
+Check the map keys of a data member, since circe writes maps as JSON objects and has key codecs for few types only.
+Extern types are trusted to have their own key codecs.
+*/
+func (s *scalaLanguageSerializer) validateMapKey(class *class, member *dataMember, serializerInfo *serializerInfo) error {
+	isMap, mapKeyType, _ := isMap(member.memberType)
+	if !isMap {
+		return nil
+	}
+
+	if _, isExtern := findExternType(serializerInfo, mapKeyType, LanguageTypeScala); isExtern {
+		return nil
+	}
+
+	for _, keyType := range scalaMapKeyTypes {
+		if mapKeyType == keyType {
+			return nil
+		}
+	}
+
+	return errors.New(fmt.Sprintf("circe doesn't support %s map keys, which %s.%s uses",
+		mapKeyType, class.name, member.name))
+}
+
+/**
+Get the Scala class name of a class or an enum, taking the name annotation into account.
+*/
+func (s *scalaLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeScala, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Keywords are escaped with backticks, which keeps them as the JSON names.
+*/
+func (s *scalaLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeScala, "name"); ok {
+		return name
+	}
+
+	return s.escapeKeyword(toCamelCase(member.name))
+}
+
+func (s *scalaLanguageSerializer) escapeKeyword(name string) string {
+	for _, keyword := range scalaKeywords {
+		if name == keyword {
+			return fmt.Sprintf("`%s`", name)
+		}
+	}
+
+	return name
+}
+
+/**
+Serialize the codec definition of a companion object, like "implicit val encoder: Encoder[Order] =" in Scala 2
+and "given Encoder[Order] =" in Scala 3. Codecs of recursive classes are lazy in Scala 2, so they can refer to themselves.
+*/
+func (s *scalaLanguageSerializer) serializeCodec(codec string, typeName string, isLazy bool, serializerInfo *serializerInfo) string {
+	if _, isScala3 := findOption(serializerInfo, "scala3"); isScala3 {
+		return fmt.Sprintf("given %s[%s] =", codec, typeName)
+	}
+
+	modifier := "implicit val"
+	if isLazy {
+		modifier = "implicit lazy val"
+	}
+
+	return fmt.Sprintf("%s %s: %s[%s] =", modifier, strings.ToLower(codec), codec, typeName)
+}
+
+func (s *scalaLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeScala, "name"); ok {
+		className = name
+	}
+
+	imports := []string{"io.circe.Decoder", "io.circe.Encoder"}
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypeScala) {
+		imports = appendUnique(imports, imp)
+	}
+
+	fields := make([]string, 0)
+	jsonNames := make([]string, 0)
+	isRenamed := false
+
+	for _, member := range class.dataMembers {
+		if err := s.validateMapKey(class, member, serializerInfo); err != nil {
+			return nil, err
+		}
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeScala) {
+			imports = appendUnique(imports, imp)
+		}
+
+		memberType, memberImports := s.typeName(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeScala, "type"); ok {
+			memberType, memberImports = overrideType, []string{}
+		} else if hasLanguageAnnotation(member.annotations, LanguageTypeScala, "optional") {
+			memberType = fmt.Sprintf("Option[%s]", memberType)
+		}
+
+		for _, imp := range memberImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		fieldName := s.fieldName(member)
+		jsonName := toCamelCase(member.name)
+
+		// Derived codecs use the field names as the JSON names
+		if strings.Trim(fieldName, "`") != jsonName {
+			isRenamed = true
+		}
+
+		fields = append(fields, fmt.Sprintf("%s: %s", fieldName, memberType))
+		jsonNames = append(jsonNames, jsonName)
+	}
+
+	serializedCode := fmt.Sprintf("final case class %s(", className)
+	if len(fields) > 0 {
+		serializedCode += fmt.Sprintf("\n  %s\n", strings.Join(fields, ",\n  "))
+	}
+	serializedCode += ")\n\n"
+
+	isLazy := isCyclicClass(class, serializerInfo.classes)
+	encoder := s.serializeCodec("Encoder", className, isLazy, serializerInfo)
+	decoder := s.serializeCodec("Decoder", className, isLazy, serializerInfo)
+
+	serializedCode += fmt.Sprintf("object %s {\n", className)
+
+	if isRenamed {
+		// Renamed fields don't match their JSON names, so the names are given to the product codecs
+		if len(fields) > scalaMaxProductFields {
+			return nil, errors.New(fmt.Sprintf("%s has renamed fields and more than %v fields, which circe can't encode by names",
+				class.name, scalaMaxProductFields))
+		}
+
+		quotedNames := make([]string, 0)
+		values := make([]string, 0)
+		for i, member := range class.dataMembers {
+			quotedNames = append(quotedNames, fmt.Sprintf("\"%s\"", jsonNames[i]))
+			values = append(values, "value."+s.fieldName(member))
+		}
+
+		names := strings.Join(quotedNames, ", ")
+		serializedCode += fmt.Sprintf("  %s\n    Encoder.forProduct%v(%s)(value => (%s))\n",
+			encoder, len(fields), names, strings.Join(values, ", "))
+		serializedCode += fmt.Sprintf("  %s\n    Decoder.forProduct%v(%s)(%s.apply)\n",
+			decoder, len(fields), names, className)
+	} else {
+		imports = append(imports, "io.circe.generic.semiauto.deriveDecoder", "io.circe.generic.semiauto.deriveEncoder")
+
+		serializedCode += fmt.Sprintf("  %s deriveEncoder[%s]\n", encoder, className)
+		serializedCode += fmt.Sprintf("  %s deriveDecoder[%s]\n", decoder, className)
+	}
+
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.scala", className),
+		s.serializeDeclaration(serializerInfo)+s.serializeImports(imports)+serializedCode), nil
+}
+
+func (s *scalaLanguageSerializer) serializeEnum(enum *enum, serializerInfo *serializerInfo) (*generatedCode, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeScala, "name"); ok {
+		enumName = name
+	}
+
+	// Scala 3 enums must have cases, and an empty sealed class can't be decoded anyway
+	if len(enum.enumValues) == 0 {
+		return nil, errors.New(fmt.Sprintf("enum %s has no values", enum.name))
+	}
+
+	_, isScala3 := findOption(serializerInfo, "scala3")
+
+	valueNames := make([]string, 0)
+	serializedCode := ""
+
+	if isScala3 {
+		serializedCode += fmt.Sprintf("enum %s(val value: Int) {\n", enumName)
+	} else {
+		serializedCode += fmt.Sprintf("sealed abstract class %s(val value: Int)\n\n", enumName)
+		serializedCode += fmt.Sprintf("object %s {\n", enumName)
+	}
+
+	for _, value := range enum.enumValues {
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeScala, "name"); ok {
+			valueName = name
+		}
+
+		valueNames = append(valueNames, valueName)
+
+		if isScala3 {
+			serializedCode += fmt.Sprintf("  case %s extends %s(%v)\n", valueName, enumName, value.value)
+		} else {
+			serializedCode += fmt.Sprintf("  case object %s extends %s(%v)\n", valueName, enumName, value.value)
+		}
+	}
+
+	if isScala3 {
+		// Scala 3 enums have their values already, so the codecs are added in the companion object
+		serializedCode += "}\n\n"
+		serializedCode += fmt.Sprintf("object %s {\n", enumName)
+	} else {
+		serializedCode += fmt.Sprintf("\n  val values: List[%s] = List(%s)\n\n", enumName, strings.Join(valueNames, ", "))
+	}
+
+	// The enums are written and read by their integer values
+	serializedCode += fmt.Sprintf("  %s Encoder.encodeInt.contramap(_.value)\n",
+		s.serializeCodec("Encoder", enumName, false, serializerInfo))
+	serializedCode += fmt.Sprintf("  %s Decoder.decodeInt.emap { value =>\n",
+		s.serializeCodec("Decoder", enumName, false, serializerInfo))
+	serializedCode += fmt.Sprintf("    values.find(_.value == value).toRight(s\"unknown %s value $value\")\n", enumName)
+	serializedCode += "  }\n"
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.scala", enumName),
+		s.serializeDeclaration(serializerInfo)+
+			s.serializeImports([]string{"io.circe.Decoder", "io.circe.Encoder"})+serializedCode), nil
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_scalaLanguageSerializer_getType(t *testing.T) {
+	if got := newScalaLanguageSerializer().getType(); got != LanguageTypeScala {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeScala)
+	}
+}
+
+func Test_scalaLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newScalaLanguageSerializer().getTypeName(); got != "scala" {
+		t.Errorf("getTypeName() = %v, want %v", got, "scala")
+	}
+}
+
+func Test_scalaLanguageSerializer_generateCode(t *testing.T) {
+	testClass := newClass("orderItem")
+	_ = testClass.addValue("price", "double", nil)
+
+	testEnum := newEnum("orderStatus")
+	_ = testEnum.addValue("active", "1", nil)
+
+	testService, _ := getTestService()
+
+	generatedCode, err := newScalaLanguageSerializer().generateCode(
+		[]middleware{testClass, testEnum, testService}, &serializerInfo{packageName: "com.acme.models"})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(generatedCode) != 2 {
+		t.Errorf("generateCode() generated %v files. expected 2", len(generatedCode))
+		return
+	}
+
+	if generatedCode[0].fileName != "OrderItem.scala" || generatedCode[1].fileName != "OrderStatus.scala" {
+		t.Errorf("generateCode() file names = %v, %v", generatedCode[0].fileName, generatedCode[1].fileName)
+	}
+
+	if !strings.HasPrefix(generatedCode[0].code, "package com.acme.models\n\n") {
+		t.Errorf("generateCode() code doesn't start with the package.\ncode: %v", generatedCode[0].code)
+	}
+}
+
+func Test_scalaLanguageSerializer_serializeClass(t *testing.T) {
+	node := newClass("node")
+	_ = node.addValue("children", "list<node>", nil)
+
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Class serialize",
+			args: args{
+				class: &class{
+					name: "order",
+					dataMembers: []*dataMember{
+						{memberType: "date", name: "createdAt"},
+						{memberType: "list<orderItem>", name: "items"},
+						{memberType: "map<int,orderStatus>", name: "statuses"},
+						{
+							memberType:  "string",
+							name:        "note",
+							annotations: []*annotation{{namespace: "scala", name: "optional"}},
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Order.scala",
+				code: "import io.circe.{Decoder, Encoder}\n" +
+					"import io.circe.generic.semiauto.{deriveDecoder, deriveEncoder}\n" +
+					"import java.time.Instant\n\n" +
+					"final case class Order(\n" +
+					"  createdAt: Instant,\n" +
+					"  items: List[OrderItem],\n" +
+					"  statuses: Map[Int, OrderStatus],\n" +
+					"  note: Option[String]\n" +
+					")\n\n" +
+					"object Order {\n" +
+					"  implicit val encoder: Encoder[Order] = deriveEncoder[Order]\n" +
+					"  implicit val decoder: Decoder[Order] = deriveDecoder[Order]\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Scala 3 class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{options: map[string]string{"scala3": ""}},
+			},
+			want: &generatedCode{
+				fileName: "Test.scala",
+				code: "import io.circe.{Decoder, Encoder}\n" +
+					"import io.circe.generic.semiauto.{deriveDecoder, deriveEncoder}\n\n" +
+					"final case class Test()\n\n" +
+					"object Test {\n" +
+					"  given Encoder[Test] = deriveEncoder[Test]\n" +
+					"  given Decoder[Test] = deriveDecoder[Test]\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Recursive class",
+			args: args{
+				class:          node,
+				serializerInfo: &serializerInfo{classes: map[string]*class{"node": node}},
+			},
+			want: &generatedCode{
+				fileName: "Node.scala",
+				code: "import io.circe.{Decoder, Encoder}\n" +
+					"import io.circe.generic.semiauto.{deriveDecoder, deriveEncoder}\n\n" +
+					"final case class Node(\n" +
+					"  children: List[Node]\n" +
+					")\n\n" +
+					"object Node {\n" +
+					"  implicit lazy val encoder: Encoder[Node] = deriveEncoder[Node]\n" +
+					"  implicit lazy val decoder: Decoder[Node] = deriveDecoder[Node]\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides, keywords and extern types",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{memberType: "string", name: "type"},
+						{
+							memberType: "Money",
+							name:       "price",
+							annotations: []*annotation{
+								{namespace: "scala", name: "name", arguments: []string{"cost"}},
+							},
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "scala", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeScala: "com.acme.Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "Renamed.scala",
+				code: "import com.acme.Money\n" +
+					"import io.circe.{Decoder, Encoder}\n\n" +
+					"final case class Renamed(\n" +
+					"  `type`: String,\n" +
+					"  cost: Money\n" +
+					")\n\n" +
+					"object Renamed {\n" +
+					"  implicit val encoder: Encoder[Renamed] =\n" +
+					"    Encoder.forProduct2(\"type\", \"price\")(value => (value.`type`, value.cost))\n" +
+					"  implicit val decoder: Decoder[Renamed] =\n" +
+					"    Decoder.forProduct2(\"type\", \"price\")(Renamed.apply)\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unsupported map key",
+			args: args{
+				class: &class{
+					name:        "test",
+					dataMembers: []*dataMember{{memberType: "map<bool,string>", name: "flags"}},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "list<event>", name: "es"},
+						{memberType: "kind", name: "kind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "Holder.scala",
+				code: "import io.circe.{Decoder, Encoder}\n" +
+					"import io.circe.generic.semiauto.{deriveDecoder, deriveEncoder}\n\n" +
+					"final case class Holder(\n" +
+					"  es: List[Evt],\n" +
+					"  kind: Category\n" +
+					")\n\n" +
+					"object Holder {\n" +
+					"  implicit val encoder: Encoder[Holder] = deriveEncoder[Holder]\n" +
+					"  implicit val decoder: Decoder[Holder] = deriveDecoder[Holder]\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScalaLanguageSerializer()
+			got, err := s.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, s.serializeDeclaration(tt.args.serializerInfo), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scalaLanguageSerializer_serializeEnum(t *testing.T) {
+	testEnum := &enum{
+		name: "orderStatus",
+		enumValues: []*enumValue{
+			{name: "active", value: 5},
+			{name: "onHold", value: 8},
+		},
+	}
+
+	type args struct {
+		enum           *enum
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Sealed class enum",
+			args: args{enum: testEnum, serializerInfo: &serializerInfo{}},
+			want: &generatedCode{
+				fileName: "OrderStatus.scala",
+				code: "import io.circe.{Decoder, Encoder}\n\n" +
+					"sealed abstract class OrderStatus(val value: Int)\n\n" +
+					"object OrderStatus {\n" +
+					"  case object Active extends OrderStatus(5)\n" +
+					"  case object OnHold extends OrderStatus(8)\n\n" +
+					"  val values: List[OrderStatus] = List(Active, OnHold)\n\n" +
+					"  implicit val encoder: Encoder[OrderStatus] = Encoder.encodeInt.contramap(_.value)\n" +
+					"  implicit val decoder: Decoder[OrderStatus] = Decoder.decodeInt.emap { value =>\n" +
+					"    values.find(_.value == value).toRight(s\"unknown OrderStatus value $value\")\n" +
+					"  }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Scala 3 enum",
+			args: args{enum: testEnum, serializerInfo: &serializerInfo{options: map[string]string{"scala3": ""}}},
+			want: &generatedCode{
+				fileName: "OrderStatus.scala",
+				code: "import io.circe.{Decoder, Encoder}\n\n" +
+					"enum OrderStatus(val value: Int) {\n" +
+					"  case Active extends OrderStatus(5)\n" +
+					"  case OnHold extends OrderStatus(8)\n" +
+					"}\n\n" +
+					"object OrderStatus {\n" +
+					"  given Encoder[OrderStatus] = Encoder.encodeInt.contramap(_.value)\n" +
+					"  given Decoder[OrderStatus] = Decoder.decodeInt.emap { value =>\n" +
+					"    values.find(_.value == value).toRight(s\"unknown OrderStatus value $value\")\n" +
+					"  }\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Empty enum",
+			args:    args{enum: &enum{name: "test", enumValues: []*enumValue{}}, serializerInfo: &serializerInfo{}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScalaLanguageSerializer()
+			got, err := s.serializeEnum(tt.args.enum, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, s.serializeDeclaration(tt.args.serializerInfo), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import "fmt"
+
+/**
+Get the schema name of a class or an enum, like "OrderCreated" for "orderCreated".
+*/
+func schemaName(typeName string) string {
+	return toFirstCharUpper(typeName)
+}
+
+/**
+Build the JSON schema of a gen file type.
+Classes and enums are referenced with $ref by the given format of their schema name,
+like "#/components/schemas/%s" or "%s.schema.json".
+*/
+func typeSchema(typeName string, refFormat string, serializerInfo *serializerInfo) *orderedMap {
+	if isList, listType := isList(typeName); isList {
+		return newOrderedMap().
+			set("type", "array").
+			set("items", typeSchema(listType, refFormat, serializerInfo))
+	}
+
+	if isMap, _, mapValueType := isMap(typeName); isMap {
+		// JSON object keys are always strings
+		return newOrderedMap().
+			set("type", "object").
+			set("additionalProperties", typeSchema(mapValueType, refFormat, serializerInfo))
+	}
+
+	switch typeName {
+	case "bool":
+		return newOrderedMap().set("type", "boolean")
+	case "int":
+		return newOrderedMap().set("type", "integer").set("format", "int32")
+	case "byte":
+		return newOrderedMap().set("type", "integer").set("minimum", 0).set("maximum", 255)
+	case "double":
+		return newOrderedMap().set("type", "number").set("format", "double")
+	case "float":
+		return newOrderedMap().set("type", "number").set("format", "float")
+	case "string":
+		return newOrderedMap().set("type", "string")
+	case "char":
+		return newOrderedMap().set("type", "string").set("minLength", 1).set("maxLength", 1)
+	case "date":
+		return newOrderedMap().set("type", "string").set("format", "date-time")
+	}
+
+	// Extern types are hand-written, so we can only reference their schema if they have one
+	if ref, hasSchema := findExternType(serializerInfo, typeName, LanguageTypeJSONSchema); hasSchema {
+		return newOrderedMap().set("$ref", ref)
+	}
+
+	if _, isExtern := serializerInfo.externTypes[typeName]; isExtern {
+		return newOrderedMap().set("description", fmt.Sprintf("extern type %s", typeName))
+	}
+
+	return newOrderedMap().set("$ref", fmt.Sprintf(refFormat, schemaName(typeName)))
+}
+
+/**
+Build the JSON schema of a class. All the data members are required,
+and they are named by their JSON names.
+*/
+func classSchema(class *class, refFormat string, serializerInfo *serializerInfo) *orderedMap {
+	properties := newOrderedMap()
+	required := make([]interface{}, 0)
+
+	for _, member := range class.dataMembers {
+		properties.set(toCamelCase(member.name), typeSchema(member.memberType, refFormat, serializerInfo))
+		required = append(required, toCamelCase(member.name))
+	}
+
+	result := newOrderedMap().
+		set("type", "object").
+		set("properties", properties)
+
+	if len(required) > 0 {
+		result.set("required", required)
+	}
+
+	return result
+}
+
+/**
+Build the JSON schema of an enum. Enums are serialized as their integer values,
+and the value names are kept in x-enum-varnames.
+*/
+func enumSchema(enum *enum) *orderedMap {
+	values := make([]interface{}, 0)
+	names := make([]interface{}, 0)
+
+	for _, value := range enum.enumValues {
+		values = append(values, value.value)
+		names = append(names, value.name)
+	}
+
+	return newOrderedMap().
+		set("type", "integer").
+		set("enum", values).
+		set("x-enum-varnames", names)
+}
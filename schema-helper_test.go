@@ -0,0 +1,88 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_typeSchema(t *testing.T) {
+	info := &serializerInfo{externTypes: getTestExternTypes()}
+	info.externTypes["Currency"] = &externType{
+		name:          "Currency",
+		languageNames: map[languageType]string{LanguageTypeJSONSchema: "https://acme.com/currency.schema.json"},
+	}
+
+	type args struct {
+		typeName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *orderedMap
+	}{
+		{name: "Int", args: args{typeName: "int"}, want: newOrderedMap().set("type", "integer").set("format", "int32")},
+		{name: "Date", args: args{typeName: "date"}, want: newOrderedMap().set("type", "string").set("format", "date-time")},
+		{
+			name: "List",
+			args: args{typeName: "list<string>"},
+			want: newOrderedMap().set("type", "array").set("items", newOrderedMap().set("type", "string")),
+		},
+		{
+			name: "Map",
+			args: args{typeName: "map<int,user>"},
+			want: newOrderedMap().
+				set("type", "object").
+				set("additionalProperties", newOrderedMap().set("$ref", "#/defs/User")),
+		},
+		{name: "Class", args: args{typeName: "user"}, want: newOrderedMap().set("$ref", "#/defs/User")},
+		{name: "Extern type", args: args{typeName: "Money"}, want: newOrderedMap().set("description", "extern type Money")},
+		{
+			name: "Extern type with schema",
+			args: args{typeName: "Currency"},
+			want: newOrderedMap().set("$ref", "https://acme.com/currency.schema.json"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := typeSchema(tt.args.typeName, "#/defs/%s", info); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("typeSchema() = %v, want %v", serializeJSON(got), serializeJSON(tt.want))
+			}
+		})
+	}
+}
+
+func Test_classSchema(t *testing.T) {
+	user := newClass("user")
+	_ = user.addValue("UserName", "string", nil)
+	_ = user.addValue("tags", "list<string>", nil)
+
+	want := newOrderedMap().
+		set("type", "object").
+		set("properties", newOrderedMap().
+			set("userName", newOrderedMap().set("type", "string")).
+			set("tags", newOrderedMap().set("type", "array").set("items", newOrderedMap().set("type", "string")))).
+		set("required", []interface{}{"userName", "tags"})
+
+	if got := classSchema(user, "#/defs/%s", &serializerInfo{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("classSchema() = %v, want %v", serializeJSON(got), serializeJSON(want))
+	}
+
+	if got := classSchema(newClass("empty"), "#/defs/%s", &serializerInfo{}); got.keys[len(got.keys)-1] == "required" {
+		t.Errorf("classSchema() added required to a class without data members")
+	}
+}
+
+func Test_enumSchema(t *testing.T) {
+	status := newEnum("status")
+	_ = status.addValue("active", "1", nil)
+	_ = status.addValue("closed", "5", nil)
+
+	want := newOrderedMap().
+		set("type", "integer").
+		set("enum", []interface{}{1, 5}).
+		set("x-enum-varnames", []interface{}{"active", "closed"})
+
+	if got := enumSchema(status); !reflect.DeepEqual(got, want) {
+		t.Errorf("enumSchema() = %v, want %v", serializeJSON(got), serializeJSON(want))
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode"
 )
 
 /**
@@ -102,3 +105,551 @@ func enumValueUnique(values []*enumValue, value *enumValue) bool {
 
 	return true
 }
+
+/**
+Find a language specific annotation, like @go.type("time.Time"), and return its first argument.
+Annotations of other languages are ignored.
+*/
+func findLanguageAnnotation(annotations []*annotation, language languageType, name string) (string, bool) {
+	for _, a := range annotations {
+		if a.name != name || len(a.arguments) == 0 {
+			continue
+		}
+
+		if annotationLanguage, ok := annotationNamespaces[a.namespace]; ok && annotationLanguage == language {
+			return a.arguments[0], true
+		}
+	}
+
+	return "", false
+}
+
+/**
+Collect all the imports declared for the given language with @language.import annotations.
+*/
+func findLanguageImports(annotations []*annotation, language languageType) []string {
+	imports := make([]string, 0)
+
+	for _, a := range annotations {
+		if a.name != "import" {
+			continue
+		}
+
+		if annotationLanguage, ok := annotationNamespaces[a.namespace]; ok && annotationLanguage == language {
+			for _, argument := range a.arguments {
+				imports = appendUnique(imports, argument)
+			}
+		}
+	}
+
+	return imports
+}
+
+/**
+Collect the extern types declared in the gen file by their names.
+*/
+func collectExternTypes(objects []middleware) map[string]*externType {
+	result := make(map[string]*externType)
+
+	for _, object := range objects {
+		if extern, ok := object.(*externType); ok {
+			result[extern.name] = extern
+		}
+	}
+
+	return result
+}
+
+/**
+Find the full name of an extern type in the given language.
+Return false if the type isn't extern, or it doesn't have a name in the language.
+*/
+func findExternType(serializerInfo *serializerInfo, typeName string, language languageType) (string, bool) {
+	extern, ok := serializerInfo.externTypes[typeName]
+	if !ok {
+		return "", false
+	}
+
+	languageName, ok := extern.languageNames[language]
+
+	return languageName, ok
+}
+
+/**
+Append an import if it isn't empty and not already exists.
+*/
+func appendImport(imports []string, imp string) []string {
+	if imp == "" {
+		return imports
+	}
+
+	return appendUnique(imports, imp)
+}
+
+/**
+Collect the classes declared in the gen file by their names.
+*/
+func collectClasses(objects []middleware) map[string]*class {
+	result := make(map[string]*class)
+
+	for _, object := range objects {
+		if c, ok := object.(*class); ok {
+			result[c.name] = c
+		}
+	}
+
+	return result
+}
+
+func collectEnums(objects []middleware) map[string]*enum {
+	result := make(map[string]*enum)
+
+	for _, object := range objects {
+		if e, ok := object.(*enum); ok {
+			result[e.name] = e
+		}
+	}
+
+	return result
+}
+
+/**
+Find the name a class or an enum is renamed to in the given language with the name annotation.
+Return false if the type isn't a class or an enum, or it isn't renamed in the language.
+*/
+func findLanguageName(typeName string, language languageType, serializerInfo *serializerInfo) (string, bool) {
+	if c, ok := serializerInfo.classes[typeName]; ok {
+		return findLanguageAnnotation(c.annotations, language, "name")
+	}
+
+	if e, ok := serializerInfo.enums[typeName]; ok {
+		return findLanguageAnnotation(e.annotations, language, "name")
+	}
+
+	return "", false
+}
+
+/**
+Find a data member of a class by its name.
+*/
+func findDataMember(class *class, name string) *dataMember {
+	if class == nil {
+		return nil
+	}
+
+	for _, member := range class.dataMembers {
+		if member.name == toCamelCase(name) {
+			return member
+		}
+	}
+
+	return nil
+}
+
+type pathPart struct {
+	value       string
+	isParameter bool
+}
+
+/**
+Split an HTTP path to literal parts and parameters.
+For example "/users/{id}" is split to the literal "/users/" and the parameter "id".
+*/
+func splitPath(path string) []*pathPart {
+	result := make([]*pathPart, 0)
+
+	for path != "" {
+		open := strings.Index(path, "{")
+		closing := strings.Index(path, "}")
+		if open == -1 || closing < open {
+			result = append(result, &pathPart{value: path})
+			break
+		}
+
+		if open > 0 {
+			result = append(result, &pathPart{value: path[:open]})
+		}
+
+		result = append(result, &pathPart{value: path[open+1 : closing], isParameter: true})
+		path = path[closing+1:]
+	}
+
+	return result
+}
+
+/**
+Get the parameter names of an HTTP path, like "id" in "/users/{id}".
+*/
+func pathParameters(path string) []string {
+	result := make([]string, 0)
+
+	for _, part := range splitPath(path) {
+		if part.isParameter {
+			result = append(result, part.value)
+		}
+	}
+
+	return result
+}
+
+/**
+Get the request data members which are sent as query parameters. Methods without a request body
+send every data member which isn't a path parameter in the query string.
+*/
+func queryMembers(method *serviceMethod, classes map[string]*class) []*dataMember {
+	result := make([]*dataMember, 0)
+
+	request, ok := classes[method.requestType]
+	if !ok || hasRequestBody(method) {
+		return result
+	}
+
+	for _, member := range request.dataMembers {
+		isPathParameter := false
+		for _, parameter := range pathParameters(method.path) {
+			if member.name == toCamelCase(parameter) {
+				isPathParameter = true
+			}
+		}
+
+		if !isPathParameter {
+			result = append(result, member)
+		}
+	}
+
+	return result
+}
+
+/**
+Split a topic name to its words, like "orders" and "created" in "orders.created".
+*/
+func topicWords(topic string) []string {
+	return strings.FieldsFunc(topic, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+/**
+Get the type name of a topic, like "OrdersCreated" for "orders.created".
+*/
+func topicTypeName(topic string) string {
+	result := ""
+	for _, word := range topicWords(topic) {
+		result += toFirstCharUpper(word)
+	}
+
+	return result
+}
+
+/**
+Get the constant name of a topic, like "ORDERS_CREATED" for "orders.created".
+*/
+func topicConstantName(topic string) string {
+	return strings.ToUpper(strings.Join(topicWords(topic), "_"))
+}
+
+/**
+Collect the channels declared in the gen file, keeping their order.
+*/
+func collectChannels(objects []middleware) []*channel {
+	result := make([]*channel, 0)
+
+	for _, object := range objects {
+		if c, ok := object.(*channel); ok {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+/**
+Get a language option given in the command line, like "dataclass" in "python:models:dataclass".
+Flags are found with an empty value.
+*/
+func findOption(serializerInfo *serializerInfo, name string) (string, bool) {
+	value, ok := serializerInfo.options[name]
+
+	return value, ok
+}
+
+/**
+Convert a camel case name to snake case, like "order_id" for "orderId" and "http_code" for "HTTPCode".
+*/
+func toSnakeCase(value string) string {
+	runes := []rune(value)
+	result := ""
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				result += "_"
+			}
+		}
+
+		result += string(unicode.ToLower(r))
+	}
+
+	return result
+}
+
+/**
+Check if a data member of a class leads back to the class through direct data members,
+like "parent order" in class order. Lists and maps break the cycle, since their items
+are allocated separately, so languages without references should box only these members.
+*/
+func isRecursiveMember(class *class, member *dataMember, classes map[string]*class) bool {
+	return referencesClass(member.memberType, class.name, classes, make(map[string]bool))
+}
+
+func referencesClass(typeName string, target string, classes map[string]*class, visited map[string]bool) bool {
+	if typeName == target {
+		return true
+	}
+
+	c, ok := classes[typeName]
+	if !ok || visited[typeName] {
+		return false
+	}
+
+	visited[typeName] = true
+
+	for _, member := range c.dataMembers {
+		if isList, _ := isList(member.memberType); isList {
+			continue
+		}
+
+		if isMap, _, _ := isMap(member.memberType); isMap {
+			continue
+		}
+
+		if referencesClass(member.memberType, target, classes, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+Find an annotation which isn't language specific, like @field(3), and return its first argument.
+*/
+func findAnnotation(annotations []*annotation, name string) (string, bool) {
+	for _, a := range annotations {
+		if a.namespace == "" && a.name == name && len(a.arguments) > 0 {
+			return a.arguments[0], true
+		}
+	}
+
+	return "", false
+}
+
+/**
+Convert a snake case name to camel case, like "orderId" for "order_id".
+*/
+func snakeToCamelCase(value string) string {
+	words := strings.Split(value, "_")
+	result := words[0]
+
+	for _, word := range words[1:] {
+		result += toFirstCharUpper(word)
+	}
+
+	return result
+}
+
+/**
+Check if a data member or a declaration has an annotation that isn't language specific, like @pk.
+*/
+func hasAnnotation(annotations []*annotation, name string) bool {
+	for _, a := range annotations {
+		if a.namespace == "" && a.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+Check if a data member or a declaration has a language specific annotation, like @sql.table, with or without arguments.
+*/
+func hasLanguageAnnotation(annotations []*annotation, language languageType, name string) bool {
+	for _, a := range annotations {
+		if annotationLanguage, ok := annotationNamespaces[a.namespace]; ok && annotationLanguage == language && a.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+Sort names so every name comes after the names it depends on, keeping the original order otherwise.
+A name can depend on itself, but longer cycles return an error.
+*/
+func sortByDependencies(names []string, dependencies map[string][]string) ([]string, error) {
+	result := make([]string, 0, len(names))
+	state := make(map[string]int)
+
+	const visiting, visited = 1, 2
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.New(fmt.Sprintf("%s depend on each other", strings.Join(append(path, name), " -> ")))
+		}
+
+		state[name] = visiting
+
+		for _, dependency := range dependencies[name] {
+			if dependency == name {
+				continue
+			}
+
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		state[name] = visited
+		result = append(result, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+/**
+Group names which depend on each other, and sort the groups so every group comes after the groups it depends on.
+Names that aren't in a cycle are groups of their own, and the original order is kept otherwise.
+*/
+func groupByDependencies(names []string, dependencies map[string][]string) [][]string {
+	result := make([][]string, 0)
+	order := make(map[string]int)
+	for i, name := range names {
+		order[name] = i
+	}
+
+	// Tarjan's algorithm, which finds every group after the groups it depends on
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := make([]string, 0)
+
+	var visit func(name string)
+	visit = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, dependency := range dependencies[name] {
+			if _, isVisited := index[dependency]; !isVisited {
+				visit(dependency)
+				if lowLink[dependency] < lowLink[name] {
+					lowLink[name] = lowLink[dependency]
+				}
+			} else if onStack[dependency] && index[dependency] < lowLink[name] {
+				lowLink[name] = index[dependency]
+			}
+		}
+
+		if lowLink[name] != index[name] {
+			return
+		}
+
+		group := make([]string, 0)
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			group = append(group, last)
+
+			if last == name {
+				break
+			}
+		}
+
+		sort.Slice(group, func(i, j int) bool { return order[group[i]] < order[group[j]] })
+		result = append(result, group)
+	}
+
+	for _, name := range names {
+		if _, isVisited := index[name]; !isVisited {
+			visit(name)
+		}
+	}
+
+	return result
+}
+
+/**
+Get the gen file types a data member type is made of, like "orderItem" for list<orderItem>,
+and the key and value types for maps.
+*/
+func elementTypes(memberType string) []string {
+	if isList, listType := isList(memberType); isList {
+		return []string{listType}
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(memberType); isMap {
+		return []string{mapKeyType, mapValueType}
+	}
+
+	return []string{memberType}
+}
+
+/**
+Check if a class leads back to itself through its data members, including lists and maps,
+like "children list<node>" in class node.
+*/
+func isCyclicClass(class *class, classes map[string]*class) bool {
+	visited := make(map[string]bool)
+
+	for _, member := range class.dataMembers {
+		for _, typeName := range elementTypes(member.memberType) {
+			if reachesClass(typeName, class.name, classes, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func reachesClass(typeName string, target string, classes map[string]*class, visited map[string]bool) bool {
+	if typeName == target {
+		return true
+	}
+
+	c, ok := classes[typeName]
+	if !ok || visited[typeName] {
+		return false
+	}
+
+	visited[typeName] = true
+
+	for _, member := range c.dataMembers {
+		for _, elementType := range elementTypes(member.memberType) {
+			if reachesClass(elementType, target, classes, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -333,3 +333,555 @@ func Test_enumValueUnique(t *testing.T) {
 		})
 	}
 }
+
+func Test_findLanguageAnnotation(t *testing.T) {
+	annotations := []*annotation{
+		{namespace: "go", name: "type", arguments: []string{"time.Time"}},
+		{namespace: "ts", name: "type", arguments: []string{"string"}},
+		{name: "type", arguments: []string{"general"}},
+	}
+
+	type args struct {
+		language languageType
+		name     string
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 bool
+	}{
+		{name: "Go annotation", args: args{language: LanguageTypeGo, name: "type"}, want: "time.Time", want1: true},
+		{name: "Typescript short namespace", args: args{language: LanguageTypeTypescript, name: "type"}, want: "string", want1: true},
+		{name: "Other language", args: args{language: LanguageTypeKotlin, name: "type"}, want: "", want1: false},
+		{name: "Other annotation", args: args{language: LanguageTypeGo, name: "name"}, want: "", want1: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := findLanguageAnnotation(annotations, tt.args.language, tt.args.name)
+			if got != tt.want {
+				t.Errorf("findLanguageAnnotation() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("findLanguageAnnotation() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_findLanguageImports(t *testing.T) {
+	annotations := []*annotation{
+		{namespace: "go", name: "import", arguments: []string{"time", "strings"}},
+		{namespace: "go", name: "import", arguments: []string{"time"}},
+		{namespace: "kotlin", name: "import", arguments: []string{"java.time.Instant"}},
+	}
+
+	want := []string{"time", "strings"}
+	if got := findLanguageImports(annotations, LanguageTypeGo); !reflect.DeepEqual(got, want) {
+		t.Errorf("findLanguageImports() = %v, want %v", got, want)
+	}
+}
+
+func getTestExternTypes() map[string]*externType {
+	return map[string]*externType{
+		"Money": {
+			name: "Money",
+			languageNames: map[languageType]string{
+				LanguageTypeGo:         "github.com/acme/money.Money",
+				LanguageTypeTypescript: "@acme/money#Money",
+				LanguageTypeKotlin:     "com.acme.Money",
+				LanguageTypeCSharp:     "Acme.Money",
+			},
+		},
+	}
+}
+
+/**
+Get a serializer info with a class and an enum which are renamed for every language,
+so classes that use them must use the new names.
+*/
+func getTestRenamedTypesInfo() *serializerInfo {
+	renames := func(name string) []*annotation {
+		result := make([]*annotation, 0)
+		for namespace := range annotationNamespaces {
+			result = append(result, &annotation{namespace: namespace, name: "name", arguments: []string{name}})
+		}
+
+		return result
+	}
+
+	event := &class{name: "event", annotations: renames("Evt"), dataMembers: []*dataMember{}}
+	kind := &enum{name: "kind", annotations: renames("Category"), enumValues: []*enumValue{{name: "a", value: 1}}}
+
+	return &serializerInfo{
+		packageName: "bla",
+		classes:     collectClasses([]middleware{event}),
+		enums:       collectEnums([]middleware{kind}),
+	}
+}
+
+func Test_collectEnums(t *testing.T) {
+	kind := &enum{name: "kind"}
+	event := &class{name: "event"}
+
+	got := collectEnums([]middleware{kind, event})
+	if len(got) != 1 || got["kind"] != kind {
+		t.Errorf("collectEnums() = %v, want only kind", got)
+	}
+}
+
+func Test_findLanguageName(t *testing.T) {
+	info := getTestRenamedTypesInfo()
+	info.classes["plain"] = &class{name: "plain", annotations: []*annotation{
+		{namespace: "go", name: "name", arguments: []string{"Plain"}},
+	}}
+
+	tests := []struct {
+		name     string
+		typeName string
+		language languageType
+		want     string
+		want1    bool
+	}{
+		{name: "Renamed class", typeName: "event", language: LanguageTypeGo, want: "Evt", want1: true},
+		{name: "Renamed enum", typeName: "kind", language: LanguageTypeKotlin, want: "Category", want1: true},
+		{name: "Not renamed in the language", typeName: "plain", language: LanguageTypeJava, want: "", want1: false},
+		{name: "Unknown type", typeName: "other", language: LanguageTypeGo, want: "", want1: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := findLanguageName(tt.typeName, tt.language, info)
+			if got != tt.want {
+				t.Errorf("findLanguageName() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("findLanguageName() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_findExternType(t *testing.T) {
+	info := &serializerInfo{externTypes: getTestExternTypes()}
+
+	if got, ok := findExternType(info, "Money", LanguageTypeKotlin); !ok || got != "com.acme.Money" {
+		t.Errorf("findExternType() = %v, %v, want %v, %v", got, ok, "com.acme.Money", true)
+	}
+
+	if _, ok := findExternType(info, "Other", LanguageTypeKotlin); ok {
+		t.Errorf("findExternType() found a type which isn't extern")
+	}
+
+	if _, ok := findExternType(&serializerInfo{}, "Money", LanguageTypeKotlin); ok {
+		t.Errorf("findExternType() found a type without extern types")
+	}
+}
+
+func getTestService() (*service, *serializerInfo) {
+	getUserRequest := newClass("getUserRequest")
+	_ = getUserRequest.addValue("id", "string", []*annotation{
+		{namespace: "go", name: "name", arguments: []string{"ID"}},
+	})
+	_ = getUserRequest.addValue("verbose", "bool", nil)
+
+	createUserRequest := newClass("createUserRequest")
+	_ = createUserRequest.addValue("name", "string", nil)
+
+	user := newClass("user")
+	_ = user.addValue("id", "string", nil)
+	_ = user.addValue("name", "string", nil)
+
+	userService := newService("userService")
+	_ = userService.addValue("getUser(getUserRequest)", "user",
+		[]*annotation{{name: "http", arguments: []string{"GET", "/users/{id}"}}})
+	_ = userService.addValue("createUser(createUserRequest)", "user",
+		[]*annotation{{name: "http", arguments: []string{"POST", "/users"}}})
+	_ = userService.addValue("ping()", "user",
+		[]*annotation{{name: "http", arguments: []string{"GET", "/ping"}}})
+
+	info := &serializerInfo{
+		packageName: "models",
+		classes:     collectClasses([]middleware{getUserRequest, createUserRequest, user}),
+	}
+
+	return userService, info
+}
+
+func Test_splitPath(t *testing.T) {
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*pathPart
+	}{
+		{name: "Without parameters", args: args{path: "/users"}, want: []*pathPart{{value: "/users"}}},
+		{
+			name: "Parameter in the middle",
+			args: args{path: "/users/{id}/orders"},
+			want: []*pathPart{{value: "/users/"}, {value: "id", isParameter: true}, {value: "/orders"}},
+		},
+		{
+			name: "Few parameters",
+			args: args{path: "/{a}/{b}"},
+			want: []*pathPart{{value: "/"}, {value: "a", isParameter: true}, {value: "/"}, {value: "b", isParameter: true}},
+		},
+		{name: "Empty path", args: args{path: ""}, want: []*pathPart{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitPath(tt.args.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pathParameters(t *testing.T) {
+	want := []string{"userId", "orderId"}
+	if got := pathParameters("/users/{userId}/orders/{orderId}"); !reflect.DeepEqual(got, want) {
+		t.Errorf("pathParameters() = %v, want %v", got, want)
+	}
+}
+
+func Test_queryMembers(t *testing.T) {
+	userService, info := getTestService()
+
+	getUser := userService.methods[0]
+	if got := queryMembers(getUser, info.classes); len(got) != 1 || got[0].name != "verbose" {
+		t.Errorf("queryMembers() = %v, want only verbose", got)
+	}
+
+	// Methods with a request body send the whole request as the body
+	createUser := userService.methods[1]
+	if got := queryMembers(createUser, info.classes); len(got) != 0 {
+		t.Errorf("queryMembers() = %v, want no query members for a request body", got)
+	}
+}
+
+func getTestChannels() ([]*channel, *serializerInfo) {
+	order := newClass("orderCreated")
+	_ = order.addValue("orderId", "int", nil)
+	_ = order.addValue("status", "string", nil)
+
+	created := newChannel("orders.created", "orderCreated",
+		[]*annotation{{name: "key", arguments: []string{"orderId"}}})
+	_ = created.addValue("traceId", "string", nil)
+
+	closed := newChannel("orders.closed", "orderCreated", nil)
+
+	info := &serializerInfo{
+		packageName: "models",
+		classes:     collectClasses([]middleware{order}),
+	}
+
+	return []*channel{created, closed}, info
+}
+
+func Test_topicTypeName(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{topic: "orders.created", want: "OrdersCreated"},
+		{topic: "orders-created.v2", want: "OrdersCreatedV2"},
+		{topic: "orders", want: "Orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			if got := topicTypeName(tt.topic); got != tt.want {
+				t.Errorf("topicTypeName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_topicConstantName(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{topic: "orders.created", want: "ORDERS_CREATED"},
+		{topic: "orders-created.v2", want: "ORDERS_CREATED_V2"},
+		{topic: "orders", want: "ORDERS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			if got := topicConstantName(tt.topic); got != tt.want {
+				t.Errorf("topicConstantName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_toSnakeCase(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "orderId", want: "order_id"},
+		{value: "OrderItem", want: "order_item"},
+		{value: "HTTPCode", want: "http_code"},
+		{value: "userID", want: "user_id"},
+		{value: "address2Line", want: "address2_line"},
+		{value: "name", want: "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := toSnakeCase(tt.value); got != tt.want {
+				t.Errorf("toSnakeCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findOption(t *testing.T) {
+	info := &serializerInfo{options: map[string]string{"dataclass": "", "style": "records"}}
+
+	if value, ok := findOption(info, "style"); !ok || value != "records" {
+		t.Errorf("findOption() = %v, %v, want %v, %v", value, ok, "records", true)
+	}
+
+	if _, ok := findOption(info, "dataclass"); !ok {
+		t.Errorf("findOption() didn't find a flag")
+	}
+
+	if _, ok := findOption(&serializerInfo{}, "dataclass"); ok {
+		t.Errorf("findOption() found an option without options")
+	}
+}
+
+func Test_isRecursiveMember(t *testing.T) {
+	owner := newClass("owner")
+	_ = owner.addValue("favorite", "node", nil)
+	_ = owner.addValue("name", "string", nil)
+
+	node := newClass("node")
+	_ = node.addValue("parent", "node", nil)
+	_ = node.addValue("children", "list<node>", nil)
+	_ = node.addValue("owner", "owner", nil)
+	_ = node.addValue("name", "string", nil)
+
+	leaf := newClass("leaf")
+	_ = leaf.addValue("owner", "owner", nil)
+
+	classes := collectClasses([]middleware{owner, node, leaf})
+
+	tests := []struct {
+		name   string
+		class  *class
+		member *dataMember
+		want   bool
+	}{
+		{name: "Self reference", class: node, member: node.dataMembers[0], want: true},
+		{name: "List of itself", class: node, member: node.dataMembers[1], want: false},
+		{name: "Cycle through another class", class: node, member: node.dataMembers[2], want: true},
+		{name: "Primitive", class: node, member: node.dataMembers[3], want: false},
+		{name: "Reference without cycle", class: leaf, member: leaf.dataMembers[0], want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRecursiveMember(tt.class, tt.member, classes); got != tt.want {
+				t.Errorf("isRecursiveMember() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findAnnotation(t *testing.T) {
+	annotations := []*annotation{
+		{namespace: "go", name: "field", arguments: []string{"7"}},
+		{name: "field", arguments: []string{"3"}},
+	}
+
+	if value, ok := findAnnotation(annotations, "field"); !ok || value != "3" {
+		t.Errorf("findAnnotation() = %v, %v, want %v, %v", value, ok, "3", true)
+	}
+
+	if _, ok := findAnnotation(annotations, "key"); ok {
+		t.Errorf("findAnnotation() found a missing annotation")
+	}
+}
+
+func Test_snakeToCamelCase(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "order_id", want: "orderId"},
+		{value: "user_id", want: "userId"},
+		{value: "address2_line", want: "address2Line"},
+		{value: "name", want: "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := snakeToCamelCase(tt.value); got != tt.want {
+				t.Errorf("snakeToCamelCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_hasAnnotation(t *testing.T) {
+	annotations := []*annotation{
+		{name: "pk"},
+		{namespace: "sql", name: "table"},
+	}
+
+	if !hasAnnotation(annotations, "pk") {
+		t.Errorf("hasAnnotation() didn't find an annotation without arguments")
+	}
+
+	if hasAnnotation(annotations, "table") {
+		t.Errorf("hasAnnotation() found a language annotation")
+	}
+
+	if !hasLanguageAnnotation(annotations, LanguageTypeSQL, "table") {
+		t.Errorf("hasLanguageAnnotation() didn't find a language annotation without arguments")
+	}
+
+	if hasLanguageAnnotation(annotations, LanguageTypeGo, "table") || hasLanguageAnnotation(annotations, LanguageTypeSQL, "pk") {
+		t.Errorf("hasLanguageAnnotation() found an annotation of another language")
+	}
+}
+
+func Test_sortByDependencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		names        []string
+		dependencies map[string][]string
+		want         []string
+		wantErr      bool
+	}{
+		{
+			name:         "Without dependencies",
+			names:        []string{"a", "b", "c"},
+			dependencies: map[string][]string{},
+			want:         []string{"a", "b", "c"},
+		},
+		{
+			name:         "Dependencies first",
+			names:        []string{"order", "item", "customer"},
+			dependencies: map[string][]string{"order": {"customer", "item"}, "item": {"product"}},
+			want:         []string{"customer", "product", "item", "order"},
+		},
+		{
+			name:         "Self dependency",
+			names:        []string{"node"},
+			dependencies: map[string][]string{"node": {"node"}},
+			want:         []string{"node"},
+		},
+		{
+			name:         "Cycle",
+			names:        []string{"a", "b"},
+			dependencies: map[string][]string{"a": {"b"}, "b": {"a"}},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sortByDependencies(tt.names, tt.dependencies)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sortByDependencies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortByDependencies() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_groupByDependencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		names        []string
+		dependencies map[string][]string
+		want         [][]string
+	}{
+		{
+			name:         "Without dependencies",
+			names:        []string{"a", "b"},
+			dependencies: map[string][]string{},
+			want:         [][]string{{"a"}, {"b"}},
+		},
+		{
+			name:         "Self dependency",
+			names:        []string{"node"},
+			dependencies: map[string][]string{"node": {"node"}},
+			want:         [][]string{{"node"}},
+		},
+		{
+			name:         "Cycle after its dependency",
+			names:        []string{"order", "item", "status"},
+			dependencies: map[string][]string{"order": {"item", "status"}, "item": {"order"}},
+			want:         [][]string{{"status"}, {"order", "item"}},
+		},
+		{
+			name:         "Group in the original order",
+			names:        []string{"c", "b", "a"},
+			dependencies: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			want:         [][]string{{"c", "b", "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupByDependencies(tt.names, tt.dependencies); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupByDependencies() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_elementTypes(t *testing.T) {
+	tests := []struct {
+		memberType string
+		want       []string
+	}{
+		{memberType: "int", want: []string{"int"}},
+		{memberType: "list<orderItem>", want: []string{"orderItem"}},
+		{memberType: "map<string,orderItem>", want: []string{"string", "orderItem"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.memberType, func(t *testing.T) {
+			if got := elementTypes(tt.memberType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("elementTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isCyclicClass(t *testing.T) {
+	tree := newClass("tree")
+	_ = tree.addValue("children", "list<tree>", nil)
+
+	owner := newClass("owner")
+	_ = owner.addValue("pets", "map<string,pet>", nil)
+
+	pet := newClass("pet")
+	_ = pet.addValue("owner", "owner", nil)
+
+	leaf := newClass("leaf")
+	_ = leaf.addValue("owner", "owner", nil)
+
+	classes := collectClasses([]middleware{tree, owner, pet, leaf})
+
+	tests := []struct {
+		name  string
+		class *class
+		want  bool
+	}{
+		{name: "List of itself", class: tree, want: true},
+		{name: "Cycle through a map", class: owner, want: true},
+		{name: "Cycle through another class", class: pet, want: true},
+		{name: "Reference to a cycle", class: leaf, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCyclicClass(tt.class, classes); got != tt.want {
+				t.Errorf("isCyclicClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
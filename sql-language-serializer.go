@@ -0,0 +1,555 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const sqlDialectPostgres = "postgres"
+const sqlDialectSQLite = "sqlite"
+
+var sqlKeywords = []string{"all", "and", "as", "asc", "between", "by", "case", "check", "column", "constraint",
+	"create", "default", "delete", "desc", "distinct", "drop", "else", "end", "from", "group", "having", "in",
+	"index", "insert", "into", "is", "join", "key", "like", "limit", "not", "null", "offset", "on", "or", "order",
+	"primary", "references", "select", "set", "table", "then", "to", "union", "unique", "update", "user", "using",
+	"values", "when", "where", "with"}
+
+/**
+Represent a column of a generated table. The constraint is written after the type, like a CHECK or REFERENCES.
+*/
+type sqlColumn struct {
+	name       string
+	columnType string
+	constraint string
+}
+
+/**
+Generate SQL tables for the classes, in a single schema.sql file.
+The dialect option chooses between Postgres (the default) and SQLite, like sql::dialect=sqlite.
+*/
+type sqlLanguageSerializer struct {
+	dialectTypes map[string]map[string]string
+}
+
+func newSQLLanguageSerializer() *sqlLanguageSerializer {
+	result := &sqlLanguageSerializer{dialectTypes: make(map[string]map[string]string, 0)}
+
+	result.dialectTypes[sqlDialectPostgres] = map[string]string{
+		"bool":   "BOOLEAN",
+		"int":    "INTEGER",
+		"string": "TEXT",
+		"double": "DOUBLE PRECISION",
+		"float":  "REAL",
+		"char":   "CHAR(1)",
+		"byte":   "SMALLINT",
+		"date":   "TIMESTAMPTZ",
+		"json":   "JSONB",
+	}
+
+	// SQLite doesn't have booleans or dates, so they are integers and ISO 8601 texts
+	result.dialectTypes[sqlDialectSQLite] = map[string]string{
+		"bool":   "INTEGER",
+		"int":    "INTEGER",
+		"string": "TEXT",
+		"double": "REAL",
+		"float":  "REAL",
+		"char":   "TEXT",
+		"byte":   "INTEGER",
+		"date":   "TEXT",
+		"json":   "TEXT",
+	}
+
+	return result
+}
+
+func (s *sqlLanguageSerializer) getType() languageType {
+	return LanguageTypeSQL
+}
+
+func (s *sqlLanguageSerializer) getTypeName() string {
+	return "sql"
+}
+
+func (s *sqlLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	dialect, err := s.dialect(serializerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	classNames := make([]string, 0)
+	dependencies := make(map[string][]string)
+	statements := make([]string, 0)
+
+	if dialect == sqlDialectPostgres && serializerInfo.packageName != "" {
+		statements = append(statements, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;\n", s.identifier(serializerInfo.packageName)))
+	}
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *class:
+			classNames = append(classNames, o.name)
+			dependencies[o.name] = s.classDependencies(o, serializerInfo)
+		case *enum:
+			// Lookup tables come first, since the class tables reference them
+			if hasLanguageAnnotation(o.annotations, LanguageTypeSQL, "lookup") {
+				statements = append(statements, s.serializeLookupTable(o, serializerInfo))
+			}
+		}
+
+		// Extern types are hand-written, and services and channels aren't generated for SQL
+	}
+
+	// Postgres must create a table before other tables reference it
+	sortedNames, err := sortByDependencies(classNames, dependencies)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("can't create tables that reference each other: %v", err))
+	}
+
+	for _, name := range sortedNames {
+		tables, err := s.serializeClass(serializerInfo.classes[name], dialect, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, tables...)
+	}
+
+	return []*generatedCode{newGeneratedCode("schema.sql",
+		s.serializeDeclaration(dialect)+strings.Join(statements, "\n"))}, nil
+}
+
+func (s *sqlLanguageSerializer) serializeDeclaration(dialect string) string {
+	return "-- **********************************\n" +
+		"--\tGenerated by ModelsGenerator\n--\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n--\tDialect: " + dialect +
+		"\n-- **********************************\n\n"
+}
+
+func (s *sqlLanguageSerializer) dialect(serializerInfo *serializerInfo) (string, error) {
+	dialect, ok := findOption(serializerInfo, "dialect")
+	if !ok {
+		return sqlDialectPostgres, nil
+	}
+
+	dialect = strings.ToLower(dialect)
+	if dialect == "postgresql" {
+		dialect = sqlDialectPostgres
+	}
+
+	if _, isSupported := s.dialectTypes[dialect]; !isSupported {
+		return "", errors.New(fmt.Sprintf("unknown SQL dialect %s, it should be postgres or sqlite", dialect))
+	}
+
+	return dialect, nil
+}
+
+/**
+Quote an identifier if it's an SQL keyword, like "order".
+*/
+func (s *sqlLanguageSerializer) identifier(name string) string {
+	for _, keyword := range sqlKeywords {
+		if strings.EqualFold(keyword, name) {
+			return fmt.Sprintf("\"%s\"", name)
+		}
+	}
+
+	return name
+}
+
+/**
+Get the table name of a class or an enum, qualified by the schema in Postgres.
+*/
+func (s *sqlLanguageSerializer) tableName(name string, serializerInfo *serializerInfo) string {
+	if overrideName, ok := findLanguageName(name, LanguageTypeSQL, serializerInfo); ok {
+		return s.qualifiedName(overrideName, serializerInfo)
+	}
+
+	return s.qualifiedName(toSnakeCase(name), serializerInfo)
+}
+
+/**
+Qualify a table name by the schema in Postgres.
+*/
+func (s *sqlLanguageSerializer) qualifiedName(tableName string, serializerInfo *serializerInfo) string {
+	dialect, _ := s.dialect(serializerInfo)
+	if dialect == sqlDialectPostgres && serializerInfo.packageName != "" {
+		return s.identifier(serializerInfo.packageName) + "." + s.identifier(tableName)
+	}
+
+	return s.identifier(tableName)
+}
+
+func (s *sqlLanguageSerializer) classTableName(class *class, serializerInfo *serializerInfo) string {
+	return s.tableName(class.name, serializerInfo)
+}
+
+/**
+Get the column name of a data member, taking the name annotation into account.
+*/
+func (s *sqlLanguageSerializer) columnName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeSQL, "name"); ok {
+		return name
+	}
+
+	return toSnakeCase(member.name)
+}
+
+/**
+Get the classes whose tables the class references, so they must be created before it.
+*/
+func (s *sqlLanguageSerializer) classDependencies(class *class, serializerInfo *serializerInfo) []string {
+	result := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeSQL, "type"); ok {
+			continue
+		}
+
+		referencedType := member.memberType
+		if isList, listType := isList(member.memberType); isList {
+			referencedType = listType
+		} else if isMap, _, mapValueType := isMap(member.memberType); isMap {
+			referencedType = mapValueType
+		}
+
+		// Lists and maps in JSON columns don't reference other tables
+		if referencedType != member.memberType && !hasLanguageAnnotation(member.annotations, LanguageTypeSQL, "table") {
+			continue
+		}
+
+		if referenced, isClass := serializerInfo.classes[referencedType]; isClass && len(s.primaryKeyMembers(referenced)) > 0 {
+			result = appendUnique(result, referencedType)
+		}
+	}
+
+	return result
+}
+
+func (s *sqlLanguageSerializer) primaryKeyMembers(class *class) []*dataMember {
+	result := make([]*dataMember, 0)
+
+	for _, member := range class.dataMembers {
+		if hasAnnotation(member.annotations, "pk") {
+			result = append(result, member)
+		}
+	}
+
+	return result
+}
+
+/**
+Get the primary key columns of a class, or an empty list if it doesn't have @pk data members.
+*/
+func (s *sqlLanguageSerializer) primaryKeyColumns(class *class, dialect string, serializerInfo *serializerInfo) ([]*sqlColumn, error) {
+	result := make([]*sqlColumn, 0)
+
+	for _, member := range s.primaryKeyMembers(class) {
+		columns, _, err := s.memberColumns(class, member, dialect, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, columns...)
+	}
+
+	return result, nil
+}
+
+/**
+Build the columns which keep a value of a gen file type, named by the base name.
+A class with a primary key is referenced by a foreign key, like customer_id, and the other classes,
+lists and maps are kept as JSON. Return the foreign keys the columns need.
+*/
+func (s *sqlLanguageSerializer) typeColumns(baseName string, typeName string, dialect string, serializerInfo *serializerInfo) ([]*sqlColumn, []string, error) {
+	types := s.dialectTypes[dialect]
+
+	if primitiveType, isPrimitive := types[typeName]; isPrimitive && typeName != "json" {
+		return []*sqlColumn{{name: baseName, columnType: primitiveType}}, nil, nil
+	}
+
+	if externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeSQL); isExtern {
+		return []*sqlColumn{{name: baseName, columnType: externName}}, nil, nil
+	}
+
+	if e, isEnum := serializerInfo.enums[typeName]; isEnum {
+		return []*sqlColumn{s.enumColumn(baseName, e, serializerInfo)}, nil, nil
+	}
+
+	if referenced, isClass := serializerInfo.classes[typeName]; isClass && len(s.primaryKeyMembers(referenced)) > 0 {
+		keyColumns, err := s.primaryKeyColumns(referenced, dialect, serializerInfo)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		columns := make([]*sqlColumn, 0)
+		columnNames := make([]string, 0)
+		keyNames := make([]string, 0)
+
+		for _, keyColumn := range keyColumns {
+			name := baseName + "_" + keyColumn.name
+			columns = append(columns, &sqlColumn{name: name, columnType: keyColumn.columnType})
+			columnNames = append(columnNames, s.identifier(name))
+			keyNames = append(keyNames, s.identifier(keyColumn.name))
+		}
+
+		foreignKey := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+			strings.Join(columnNames, ", "), s.classTableName(referenced, serializerInfo), strings.Join(keyNames, ", "))
+
+		return columns, []string{foreignKey}, nil
+	}
+
+	return []*sqlColumn{s.jsonColumn(baseName, dialect)}, nil, nil
+}
+
+func (s *sqlLanguageSerializer) jsonColumn(name string, dialect string) *sqlColumn {
+	column := &sqlColumn{name: name, columnType: s.dialectTypes[dialect]["json"]}
+
+	// SQLite keeps JSON as text, so it checks it's valid
+	if dialect == sqlDialectSQLite {
+		column.constraint = fmt.Sprintf("CHECK (json_valid(%s))", s.identifier(name))
+	}
+
+	return column
+}
+
+/**
+Build the column of an enum, which keeps its integer value.
+The value is checked with a CHECK constraint, or references the lookup table of the enum.
+*/
+func (s *sqlLanguageSerializer) enumColumn(name string, enum *enum, serializerInfo *serializerInfo) *sqlColumn {
+	column := &sqlColumn{name: name, columnType: "INTEGER"}
+
+	if hasLanguageAnnotation(enum.annotations, LanguageTypeSQL, "lookup") {
+		column.constraint = fmt.Sprintf("REFERENCES %s (id)", s.tableName(enum.name, serializerInfo))
+		return column
+	}
+
+	if len(enum.enumValues) == 0 {
+		return column
+	}
+
+	values := make([]string, 0)
+	for _, value := range enum.enumValues {
+		values = append(values, fmt.Sprint(value.value))
+	}
+
+	column.constraint = fmt.Sprintf("CHECK (%s IN (%s))", s.identifier(name), strings.Join(values, ", "))
+
+	return column
+}
+
+/**
+Build the columns of a data member and the foreign keys they need.
+Lists and maps with @sql.table don't have columns, since they are kept in a child table.
+*/
+func (s *sqlLanguageSerializer) memberColumns(class *class, member *dataMember, dialect string, serializerInfo *serializerInfo) ([]*sqlColumn, []string, error) {
+	columnName := s.columnName(member)
+
+	if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeSQL, "type"); ok {
+		return []*sqlColumn{{name: columnName, columnType: overrideType}}, nil, nil
+	}
+
+	isListMember, _ := isList(member.memberType)
+	isMapMember, _, _ := isMap(member.memberType)
+
+	if !isListMember && !isMapMember {
+		return s.typeColumns(columnName, member.memberType, dialect, serializerInfo)
+	}
+
+	if hasAnnotation(member.annotations, "pk") || hasAnnotation(member.annotations, "index") {
+		return nil, nil, errors.New(fmt.Sprintf(
+			"data member %s of class %s is a list or a map, so it can't be a primary key or an index", member.name, class.name))
+	}
+
+	if hasLanguageAnnotation(member.annotations, LanguageTypeSQL, "table") {
+		return []*sqlColumn{}, nil, nil
+	}
+
+	return []*sqlColumn{s.jsonColumn(columnName, dialect)}, nil, nil
+}
+
+/**
+Serialize the table of a class, its indexes and the child tables of its lists and maps.
+*/
+func (s *sqlLanguageSerializer) serializeClass(class *class, dialect string, serializerInfo *serializerInfo) ([]string, error) {
+	tableName := s.classTableName(class, serializerInfo)
+	columns := make([]*sqlColumn, 0)
+	constraints := make([]string, 0)
+	indexes := make([]string, 0)
+	childTables := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		memberColumns, foreignKeys, err := s.memberColumns(class, member, dialect, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, memberColumns...)
+		constraints = append(constraints, foreignKeys...)
+
+		if len(memberColumns) == 0 {
+			childTable, err := s.serializeChildTable(class, member, dialect, serializerInfo)
+			if err != nil {
+				return nil, err
+			}
+
+			childTables = append(childTables, childTable)
+		}
+
+		if hasAnnotation(member.annotations, "index") {
+			indexes = append(indexes, s.serializeIndex(class, member, memberColumns, serializerInfo))
+		}
+	}
+
+	keyColumns, err := s.primaryKeyColumns(class, dialect, serializerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyColumns) > 0 {
+		constraints = append([]string{fmt.Sprintf("PRIMARY KEY (%s)", s.columnNames(keyColumns))}, constraints...)
+	}
+
+	result := []string{s.serializeTable(tableName, columns, constraints)}
+	if len(indexes) > 0 {
+		result = append(result, strings.Join(indexes, ""))
+	}
+
+	return append(result, childTables...), nil
+}
+
+func (s *sqlLanguageSerializer) serializeTable(tableName string, columns []*sqlColumn, constraints []string) string {
+	lines := make([]string, 0)
+
+	for _, column := range columns {
+		line := fmt.Sprintf("  %s %s NOT NULL", s.identifier(column.name), column.columnType)
+		if column.constraint != "" {
+			line += " " + column.constraint
+		}
+
+		lines = append(lines, line)
+	}
+
+	for _, constraint := range constraints {
+		lines = append(lines, "  "+constraint)
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);\n", tableName, strings.Join(lines, ",\n"))
+}
+
+func (s *sqlLanguageSerializer) columnNames(columns []*sqlColumn) string {
+	names := make([]string, 0)
+	for _, column := range columns {
+		names = append(names, s.identifier(column.name))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+/**
+Serialize the index of a data member with @index, or a unique index with @index(unique).
+*/
+func (s *sqlLanguageSerializer) serializeIndex(class *class, member *dataMember, columns []*sqlColumn, serializerInfo *serializerInfo) string {
+	kind := "INDEX"
+	if value, _ := findAnnotation(member.annotations, "index"); value == "unique" {
+		kind = "UNIQUE INDEX"
+	}
+
+	// Index names are unique in the whole schema, so they start with the table name
+	indexName := fmt.Sprintf("%s_%s_idx", toSnakeCase(class.name), s.columnName(member))
+
+	return fmt.Sprintf("CREATE %s %s ON %s (%s);\n",
+		kind, indexName, s.classTableName(class, serializerInfo), s.columnNames(columns))
+}
+
+/**
+Serialize the child table of a list or a map with @sql.table, like order_items for the items of order.
+The rows reference the parent row by its primary key, and they are ordered by position for lists,
+or identified by key for maps.
+*/
+func (s *sqlLanguageSerializer) serializeChildTable(class *class, member *dataMember, dialect string, serializerInfo *serializerInfo) (string, error) {
+	parentKeyColumns, err := s.primaryKeyColumns(class, dialect, serializerInfo)
+	if err != nil {
+		return "", err
+	}
+
+	if len(parentKeyColumns) == 0 {
+		return "", errors.New(fmt.Sprintf(
+			"data member %s of class %s is kept in a child table, but the class doesn't have a @pk data member", member.name, class.name))
+	}
+
+	parentName := toSnakeCase(class.name)
+	tableName := s.qualifiedName(parentName+"_"+s.columnName(member), serializerInfo)
+
+	columns := make([]*sqlColumn, 0)
+	for _, keyColumn := range parentKeyColumns {
+		columns = append(columns, &sqlColumn{name: parentName + "_" + keyColumn.name, columnType: keyColumn.columnType})
+	}
+
+	parentColumns := s.columnNames(columns)
+	constraints := make([]string, 0)
+
+	var valueColumns []*sqlColumn
+	var foreignKeys []string
+
+	if isList, listType := isList(member.memberType); isList {
+		columns = append(columns, &sqlColumn{name: "position", columnType: "INTEGER"})
+		constraints = append(constraints, fmt.Sprintf("PRIMARY KEY (%s, position)", parentColumns))
+
+		valueColumns, foreignKeys, err = s.typeColumns("value", listType, dialect, serializerInfo)
+	} else {
+		_, mapKeyType, mapValueType := isMap(member.memberType)
+
+		keyColumns, _, err := s.typeColumns("key", mapKeyType, dialect, serializerInfo)
+		if err != nil {
+			return "", err
+		}
+
+		columns = append(columns, keyColumns...)
+		constraints = append(constraints, fmt.Sprintf("PRIMARY KEY (%s, %s)", parentColumns, s.columnNames(keyColumns)))
+
+		valueColumns, foreignKeys, err = s.typeColumns("value", mapValueType, dialect, serializerInfo)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	columns = append(columns, valueColumns...)
+
+	// The child rows belong to the parent row, so they are deleted with it
+	constraints = append(constraints, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE CASCADE",
+		parentColumns, s.classTableName(class, serializerInfo), s.columnNames(parentKeyColumns)))
+	constraints = append(constraints, foreignKeys...)
+
+	return s.serializeTable(tableName, columns, constraints), nil
+}
+
+/**
+Serialize the lookup table of an enum with @sql.lookup, with a row for every enum value.
+*/
+func (s *sqlLanguageSerializer) serializeLookupTable(enum *enum, serializerInfo *serializerInfo) string {
+	tableName := s.tableName(enum.name, serializerInfo)
+
+	result := s.serializeTable(tableName,
+		[]*sqlColumn{{name: "id", columnType: "INTEGER"}, {name: "name", columnType: "TEXT", constraint: "UNIQUE"}},
+		[]string{"PRIMARY KEY (id)"})
+
+	if len(enum.enumValues) == 0 {
+		return result
+	}
+
+	rows := make([]string, 0)
+	for _, value := range enum.enumValues {
+		rows = append(rows, fmt.Sprintf("  (%v, '%s')", value.value, value.name))
+	}
+
+	return result + fmt.Sprintf("\nINSERT INTO %s (id, name) VALUES\n%s;\n", tableName, strings.Join(rows, ",\n"))
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_sqlLanguageSerializer_getType(t *testing.T) {
+	if got := newSQLLanguageSerializer().getType(); got != LanguageTypeSQL {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeSQL)
+	}
+}
+
+func Test_sqlLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newSQLLanguageSerializer().getTypeName(); got != "sql" {
+		t.Errorf("getTypeName() = %v, want %v", got, "sql")
+	}
+}
+
+func pkAnnotation() *annotation {
+	return &annotation{name: "pk"}
+}
+
+func sqlAnnotation(name string) *annotation {
+	return &annotation{namespace: "sql", name: name}
+}
+
+func getTestSQLObjects() []middleware {
+	order := &class{
+		name: "order",
+		dataMembers: []*dataMember{
+			{memberType: "int", name: "id", annotations: []*annotation{pkAnnotation()}},
+			{memberType: "customer", name: "customer"},
+			{memberType: "list<string>", name: "tags", annotations: []*annotation{sqlAnnotation("table")}},
+			{memberType: "map<string,double>", name: "meta"},
+			{memberType: "orderStatus", name: "status"},
+		},
+	}
+
+	customer := &class{
+		name: "customer",
+		dataMembers: []*dataMember{
+			{memberType: "string", name: "id", annotations: []*annotation{pkAnnotation()}},
+		},
+	}
+
+	status := &enum{
+		name:       "orderStatus",
+		enumValues: []*enumValue{{name: "active", value: 1}, {name: "closed", value: 2}},
+	}
+
+	return []middleware{order, customer, status}
+}
+
+func Test_sqlLanguageSerializer_generateCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    *serializerInfo
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Postgres",
+			info: &serializerInfo{packageName: "shop"},
+			want: "CREATE SCHEMA IF NOT EXISTS shop;\n\n" +
+				"CREATE TABLE shop.customer (\n  id TEXT NOT NULL,\n  PRIMARY KEY (id)\n);\n\n" +
+				"CREATE TABLE shop.\"order\" (\n" +
+				"  id INTEGER NOT NULL,\n" +
+				"  customer_id TEXT NOT NULL,\n" +
+				"  meta JSONB NOT NULL,\n" +
+				"  status INTEGER NOT NULL CHECK (status IN (1, 2)),\n" +
+				"  PRIMARY KEY (id),\n" +
+				"  FOREIGN KEY (customer_id) REFERENCES shop.customer (id)\n" +
+				");\n\n" +
+				"CREATE TABLE shop.order_tags (\n" +
+				"  order_id INTEGER NOT NULL,\n" +
+				"  position INTEGER NOT NULL,\n" +
+				"  value TEXT NOT NULL,\n" +
+				"  PRIMARY KEY (order_id, position),\n" +
+				"  FOREIGN KEY (order_id) REFERENCES shop.\"order\" (id) ON DELETE CASCADE\n" +
+				");\n",
+		},
+		{
+			name: "SQLite",
+			info: &serializerInfo{packageName: "shop", options: map[string]string{"dialect": "sqlite"}},
+			want: "CREATE TABLE customer (\n  id TEXT NOT NULL,\n  PRIMARY KEY (id)\n);\n\n" +
+				"CREATE TABLE \"order\" (\n" +
+				"  id INTEGER NOT NULL,\n" +
+				"  customer_id TEXT NOT NULL,\n" +
+				"  meta TEXT NOT NULL CHECK (json_valid(meta)),\n" +
+				"  status INTEGER NOT NULL CHECK (status IN (1, 2)),\n" +
+				"  PRIMARY KEY (id),\n" +
+				"  FOREIGN KEY (customer_id) REFERENCES customer (id)\n" +
+				");\n\n" +
+				"CREATE TABLE order_tags (\n" +
+				"  order_id INTEGER NOT NULL,\n" +
+				"  position INTEGER NOT NULL,\n" +
+				"  value TEXT NOT NULL,\n" +
+				"  PRIMARY KEY (order_id, position),\n" +
+				"  FOREIGN KEY (order_id) REFERENCES \"order\" (id) ON DELETE CASCADE\n" +
+				");\n",
+		},
+		{
+			name:    "Unknown dialect",
+			info:    &serializerInfo{options: map[string]string{"dialect": "oracle"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newSQLLanguageSerializer().generateCode(getTestSQLObjects(), tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("generateCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != 1 || got[0].fileName != "schema.sql" {
+				t.Errorf("generateCode() should create a single schema.sql file, got %v", got)
+				return
+			}
+
+			if !strings.HasSuffix(got[0].code, tt.want) {
+				t.Errorf("generateCode() got = %v, want %v", got[0].code, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sqlLanguageSerializer_generateCode_errors(t *testing.T) {
+	first := &class{name: "first", dataMembers: []*dataMember{
+		{memberType: "int", name: "id", annotations: []*annotation{pkAnnotation()}},
+		{memberType: "second", name: "second"},
+	}}
+	second := &class{name: "second", dataMembers: []*dataMember{
+		{memberType: "int", name: "id", annotations: []*annotation{pkAnnotation()}},
+		{memberType: "first", name: "first"},
+	}}
+	withoutKey := &class{name: "order", dataMembers: []*dataMember{
+		{memberType: "list<string>", name: "tags", annotations: []*annotation{sqlAnnotation("table")}},
+	}}
+	indexedList := &class{name: "order", dataMembers: []*dataMember{
+		{memberType: "list<string>", name: "tags", annotations: []*annotation{{name: "index"}}},
+	}}
+
+	tests := []struct {
+		name    string
+		objects []middleware
+	}{
+		{name: "Tables reference each other", objects: []middleware{first, second}},
+		{name: "Child table without primary key", objects: []middleware{withoutKey}},
+		{name: "Index on a list", objects: []middleware{indexedList}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newSQLLanguageSerializer().generateCode(tt.objects, &serializerInfo{}); err == nil {
+				t.Errorf("generateCode() expected an error")
+			}
+		})
+	}
+}
+
+func Test_sqlLanguageSerializer_serializeClass(t *testing.T) {
+	money := newExternType("money")
+	_ = money.addValue("sql", "NUMERIC(12, 2)", nil)
+
+	item := &class{
+		name: "orderItem",
+		dataMembers: []*dataMember{
+			{memberType: "string", name: "sku", annotations: []*annotation{pkAnnotation()}},
+			{memberType: "int", name: "order", annotations: []*annotation{pkAnnotation()}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		class *class
+		want  []string
+	}{
+		{
+			name: "Class with indexes and language overrides",
+			class: &class{
+				name:        "event",
+				annotations: []*annotation{{namespace: "sql", name: "name", arguments: []string{"events"}}},
+				dataMembers: []*dataMember{
+					{memberType: "date", name: "createdAt", annotations: []*annotation{{name: "index"}}},
+					{memberType: "string", name: "email", annotations: []*annotation{{name: "index", arguments: []string{"unique"}}}},
+					{
+						memberType: "string",
+						name:       "payload",
+						annotations: []*annotation{
+							{namespace: "sql", name: "type", arguments: []string{"XML"}},
+							{namespace: "sql", name: "name", arguments: []string{"body"}},
+						},
+					},
+					{memberType: "money", name: "price"},
+				},
+			},
+			want: []string{
+				"CREATE TABLE events (\n" +
+					"  created_at TIMESTAMPTZ NOT NULL,\n" +
+					"  email TEXT NOT NULL,\n" +
+					"  body XML NOT NULL,\n" +
+					"  price NUMERIC(12, 2) NOT NULL\n" +
+					");\n",
+				"CREATE INDEX event_created_at_idx ON events (created_at);\n" +
+					"CREATE UNIQUE INDEX event_email_idx ON events (email);\n",
+			},
+		},
+		{
+			name: "Child tables with composite keys",
+			class: &class{
+				name: "order",
+				dataMembers: []*dataMember{
+					{memberType: "int", name: "id", annotations: []*annotation{pkAnnotation()}},
+					{memberType: "list<orderItem>", name: "items", annotations: []*annotation{sqlAnnotation("table")}},
+					{memberType: "map<string,note>", name: "notes", annotations: []*annotation{sqlAnnotation("table")}},
+				},
+			},
+			want: []string{
+				"CREATE TABLE \"order\" (\n  id INTEGER NOT NULL,\n  PRIMARY KEY (id)\n);\n",
+				"CREATE TABLE order_items (\n" +
+					"  order_id INTEGER NOT NULL,\n" +
+					"  position INTEGER NOT NULL,\n" +
+					"  value_sku TEXT NOT NULL,\n" +
+					"  value_order INTEGER NOT NULL,\n" +
+					"  PRIMARY KEY (order_id, position),\n" +
+					"  FOREIGN KEY (order_id) REFERENCES \"order\" (id) ON DELETE CASCADE,\n" +
+					"  FOREIGN KEY (value_sku, value_order) REFERENCES order_item (sku, \"order\")\n" +
+					");\n",
+				"CREATE TABLE order_notes (\n" +
+					"  order_id INTEGER NOT NULL,\n" +
+					"  \"key\" TEXT NOT NULL,\n" +
+					"  value JSONB NOT NULL,\n" +
+					"  PRIMARY KEY (order_id, \"key\"),\n" +
+					"  FOREIGN KEY (order_id) REFERENCES \"order\" (id) ON DELETE CASCADE\n" +
+					");\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &serializerInfo{
+				externTypes: collectExternTypes([]middleware{money}),
+				classes:     collectClasses([]middleware{tt.class, item, newClass("note")}),
+			}
+
+			got, err := newSQLLanguageSerializer().serializeClass(tt.class, sqlDialectPostgres, info)
+			if err != nil {
+				t.Errorf("serializeClass() error = %v", err)
+				return
+			}
+
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("serializeClass() got = %v, want %v", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func Test_sqlLanguageSerializer_serializeLookupTable(t *testing.T) {
+	status := &enum{
+		name:        "orderStatus",
+		annotations: []*annotation{sqlAnnotation("lookup")},
+		enumValues:  []*enumValue{{name: "active", value: 1}, {name: "closed", value: 2}},
+	}
+
+	want := "CREATE TABLE order_status (\n" +
+		"  id INTEGER NOT NULL,\n" +
+		"  name TEXT NOT NULL UNIQUE,\n" +
+		"  PRIMARY KEY (id)\n" +
+		");\n\n" +
+		"INSERT INTO order_status (id, name) VALUES\n" +
+		"  (1, 'active'),\n" +
+		"  (2, 'closed');\n"
+
+	s := newSQLLanguageSerializer()
+	if got := s.serializeLookupTable(status, &serializerInfo{}); got != want {
+		t.Errorf("serializeLookupTable() got = %v, want %v", got, want)
+	}
+
+	column := s.enumColumn("status", status, &serializerInfo{})
+	if column.constraint != "REFERENCES order_status (id)" {
+		t.Errorf("enumColumn() constraint = %v, want a reference to the lookup table", column.constraint)
+	}
+	status.annotations = append(status.annotations, &annotation{namespace: "sql", name: "name", arguments: []string{"statuses"}})
+	column = s.enumColumn("status", status, &serializerInfo{enums: collectEnums([]middleware{status})})
+	if column.constraint != "REFERENCES statuses (id)" {
+		t.Errorf("enumColumn() constraint = %v, want a reference to the renamed lookup table", column.constraint)
+	}
+}
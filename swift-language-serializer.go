@@ -0,0 +1,349 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var swiftKeywords = []string{
+	"associatedtype", "class", "deinit", "enum", "extension", "fileprivate", "func", "import", "init",
+	"inout", "internal", "let", "open", "operator", "private", "protocol", "public", "rethrows", "static",
+	"struct", "subscript", "typealias", "var", "break", "case", "continue", "default", "defer", "do",
+	"else", "fallthrough", "for", "guard", "if", "in", "repeat", "return", "switch", "where", "while",
+	"as", "catch", "false", "is", "nil", "super", "self", "Self", "throw", "throws", "true", "try",
+}
+
+/**
+The coding helpers for the generated models. Swift decodes dates as seconds since 2001 by default,
+so the helpers configure ISO 8601 dates, which is what the other languages write.
+Structs can't contain themselves, so the helpers also have the Indirect property wrapper, which boxes recursive properties.
+*/
+const swiftCodingTemplate = `import Foundation
+
+private let modelsDateFormatters: [ISO8601DateFormatter] = {
+	let fractional = ISO8601DateFormatter()
+	fractional.formatOptions = [.withInternetDateTime, .withFractionalSeconds]
+
+	return [ISO8601DateFormatter(), fractional]
+}()
+
+extension JSONDecoder {
+	/// A decoder for the generated models. Dates are ISO 8601 strings, with or without fractional seconds.
+	static var models: JSONDecoder {
+		let decoder = JSONDecoder()
+		decoder.dateDecodingStrategy = .custom { decoder in
+			let container = try decoder.singleValueContainer()
+			let value = try container.decode(String.self)
+
+			for formatter in modelsDateFormatters {
+				if let date = formatter.date(from: value) {
+					return date
+				}
+			}
+
+			throw DecodingError.dataCorruptedError(in: container, debugDescription: "Invalid ISO 8601 date \(value)")
+		}
+
+		return decoder
+	}
+}
+
+extension JSONEncoder {
+	/// An encoder for the generated models. Dates are written as ISO 8601 strings.
+	static var models: JSONEncoder {
+		let encoder = JSONEncoder()
+		encoder.dateEncodingStrategy = .iso8601
+
+		return encoder
+	}
+}
+
+/// Boxes a property of a struct which contains itself, like "parent" in "struct User", since a struct can't store itself.
+/// It's encoded and decoded as the value itself.
+@propertyWrapper
+enum Indirect<Value: Codable & Equatable>: Codable, Equatable {
+	indirect case value(Value)
+
+	init(wrappedValue: Value) {
+		self = .value(wrappedValue)
+	}
+
+	var wrappedValue: Value {
+		get {
+			switch self {
+			case .value(let value):
+				return value
+			}
+		}
+		set {
+			self = .value(newValue)
+		}
+	}
+
+	init(from decoder: Decoder) throws {
+		self = .value(try Value(from: decoder))
+	}
+
+	func encode(to encoder: Encoder) throws {
+		try wrappedValue.encode(to: encoder)
+	}
+}
+`
+
+/**
+Generate Codable structs and enums. Swift has no packages, so the package name is ignored.
+*/
+type swiftLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newSwiftLanguageSerializer() *swiftLanguageSerializer {
+	result := &swiftLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "Bool"
+	result.typesMap["int"] = "Int"
+	result.typesMap["string"] = "String"
+	result.typesMap["double"] = "Double"
+	result.typesMap["float"] = "Float"
+	// Character isn't Codable
+	result.typesMap["char"] = "String"
+	result.typesMap["byte"] = "UInt8"
+	result.typesMap["date"] = "Date"
+
+	return result
+}
+
+func (s *swiftLanguageSerializer) getType() languageType {
+	return LanguageTypeSwift
+}
+
+func (s *swiftLanguageSerializer) getTypeName() string {
+	return "swift"
+}
+
+func (s *swiftLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, so we only reference them.
+		// Services and channels aren't generated for Swift
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := s.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return append(result, newGeneratedCode("ModelsCoding.swift", s.serializeDeclaration()+swiftCodingTemplate)), nil
+}
+
+func (s *swiftLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return s.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return s.serializeEnum(enum)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+func (s *swiftLanguageSerializer) serializeDeclaration() string {
+	return "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+}
+
+func (s *swiftLanguageSerializer) serializeImports(imports []string) string {
+	result := ""
+	for _, imp := range imports {
+		result += fmt.Sprintf("import %s\n", imp)
+	}
+
+	return result + "\n"
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Swift type.
+Return the module the type needs, or an empty string if it doesn't need one.
+*/
+func (s *swiftLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := s.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeSwift)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "MoneyKit.Money" is imported from the MoneyKit module and used as "Money"
+	dot := strings.LastIndex(externName, ".")
+	if dot == -1 {
+		return externName, "", true
+	}
+
+	return externName[dot+1:], externName[:dot], true
+}
+
+/**
+Serialize the Swift type of a gen file type, with [T] for lists and [K: V] for maps.
+Return the modules the type needs.
+*/
+func (s *swiftLanguageSerializer) memberType(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, imports := s.memberType(listType, serializerInfo)
+
+		return fmt.Sprintf("[%s]", itemType), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, imports := s.memberType(mapKeyType, serializerInfo)
+		valueType, valueImports := s.memberType(mapValueType, serializerInfo)
+
+		for _, imp := range valueImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		return fmt.Sprintf("[%s: %s]", keyType, valueType), imports
+	}
+
+	if knownType, imp, isKnown := s.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, imp)
+	}
+
+	return s.className(typeName, serializerInfo), []string{}
+}
+
+/**
+Get the Swift type name of a class or an enum, taking the name annotation into account.
+*/
+func (s *swiftLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeSwift, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Escape Swift keywords with backticks, so they can be used as names.
+*/
+func (s *swiftLanguageSerializer) escapeName(name string) string {
+	for _, keyword := range swiftKeywords {
+		if name == keyword {
+			return fmt.Sprintf("`%s`", name)
+		}
+	}
+
+	return name
+}
+
+/**
+Get the property name of a data member, taking the name annotation into account.
+*/
+func (s *swiftLanguageSerializer) memberName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeSwift, "name"); ok {
+		return s.escapeName(name)
+	}
+
+	return s.escapeName(toCamelCase(member.name))
+}
+
+func (s *swiftLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeSwift, "name"); ok {
+		className = name
+	}
+
+	imports := []string{"Foundation"}
+	for _, imp := range findLanguageImports(class.annotations, LanguageTypeSwift) {
+		imports = appendUnique(imports, imp)
+	}
+
+	properties := ""
+	codingKeys := ""
+
+	for _, member := range class.dataMembers {
+		memberName := s.memberName(member)
+		jsonName := toCamelCase(member.name)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeSwift) {
+			imports = appendUnique(imports, imp)
+		}
+
+		memberType, memberImports := s.memberType(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeSwift, "type"); ok {
+			memberType, memberImports = overrideType, []string{}
+		}
+
+		for _, imp := range memberImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		// A struct can't contain itself, so the recursive members are boxed
+		_, isOverridden := findLanguageAnnotation(member.annotations, LanguageTypeSwift, "type")
+		if !isOverridden && isRecursiveMember(class, member, serializerInfo.classes) {
+			properties += fmt.Sprintf("\t@Indirect var %s: %s\n", memberName, memberType)
+		} else {
+			properties += fmt.Sprintf("\tlet %s: %s\n", memberName, memberType)
+		}
+
+		if strings.Trim(memberName, "`") == jsonName {
+			codingKeys += fmt.Sprintf("\t\tcase %s\n", memberName)
+		} else {
+			codingKeys += fmt.Sprintf("\t\tcase %s = \"%s\"\n", memberName, jsonName)
+		}
+	}
+
+	serializedCode := fmt.Sprintf("struct %s: Codable, Equatable {\n", className)
+
+	if len(class.dataMembers) > 0 {
+		serializedCode += properties + "\n\tenum CodingKeys: String, CodingKey {\n" + codingKeys + "\t}\n"
+	}
+
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.swift", className),
+		s.serializeDeclaration()+s.serializeImports(imports)+serializedCode), nil
+}
+
+func (s *swiftLanguageSerializer) serializeEnum(enum *enum) (*generatedCode, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeSwift, "name"); ok {
+		enumName = name
+	}
+
+	// An enum without cases can't have a raw type
+	serializedCode := fmt.Sprintf("enum %s: Codable {\n", enumName)
+	if len(enum.enumValues) > 0 {
+		serializedCode = fmt.Sprintf("enum %s: Int, Codable {\n", enumName)
+	}
+
+	for _, value := range enum.enumValues {
+		valueName := s.escapeName(toCamelCase(value.name))
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeSwift, "name"); ok {
+			valueName = s.escapeName(name)
+		}
+
+		serializedCode += fmt.Sprintf("\tcase %s = %v\n", valueName, value.value)
+	}
+
+	serializedCode += "}\n"
+
+	return newGeneratedCode(fmt.Sprintf("%s.swift", enumName),
+		s.serializeDeclaration()+s.serializeImports([]string{"Foundation"})+serializedCode), nil
+}
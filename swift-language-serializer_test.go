@@ -0,0 +1,353 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_swiftLanguageSerializer_generateCode(t *testing.T) {
+	s := newSwiftLanguageSerializer()
+
+	testClass := &class{
+		name: "test",
+		dataMembers: []*dataMember{
+			{
+				memberType: "int",
+				name:       "a",
+			},
+		},
+	}
+
+	testEnum := &enum{
+		name: "testStatus",
+		enumValues: []*enumValue{
+			{
+				name:  "a",
+				value: 5,
+			},
+		},
+	}
+
+	generatedCode, err := s.generateCode([]middleware{testClass, testEnum}, &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	// The class, the enum and the coding helpers
+	if len(generatedCode) != 3 {
+		t.Errorf("generateCode() generated %v files. expected 3", len(generatedCode))
+		return
+	}
+
+	coding := generatedCode[2]
+	if coding.fileName != "ModelsCoding.swift" || !strings.Contains(coding.code, "decoder.dateDecodingStrategy = .custom") ||
+		!strings.Contains(coding.code, "enum Indirect<Value: Codable & Equatable>: Codable, Equatable {") {
+		t.Errorf("generateCode() coding helpers = %v", coding)
+	}
+}
+
+func Test_swiftLanguageSerializer_getType(t *testing.T) {
+	if got := newSwiftLanguageSerializer().getType(); got != LanguageTypeSwift {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeSwift)
+	}
+}
+
+func Test_swiftLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newSwiftLanguageSerializer().getTypeName(); got != "swift" {
+		t.Errorf("getTypeName() = %v, want %v", got, "swift")
+	}
+}
+
+func Test_swiftLanguageSerializer_serializeClass(t *testing.T) {
+	user := &class{
+		name: "user",
+		dataMembers: []*dataMember{
+			{memberType: "user", name: "parent"},
+			{memberType: "list<user>", name: "children"},
+		},
+	}
+
+	// Classes which contain each other
+	order := &class{name: "order", dataMembers: []*dataMember{{memberType: "invoice", name: "invoice"}}}
+	invoice := &class{name: "invoice", dataMembers: []*dataMember{{memberType: "order", name: "order"}}}
+
+	type args struct {
+		class          *class
+		serializerInfo *serializerInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Class with list and map serialize",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+						},
+						{
+							memberType: "list<other>",
+							name:       "others",
+						},
+						{
+							memberType: "map<string,int>",
+							name:       "counts",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Test.swift",
+				code: "import Foundation\n\n" +
+					"struct Test: Codable, Equatable {\n" +
+					"\tlet createdAt: Date\n" +
+					"\tlet others: [Other]\n" +
+					"\tlet counts: [String: Int]\n\n" +
+					"\tenum CodingKeys: String, CodingKey {\n" +
+					"\t\tcase createdAt\n" +
+					"\t\tcase others\n" +
+					"\t\tcase counts\n" +
+					"\t}\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty class",
+			args: args{
+				class:          &class{name: "test", dataMembers: []*dataMember{}},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Test.swift",
+				code:     "import Foundation\n\nstruct Test: Codable, Equatable {\n}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with language overrides and keywords",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "string",
+							name:       "ID",
+							annotations: []*annotation{
+								{namespace: "swift", name: "type", arguments: []string{"UUID"}},
+								{namespace: "swift", name: "name", arguments: []string{"identifier"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "class",
+						},
+					},
+					annotations: []*annotation{
+						{namespace: "swift", name: "name", arguments: []string{"Renamed"}},
+					},
+				},
+				serializerInfo: &serializerInfo{},
+			},
+			want: &generatedCode{
+				fileName: "Renamed.swift",
+				code: "import Foundation\n\n" +
+					"struct Renamed: Codable, Equatable {\n" +
+					"\tlet identifier: UUID\n" +
+					"\tlet `class`: String\n\n" +
+					"\tenum CodingKeys: String, CodingKey {\n" +
+					"\t\tcase identifier = \"iD\"\n" +
+					"\t\tcase `class`\n" +
+					"\t}\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+					},
+				},
+				serializerInfo: &serializerInfo{externTypes: map[string]*externType{
+					"Money": {
+						name:          "Money",
+						languageNames: map[languageType]string{LanguageTypeSwift: "MoneyKit.Money"},
+					},
+				}},
+			},
+			want: &generatedCode{
+				fileName: "Test.swift",
+				code: "import Foundation\nimport MoneyKit\n\n" +
+					"struct Test: Codable, Equatable {\n" +
+					"\tlet price: Money\n\n" +
+					"\tenum CodingKeys: String, CodingKey {\n" +
+					"\t\tcase price\n" +
+					"\t}\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class that contains itself",
+			args: args{
+				class:          user,
+				serializerInfo: &serializerInfo{classes: collectClasses([]middleware{user})},
+			},
+			want: &generatedCode{
+				fileName: "User.swift",
+				code: "import Foundation\n\n" +
+					"struct User: Codable, Equatable {\n" +
+					"\t@Indirect var parent: User\n" +
+					"\tlet children: [User]\n\n" +
+					"\tenum CodingKeys: String, CodingKey {\n" +
+					"\t\tcase parent\n" +
+					"\t\tcase children\n" +
+					"\t}\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Classes that contain each other",
+			args: args{
+				class:          invoice,
+				serializerInfo: &serializerInfo{classes: collectClasses([]middleware{order, invoice})},
+			},
+			want: &generatedCode{
+				fileName: "Invoice.swift",
+				code: "import Foundation\n\n" +
+					"struct Invoice: Codable, Equatable {\n" +
+					"\t@Indirect var order: Order\n\n" +
+					"\tenum CodingKeys: String, CodingKey {\n" +
+					"\t\tcase order\n" +
+					"\t}\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "map<kind,event>", name: "byKind"},
+					},
+				},
+				serializerInfo: getTestRenamedTypesInfo(),
+			},
+			want: &generatedCode{
+				fileName: "Holder.swift",
+				code: "import Foundation\n\n" +
+					"struct Holder: Codable, Equatable {\n" +
+					"\tlet e: Evt\n" +
+					"\tlet byKind: [Category: Evt]\n\n" +
+					"\tenum CodingKeys: String, CodingKey {\n" +
+					"\t\tcase e\n" +
+					"\t\tcase byKind\n" +
+					"\t}\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSwiftLanguageSerializer()
+			got, err := s.serializeClass(tt.args.class, tt.args.serializerInfo)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, s.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_swiftLanguageSerializer_serializeEnum(t *testing.T) {
+	type args struct {
+		enum *enum
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *generatedCode
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			args: args{
+				enum: &enum{
+					name: "orderStatus",
+					enumValues: []*enumValue{
+						{name: "active", value: 5},
+						{name: "default", value: 8},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "OrderStatus.swift",
+				code: "import Foundation\n\n" +
+					"enum OrderStatus: Int, Codable {\n" +
+					"\tcase active = 5\n" +
+					"\tcase `default` = 8\n" +
+					"}\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Empty enum",
+			args: args{enum: &enum{name: "test", enumValues: []*enumValue{}}},
+			want: &generatedCode{
+				fileName: "Test.swift",
+				code:     "import Foundation\n\nenum Test: Codable {\n}\n",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSwiftLanguageSerializer()
+			got, err := s.serializeEnum(tt.args.enum)
+
+			if got != nil {
+				// delete the header
+				got.code = strings.Replace(got.code, s.serializeDeclaration(), "", -1)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
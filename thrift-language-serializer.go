@@ -0,0 +1,333 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const maxThriftFieldNumber = 32767
+
+var thriftReservedNames = []string{
+	"binary", "bool", "byte", "const", "double", "enum", "exception", "extends", "include", "list", "map",
+	"namespace", "oneway", "optional", "required", "service", "set", "string", "struct", "throws", "typedef",
+	"union", "void",
+}
+
+/**
+Generate a Thrift IDL file with a struct for every class and an enum for every enum.
+The field numbers are kept stable with @field annotations and a lock file, like proto, see fieldNumbers.
+*/
+type thriftLanguageSerializer struct {
+	typesMap map[string]string
+}
+
+func newThriftLanguageSerializer() *thriftLanguageSerializer {
+	result := &thriftLanguageSerializer{typesMap: make(map[string]string, 0)}
+
+	result.typesMap["bool"] = "bool"
+	result.typesMap["int"] = "i32"
+	result.typesMap["string"] = "string"
+	result.typesMap["double"] = "double"
+	result.typesMap["float"] = "double"
+	result.typesMap["char"] = "string"
+	result.typesMap["byte"] = "i16"
+	result.typesMap["date"] = "i64"
+
+	return result
+}
+
+func (t *thriftLanguageSerializer) getType() languageType {
+	return LanguageTypeThrift
+}
+
+func (t *thriftLanguageSerializer) getTypeName() string {
+	return "thrift"
+}
+
+func (t *thriftLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	numbers, err := loadFieldNumbers(serializerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	definitions := make(map[string]middleware)
+
+	for _, object := range objects {
+		// Extern types are hand-written, and services and channels aren't generated for Thrift
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		names = append(names, middlewareName(object))
+		definitions[middlewareName(object)] = object
+	}
+
+	dependencies := make(map[string][]string)
+	for _, name := range names {
+		if class, ok := serializerInfo.classes[name]; ok {
+			dependencies[name] = t.classDependencies(class, definitions)
+		}
+	}
+
+	// Thrift types must be defined before they are used
+	sortedNames, err := sortByDependencies(names, dependencies)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("can't generate structs that use each other: %v", err))
+	}
+
+	includes := make([]string, 0)
+	serialized := make([]string, 0)
+
+	for _, name := range sortedNames {
+		switch o := definitions[name].(type) {
+		case *class:
+			code, classIncludes, err := t.serializeClass(o, serializerInfo, numbers)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, include := range classIncludes {
+				includes = appendUnique(includes, include)
+			}
+
+			serialized = append(serialized, code)
+		case *enum:
+			code, err := t.serializeEnum(o)
+			if err != nil {
+				return nil, err
+			}
+
+			serialized = append(serialized, code)
+		}
+	}
+
+	if err := numbers.save(); err != nil {
+		return nil, err
+	}
+
+	return []*generatedCode{newGeneratedCode("models.thrift",
+		t.serializeDeclaration(serializerInfo, includes)+strings.Join(serialized, "\n"))}, nil
+}
+
+/**
+Get the classes and enums a class uses, which must be defined before it.
+*/
+func (t *thriftLanguageSerializer) classDependencies(class *class, definitions map[string]middleware) []string {
+	result := make([]string, 0)
+
+	for _, member := range class.dataMembers {
+		if _, ok := findLanguageAnnotation(member.annotations, LanguageTypeThrift, "type"); ok {
+			continue
+		}
+
+		for _, typeName := range elementTypes(member.memberType) {
+			if _, ok := definitions[typeName]; ok {
+				result = appendUnique(result, typeName)
+			}
+		}
+	}
+
+	return result
+}
+
+func (t *thriftLanguageSerializer) serializeDeclaration(serializerInfo *serializerInfo, includes []string) string {
+	result := "// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+
+	if len(includes) > 0 {
+		sorted := append([]string{}, includes...)
+		sort.Strings(sorted)
+
+		for _, include := range sorted {
+			result += fmt.Sprintf("include \"%s\"\n", include)
+		}
+
+		result += "\n"
+	}
+
+	if serializerInfo.packageName != "" {
+		result += fmt.Sprintf("namespace * %s\n\n", serializerInfo.packageName)
+	}
+
+	return result
+}
+
+/**
+Map a gen file type which is a primitive or an extern type to a Thrift type.
+Return the include the type needs, or an empty string if it doesn't need one.
+*/
+func (t *thriftLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := t.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeThrift)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "acme/money.thrift#money.Money" is included from "acme/money.thrift" and used as "money.Money"
+	if file, structName, ok := strings.Cut(externName, "#"); ok {
+		return structName, file, true
+	}
+
+	return externName, "", true
+}
+
+/**
+Get the Thrift name of a class or an enum, taking the name annotation into account.
+*/
+func (t *thriftLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeThrift, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Serialize the Thrift type of a gen file type, with list<T> for lists and map<K,V> for maps.
+Return the includes the type needs.
+*/
+func (t *thriftLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemType, includes := t.typeName(listType, serializerInfo)
+
+		return fmt.Sprintf("list<%s>", itemType), includes
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType, includes := t.typeName(mapKeyType, serializerInfo)
+		valueType, valueIncludes := t.typeName(mapValueType, serializerInfo)
+
+		for _, include := range valueIncludes {
+			includes = appendUnique(includes, include)
+		}
+
+		return fmt.Sprintf("map<%s, %s>", keyType, valueType), includes
+	}
+
+	if knownType, include, isKnown := t.mapType(typeName, serializerInfo); isKnown {
+		return knownType, appendImport([]string{}, include)
+	}
+
+	return t.className(typeName, serializerInfo), []string{}
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+Thrift keywords get an underscore suffix, like "list_".
+*/
+func (t *thriftLanguageSerializer) fieldName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeThrift, "name"); ok {
+		return name
+	}
+
+	name := toCamelCase(member.name)
+	for _, reserved := range thriftReservedNames {
+		if name == reserved {
+			return name + "_"
+		}
+	}
+
+	return name
+}
+
+func (t *thriftLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo, numbers *fieldNumbers) (string, []string, error) {
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeThrift, "name"); ok {
+		className = name
+	}
+
+	memberNumbers, err := numbers.assign(class)
+	if err != nil {
+		return "", nil, err
+	}
+
+	includes := findLanguageImports(class.annotations, LanguageTypeThrift)
+	serializedCode := fmt.Sprintf("struct %s {\n", className)
+
+	for _, member := range class.dataMembers {
+		// Thrift field ids are 16 bit, which is less than proto allows
+		number := memberNumbers[member.name]
+		if number > maxThriftFieldNumber {
+			return "", nil, errors.New(fmt.Sprintf(
+				"data member %s of class %s has field number %v, but Thrift field numbers are up to %v",
+				member.name, class.name, number, maxThriftFieldNumber))
+		}
+
+		memberType, memberIncludes := t.typeName(member.memberType, serializerInfo)
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeThrift, "type"); ok {
+			memberType, memberIncludes = overrideType, []string{}
+		}
+
+		for _, include := range append(memberIncludes, findLanguageImports(member.annotations, LanguageTypeThrift)...) {
+			includes = appendUnique(includes, include)
+		}
+
+		requiredness := ""
+		if hasLanguageAnnotation(member.annotations, LanguageTypeThrift, "optional") {
+			requiredness = "optional "
+		}
+
+		serializedCode += fmt.Sprintf("  %v: %s%s %s,\n", number, requiredness, memberType, t.fieldName(member))
+	}
+
+	// Thrift has no reserved fields, so removed data members are listed to keep their numbers from being used again
+	reservedNumbers, reservedNames := numbers.reserved(class)
+	if len(reservedNumbers) > 0 {
+		removed := make([]string, 0)
+		for i := range reservedNumbers {
+			removed = append(removed, fmt.Sprintf("%v (%s)", reservedNumbers[i], reservedNames[i]))
+		}
+
+		if len(class.dataMembers) > 0 {
+			serializedCode += "\n"
+		}
+
+		serializedCode += fmt.Sprintf("  // Removed, don't reuse: %s\n", strings.Join(removed, ", "))
+	}
+
+	return serializedCode + "}\n", includes, nil
+}
+
+/**
+Serialize an enum. Thrift enum values are scoped by the enum, so they aren't prefixed.
+*/
+func (t *thriftLanguageSerializer) serializeEnum(enum *enum) (string, error) {
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeThrift, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := fmt.Sprintf("enum %s {\n", enumName)
+	values := make(map[int]string)
+
+	for _, value := range enum.enumValues {
+		if other, exists := values[value.value]; exists {
+			return "", errors.New(fmt.Sprintf("values %s and %s of enum %s are both %v, but Thrift enum values must be unique",
+				other, value.name, enum.name, value.value))
+		}
+
+		values[value.value] = value.name
+
+		valueName := strings.ToUpper(toSnakeCase(value.name))
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeThrift, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("  %s = %v,\n", valueName, value.value)
+	}
+
+	return serializedCode + "}\n", nil
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_thriftLanguageSerializer_getType(t *testing.T) {
+	if got := newThriftLanguageSerializer().getType(); got != LanguageTypeThrift {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeThrift)
+	}
+}
+
+func Test_thriftLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newThriftLanguageSerializer().getTypeName(); got != "thrift" {
+		t.Errorf("getTypeName() = %v, want %v", got, "thrift")
+	}
+}
+
+func Test_thriftLanguageSerializer_generateCode(t *testing.T) {
+	s := newThriftLanguageSerializer()
+	lock := filepath.Join(t.TempDir(), "fields.lock")
+
+	money := newExternType("money")
+	_ = money.addValue("thrift", "acme/money.thrift#money.Money", nil)
+
+	testClass := &class{
+		name: "order",
+		dataMembers: []*dataMember{
+			{memberType: "int", name: "id"},
+			{memberType: "date", name: "createdAt"},
+			{memberType: "money", name: "price"},
+			{memberType: "orderStatus", name: "status"},
+		},
+	}
+
+	testEnum := &enum{
+		name:       "orderStatus",
+		enumValues: []*enumValue{{name: "active", value: 1}},
+	}
+
+	testService, _ := getTestService()
+	info := &serializerInfo{packageName: "shop", options: map[string]string{"lock": lock}}
+	generatedCode, err := s.generateCode([]middleware{money, testClass, testEnum, testService}, info)
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	if len(generatedCode) != 1 || generatedCode[0].fileName != "models.thrift" {
+		t.Errorf("generateCode() should generate only models.thrift")
+		return
+	}
+
+	// Enums are defined before the structs that use them
+	want := "include \"acme/money.thrift\"\n\n" +
+		"namespace * shop\n\n" +
+		"enum OrderStatus {\n" +
+		"  ACTIVE = 1,\n" +
+		"}\n\n" +
+		"struct Order {\n" +
+		"  1: i32 id,\n" +
+		"  2: i64 createdAt,\n" +
+		"  3: money.Money price,\n" +
+		"  4: OrderStatus status,\n" +
+		"}\n"
+
+	if !strings.HasSuffix(generatedCode[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", generatedCode[0].code, want)
+	}
+
+	// The next generation reads the lock, so reordered and removed data members keep their numbers
+	testClass.dataMembers = []*dataMember{
+		{memberType: "money", name: "price"},
+		{memberType: "int", name: "id"},
+		{memberType: "string", name: "note"},
+	}
+
+	generatedCode, err = s.generateCode([]middleware{money, testClass}, info)
+	if err != nil {
+		t.Errorf("generateCode() error = %v, wantErr %v", err, false)
+		return
+	}
+
+	want = "struct Order {\n" +
+		"  3: money.Money price,\n" +
+		"  1: i32 id,\n" +
+		"  5: string note,\n\n" +
+		"  // Removed, don't reuse: 2 (createdAt), 4 (status)\n" +
+		"}\n"
+
+	if !strings.HasSuffix(generatedCode[0].code, want) {
+		t.Errorf("generateCode() got = %v, want %v", generatedCode[0].code, want)
+	}
+
+	// Structs that use each other can't be defined one before the other
+	first := &class{name: "first", dataMembers: []*dataMember{{memberType: "second", name: "second"}}}
+	second := &class{name: "second", dataMembers: []*dataMember{{memberType: "first", name: "first"}}}
+	if _, err := s.generateCode([]middleware{first, second}, info); err == nil {
+		t.Errorf("generateCode() expected an error for structs that use each other")
+	}
+}
+
+func Test_thriftLanguageSerializer_serializeClass(t *testing.T) {
+	tests := []struct {
+		name           string
+		class          *class
+		serializerInfo *serializerInfo
+		locked         map[string]int
+		want           string
+		wantIncludes   []string
+		wantErr        bool
+	}{
+		{
+			name: "Class serialize",
+			class: &class{
+				name: "orderItem",
+				dataMembers: []*dataMember{
+					{memberType: "int", name: "orderId"},
+					{memberType: "list<product>", name: "products"},
+					{memberType: "map<string,double>", name: "prices"},
+					{memberType: "byte", name: "count"},
+					{memberType: "string", name: "note", annotations: []*annotation{{namespace: "thrift", name: "optional"}}},
+				},
+			},
+			want: "struct OrderItem {\n" +
+				"  1: i32 orderId,\n" +
+				"  2: list<Product> products,\n" +
+				"  3: map<string, double> prices,\n" +
+				"  4: i16 count,\n" +
+				"  5: optional string note,\n" +
+				"}\n",
+			wantIncludes: []string{},
+		},
+		{
+			name: "Class with explicit field numbers and reserved names",
+			class: &class{
+				name: "user",
+				dataMembers: []*dataMember{
+					{memberType: "string", name: "list"},
+					{memberType: "int", name: "id", annotations: fieldAnnotation("1")},
+				},
+			},
+			want: "struct User {\n" +
+				"  2: string list_,\n" +
+				"  1: i32 id,\n" +
+				"}\n",
+			wantIncludes: []string{},
+		},
+		{
+			name: "Class with removed members",
+			class: &class{
+				name:        "user",
+				dataMembers: []*dataMember{{memberType: "int", name: "id"}},
+			},
+			locked: map[string]int{"zeta": 1, "alpha": 2, "id": 3},
+			want: "struct User {\n" +
+				"  3: i32 id,\n\n" +
+				"  // Removed, don't reuse: 1 (zeta), 2 (alpha)\n" +
+				"}\n",
+			wantIncludes: []string{},
+		},
+		{
+			name: "Class with language overrides",
+			class: &class{
+				name:        "event",
+				annotations: []*annotation{{namespace: "thrift", name: "name", arguments: []string{"UserEvent"}}},
+				dataMembers: []*dataMember{
+					{
+						memberType: "string",
+						name:       "payload",
+						annotations: []*annotation{
+							{namespace: "thrift", name: "type", arguments: []string{"common.Payload"}},
+							{namespace: "thrift", name: "import", arguments: []string{"common.thrift"}},
+							{namespace: "thrift", name: "name", arguments: []string{"body"}},
+						},
+					},
+				},
+			},
+			want: "struct UserEvent {\n" +
+				"  1: common.Payload body,\n" +
+				"}\n",
+			wantIncludes: []string{"common.thrift"},
+		},
+		{
+			name: "Class with renamed types",
+			class: &class{
+				name: "holder",
+				dataMembers: []*dataMember{
+					{memberType: "event", name: "e"},
+					{memberType: "kind", name: "kind"},
+				},
+			},
+			serializerInfo: getTestRenamedTypesInfo(),
+			want: "struct Holder {\n" +
+				"  1: Evt e,\n" +
+				"  2: Category kind,\n" +
+				"}\n",
+			wantIncludes: []string{},
+		},
+		{
+			name: "Field number out of the Thrift range",
+			class: &class{
+				name:        "prices",
+				dataMembers: []*dataMember{{memberType: "string", name: "names", annotations: fieldAnnotation("40000")}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newThriftLanguageSerializer()
+			numbers := &fieldNumbers{classes: make(map[string]map[string]int)}
+			if tt.locked != nil {
+				numbers.classes[tt.class.name] = tt.locked
+			}
+
+			info := tt.serializerInfo
+			if info == nil {
+				info = &serializerInfo{}
+			}
+
+			got, includes, err := s.serializeClass(tt.class, info, numbers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeClass() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+			if strings.Join(includes, ",") != strings.Join(tt.wantIncludes, ",") {
+				t.Errorf("serializeClass() includes = %v, want %v", includes, tt.wantIncludes)
+			}
+		})
+	}
+}
+
+func Test_thriftLanguageSerializer_serializeEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		enum    *enum
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Valid enum generator",
+			enum: &enum{
+				name: "orderStatus",
+				enumValues: []*enumValue{
+					{name: "active", value: 1},
+					{name: "cancelledByUser", value: 2},
+					{name: "none", value: 0, annotations: []*annotation{{namespace: "thrift", name: "name", arguments: []string{"UNKNOWN"}}}},
+				},
+			},
+			want: "enum OrderStatus {\n" +
+				"  ACTIVE = 1,\n" +
+				"  CANCELLED_BY_USER = 2,\n" +
+				"  UNKNOWN = 0,\n" +
+				"}\n",
+		},
+		{
+			name: "Duplicate values",
+			enum: &enum{
+				name: "test",
+				enumValues: []*enumValue{
+					{name: "a", value: 1},
+					{name: "b", value: 1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newThriftLanguageSerializer().serializeEnum(tt.enum)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("serializeEnum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("serializeEnum() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
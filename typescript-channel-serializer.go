@@ -0,0 +1,61 @@
+package main
+
+import "fmt"
+
+/**
+Serialize all the channels to a single file with the topic constants, and typed publisher
+and subscriber interfaces for every channel. Channels with a key also get a function which
+extracts the key from the message.
+*/
+func (t *typescriptLanguageSerializer) serializeChannels(channels []*channel, serializerInfo *serializerInfo) (*generatedCode, error) {
+	imports := make([]string, 0)
+	constantsCode := ""
+	channelsCode := ""
+
+	for _, c := range channels {
+		typeName := topicTypeName(c.topic)
+		payloadName := t.className(c.payloadType, serializerInfo)
+		imports = appendUnique(imports, c.payloadType)
+
+		constantsCode += fmt.Sprintf("export const %s_TOPIC = \"%s\";\n", topicConstantName(c.topic), c.topic)
+
+		parameters := fmt.Sprintf("message: %s", payloadName)
+
+		if len(c.headers) > 0 {
+			parameters += fmt.Sprintf(", headers: %sHeaders", typeName)
+			channelsCode += fmt.Sprintf("\nexport interface %sHeaders {\n", typeName)
+
+			for _, header := range c.headers {
+				headerType := header.headerType
+				if knownType, imp, isKnown := t.mapType(header.headerType, serializerInfo); isKnown {
+					headerType = knownType
+					imports = appendImport(imports, imp)
+				}
+
+				channelsCode += fmt.Sprintf("\t%s: %s;\n", toCamelCase(header.name), headerType)
+			}
+
+			channelsCode += "}\n"
+		}
+
+		channelsCode += fmt.Sprintf("\nexport interface %sPublisher {\n\tpublish%s(%s): Promise<void>;\n}\n",
+			typeName, typeName, parameters)
+		channelsCode += fmt.Sprintf("\nexport interface %sSubscriber {\n\thandle%s(%s): Promise<void>;\n}\n",
+			typeName, typeName, parameters)
+
+		if c.keyField != "" {
+			memberName := toCamelCase(c.keyField)
+			if member := findDataMember(serializerInfo.classes[c.payloadType], c.keyField); member != nil {
+				memberName = t.memberName(member)
+			}
+
+			channelsCode += fmt.Sprintf("\nexport function %sKey(message: %s): string {\n\treturn String(message.%s);\n}\n",
+				toCamelCase(typeName), payloadName, memberName)
+		}
+	}
+
+	// Delete the last new line
+	channelsCode = channelsCode[:len(channelsCode)-1]
+
+	return newGeneratedCode("channels.ts", t.serializeDeclaration(imports)+constantsCode+channelsCode), nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_typescriptLanguageSerializer_serializeChannels(t *testing.T) {
+	ts := newTypescriptLanguageSerializer()
+	channels, info := getTestChannels()
+
+	got, err := ts.serializeChannels(channels, info)
+	if err != nil {
+		t.Errorf("serializeChannels() error = %v", err)
+		return
+	}
+
+	if got.fileName != "channels.ts" {
+		t.Errorf("serializeChannels() fileName = %v, want %v", got.fileName, "channels.ts")
+	}
+
+	expectedParts := []string{
+		"import { OrderCreated } from \"./orderCreated\";",
+		"export const ORDERS_CREATED_TOPIC = \"orders.created\";",
+		"export interface OrdersCreatedHeaders {\n\ttraceId: string;\n}",
+		"publishOrdersCreated(message: OrderCreated, headers: OrdersCreatedHeaders): Promise<void>;",
+		"handleOrdersClosed(message: OrderCreated): Promise<void>;",
+		"export function ordersCreatedKey(message: OrderCreated): string {\n\treturn String(message.orderId);\n}",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeChannels() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+}
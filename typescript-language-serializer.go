@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -36,8 +37,27 @@ func (t *typescriptLanguageSerializer) getTypeName() string {
 func (t *typescriptLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
 	result := make([]*generatedCode, 0)
 
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
 	for _, object := range objects {
-		serialized, err := t.serializeMiddleware(object)
+		// Extern types are hand-written, so we only reference them.
+		// Channels are serialized together in the end
+		if object.getType() == middlewareTypeExtern || object.getType() == middlewareTypeChannel {
+			continue
+		}
+
+		serialized, err := t.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	if channels := collectChannels(objects); len(channels) > 0 {
+		serialized, err := t.serializeChannels(channels, serializerInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -48,15 +68,19 @@ func (t *typescriptLanguageSerializer) generateCode(objects []middleware, serial
 	return result, nil
 }
 
-func (t *typescriptLanguageSerializer) serializeMiddleware(middleware middleware) (*generatedCode, error) {
+func (t *typescriptLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
 	if class, ok := middleware.(*class); ok {
-		return t.serializeClass(class)
+		return t.serializeClass(class, serializerInfo)
 	}
 
 	if enum, ok := middleware.(*enum); ok {
 		return t.serializeEnum(enum)
 	}
 
+	if service, ok := middleware.(*service); ok {
+		return t.serializeService(service, serializerInfo)
+	}
+
 	return nil, errors.New("tried to serialize unknown middleware type")
 }
 
@@ -73,6 +97,12 @@ func (t *typescriptLanguageSerializer) serializeDeclaration(imports []string) st
 
 	result := ""
 	for _, imp := range imports {
+		// Imports written like "module#Symbol" come from annotations and point to another module
+		if module, symbol, ok := strings.Cut(imp, "#"); ok {
+			result += fmt.Sprintf("import { %s } from \"%s\";\n", symbol, module)
+			continue
+		}
+
 		result += fmt.Sprintf("import { %s } from \"./%s\";\n",
 			toFirstCharUpper(imp), toCamelCase(imp))
 	}
@@ -80,61 +110,135 @@ func (t *typescriptLanguageSerializer) serializeDeclaration(imports []string) st
 	return result + generatedMark
 }
 
-func (t *typescriptLanguageSerializer) serializeClass(class *class) (*generatedCode, error) {
+/**
+Map a gen file type which is a primitive or an extern type to a Typescript type.
+Return the import the type needs, or an empty string if it doesn't need one.
+*/
+func (t *typescriptLanguageSerializer) mapType(typeName string, serializerInfo *serializerInfo) (string, string, bool) {
+	if primitiveType, isPrimitive := t.typesMap[typeName]; isPrimitive {
+		return primitiveType, "", true
+	}
+
+	externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeTypescript)
+	if !isExtern {
+		return "", "", false
+	}
+
+	// "@acme/money#Money" is imported from "@acme/money" and used as "Money"
+	if _, symbol, ok := strings.Cut(externName, "#"); ok {
+		return symbol, externName, true
+	}
+
+	return externName, "", true
+}
+
+/**
+Get the Typescript name of a class or an enum, taking the name annotation into account.
+*/
+func (t *typescriptLanguageSerializer) className(className string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(className, LanguageTypeTypescript, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(className)
+}
+
+/**
+Get the import of a class or an enum a data member uses, like "./orderItem#OrderItem",
+so renamed types are imported by their Typescript names.
+*/
+func (t *typescriptLanguageSerializer) localImport(typeName string, serializerInfo *serializerInfo) string {
+	return fmt.Sprintf("./%s#%s", toCamelCase(typeName), t.className(typeName, serializerInfo))
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+*/
+func (t *typescriptLanguageSerializer) memberName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeTypescript, "name"); ok {
+		return name
+	}
+
+	return toCamelCase(member.name)
+}
+
+func (t *typescriptLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
 	serializedCode := ""
 	fileName := fmt.Sprintf("%s.ts", toCamelCase(class.name))
 
-	serializedCode += fmt.Sprintf("export class %s {\n", toFirstCharUpper(class.name))
+	className := toFirstCharUpper(class.name)
+	if name, ok := findLanguageAnnotation(class.annotations, LanguageTypeTypescript, "name"); ok {
+		className = name
+	}
+
+	serializedCode += fmt.Sprintf("export class %s {\n", className)
 
-	imports := make([]string, 0)
+	imports := findLanguageImports(class.annotations, LanguageTypeTypescript)
 
 	for _, member := range class.dataMembers {
+		memberName := t.memberName(member)
+
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeTypescript) {
+			imports = appendUnique(imports, imp)
+		}
+
+		if overrideType, ok := findLanguageAnnotation(member.annotations, LanguageTypeTypescript, "type"); ok {
+			serializedCode += fmt.Sprintf("\t%s: %s;\n", memberName, overrideType)
+
+			continue
+		}
+
 		if isList, listType := isList(member.memberType); isList {
-			if tsType, isPrimitive := t.typesMap[listType]; isPrimitive {
-				listType = tsType
+			if knownType, imp, isKnown := t.mapType(listType, serializerInfo); isKnown {
+				listType = knownType
+				imports = appendImport(imports, imp)
 			} else {
-				imports = appendUnique(imports, listType)
-				listType = toFirstCharUpper(listType)
+				imports = appendUnique(imports, t.localImport(listType, serializerInfo))
+				listType = t.className(listType, serializerInfo)
 			}
 
-			serializedCode += fmt.Sprintf("\t%s: %s[];\n", toCamelCase(member.name), listType)
+			serializedCode += fmt.Sprintf("\t%s: %s[];\n", memberName, listType)
 
 			continue
 		}
 
 		if isMap, mapKeyType, mapValueType := isMap(member.memberType); isMap {
-			if keyPrimitiveType, isPrimitive := t.typesMap[mapKeyType]; isPrimitive {
-				mapKeyType = keyPrimitiveType
+			if knownType, imp, isKnown := t.mapType(mapKeyType, serializerInfo); isKnown {
+				mapKeyType = knownType
+				imports = appendImport(imports, imp)
 			} else {
 				// Not suppose to happen, but it's user's problem
-				mapKeyType = toFirstCharUpper(mapKeyType)
+				imports = appendUnique(imports, t.localImport(mapKeyType, serializerInfo))
+				mapKeyType = t.className(mapKeyType, serializerInfo)
 			}
 
-			if valuePrimitiveType, isPrimitive := t.typesMap[mapValueType]; isPrimitive {
-				mapValueType = valuePrimitiveType
+			if knownType, imp, isKnown := t.mapType(mapValueType, serializerInfo); isKnown {
+				mapValueType = knownType
+				imports = appendImport(imports, imp)
 			} else {
-				imports = appendUnique(imports, mapValueType)
-				mapValueType = toFirstCharUpper(mapValueType)
+				imports = appendUnique(imports, t.localImport(mapValueType, serializerInfo))
+				mapValueType = t.className(mapValueType, serializerInfo)
 			}
 
 			serializedCode += fmt.Sprintf("\t%s: Map<%s, %s>;\n",
-				toCamelCase(member.name), mapKeyType, mapValueType)
+				memberName, mapKeyType, mapValueType)
 
 			continue
 		}
 
-		if primitiveType, ok := t.typesMap[member.memberType]; ok {
+		if knownType, imp, isKnown := t.mapType(member.memberType, serializerInfo); isKnown {
+			imports = appendImport(imports, imp)
 			serializedCode += fmt.Sprintf("\t%s: %s;\n",
-				toCamelCase(member.name), primitiveType)
+				memberName, knownType)
 
 			continue
 		}
 
 		// Normal but not primitive member
 		serializedCode += fmt.Sprintf("\t%s: %s;\n",
-			toCamelCase(member.name), toFirstCharUpper(member.memberType))
+			memberName, t.className(member.memberType, serializerInfo))
 
-		imports = appendUnique(imports, member.memberType)
+		imports = appendUnique(imports, t.localImport(member.memberType, serializerInfo))
 	}
 
 	serializedCode += "}"
@@ -146,11 +250,20 @@ func (t *typescriptLanguageSerializer) serializeEnum(enum *enum) (*generatedCode
 	serializedCode := t.serializeDeclaration([]string{})
 	fileName := fmt.Sprintf("%s.ts", enum.name)
 
-	serializedCode += fmt.Sprintf("export enum %s {\n", toFirstCharUpper(enum.name))
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeTypescript, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode += fmt.Sprintf("export enum %s {\n", enumName)
 
 	for _, value := range enum.enumValues {
-		serializedCode += fmt.Sprintf("\t%s = %v,\n",
-			toFirstCharUpper(value.name), value.value)
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeTypescript, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("\t%s = %v,\n", valueName, value.value)
 	}
 
 	if len(enum.enumValues) > 1 {
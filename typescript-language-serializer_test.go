@@ -224,6 +224,67 @@ func Test_typescriptLanguageSerializer_serializeClass(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Class with language overrides",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "date",
+							name:       "createdAt",
+							annotations: []*annotation{
+								{namespace: "go", name: "type", arguments: []string{"time.Time"}},
+								{namespace: "go", name: "import", arguments: []string{"time"}},
+								{namespace: "kotlin", name: "type", arguments: []string{"Instant"}},
+								{namespace: "kotlin", name: "import", arguments: []string{"java.time.Instant"}},
+								{namespace: "ts", name: "type", arguments: []string{"string"}},
+								{namespace: "csharp", name: "type", arguments: []string{"DateTimeOffset"}},
+								{namespace: "csharp", name: "import", arguments: []string{"System"}},
+							},
+						},
+						{
+							memberType: "string",
+							name:       "id",
+							annotations: []*annotation{
+								{namespace: "go", name: "name", arguments: []string{"ID"}},
+								{namespace: "csharp", name: "name", arguments: []string{"ID"}},
+							},
+						},
+					},
+				},
+				imports: []string{},
+			},
+			want: &generatedCode{
+				fileName: "test.ts",
+				code:     "export class Test {\n\tcreatedAt: string;\n\tid: string;\n}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Class with extern type",
+			args: args{
+				class: &class{
+					name: "test",
+					dataMembers: []*dataMember{
+						{
+							memberType: "Money",
+							name:       "price",
+						},
+						{
+							memberType: "list<Money>",
+							name:       "history",
+						},
+					},
+				},
+				imports: []string{"@acme/money#Money"},
+			},
+			want: &generatedCode{
+				fileName: "test.ts",
+				code:     "export class Test {\n\tprice: Money;\n\thistory: Money[];\n}",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Class with struct",
 			args: args{
@@ -248,11 +309,33 @@ func Test_typescriptLanguageSerializer_serializeClass(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Class with renamed types",
+			args: args{
+				class: &class{
+					name: "holder",
+					dataMembers: []*dataMember{
+						{memberType: "event", name: "e"},
+						{memberType: "list<event>", name: "es"},
+						{memberType: "map<kind,event>", name: "byKind"},
+					},
+				},
+				imports: []string{"./event#Evt", "./kind#Category"},
+			},
+			want: &generatedCode{
+				fileName: "holder.ts",
+				code:     "export class Holder {\n\te: Evt;\n\tes: Evt[];\n\tbyKind: Map<Category, Evt>;\n}",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			info := getTestRenamedTypesInfo()
+			info.externTypes = getTestExternTypes()
+
 			g := newTypescriptLanguageSerializer()
-			got, err := g.serializeClass(tt.args.class)
+			got, err := g.serializeClass(tt.args.class, info)
 
 			if got != nil {
 				// delete the header
@@ -444,7 +527,7 @@ func Test_typescriptLanguageSerializer_serializeMiddleware(t *testing.T) {
 			},
 		},
 	}
-	classCode, _ := g.serializeClass(testClass)
+	classCode, _ := g.serializeClass(testClass, &serializerInfo{})
 
 	testEnum := &enum{
 		name: "test",
@@ -492,7 +575,7 @@ func Test_typescriptLanguageSerializer_serializeMiddleware(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := g.serializeMiddleware(tt.args.middleware)
+			got, err := g.serializeMiddleware(tt.args.middleware, &serializerInfo{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("serializeMiddleware() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+Serialize a service to a Typescript interface, a typed error and a client based on fetch.
+The request and response types are imported from the generated classes.
+*/
+func (t *typescriptLanguageSerializer) serializeService(service *service, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.ts", toCamelCase(service.name))
+	serviceName := toFirstCharUpper(service.name)
+
+	imports := make([]string, 0)
+	interfaceCode := fmt.Sprintf("export interface %s {\n", serviceName)
+	clientCode := ""
+
+	for _, method := range service.methods {
+		signature := t.serializeMethodSignature(method, serializerInfo)
+		interfaceCode += fmt.Sprintf("\t%s;\n", signature)
+
+		if method.requestType != "" {
+			imports = appendUnique(imports, method.requestType)
+		}
+
+		imports = appendUnique(imports, method.responseType)
+
+		body := "undefined"
+		if hasRequestBody(method) {
+			body = "request"
+		}
+
+		clientCode += fmt.Sprintf("\n\t%s {\n\t\treturn this.send<%s>(\"%s\", %s, %s);\n\t}\n", signature,
+			t.className(method.responseType, serializerInfo), method.httpMethod,
+			t.serializePath(method, serializerInfo), body)
+	}
+
+	interfaceCode += "}\n\n"
+
+	serializedCode := t.serializeDeclaration(imports) + interfaceCode +
+		fmt.Sprintf(typescriptServiceErrorTemplate, serviceName) +
+		fmt.Sprintf(typescriptServiceClientTemplate, serviceName, clientCode)
+
+	return newGeneratedCode(fileName, serializedCode), nil
+}
+
+/**
+Serialize the method signature, like: getUser(request: GetUserRequest): Promise<GetUserResponse>
+*/
+func (t *typescriptLanguageSerializer) serializeMethodSignature(method *serviceMethod, serializerInfo *serializerInfo) string {
+	parameters := ""
+	if method.requestType != "" {
+		parameters = fmt.Sprintf("request: %s", t.className(method.requestType, serializerInfo))
+	}
+
+	return fmt.Sprintf("%s(%s): Promise<%s>", toCamelCase(method.name), parameters,
+		t.className(method.responseType, serializerInfo))
+}
+
+/**
+Serialize the method path to a template literal. Path parameters are taken from the request,
+and the query parameters are encoded with URLSearchParams.
+*/
+func (t *typescriptLanguageSerializer) serializePath(method *serviceMethod, serializerInfo *serializerInfo) string {
+	result := "`"
+
+	for _, part := range splitPath(method.path) {
+		if !part.isParameter {
+			result += strings.Replace(part.value, "`", "\\`", -1)
+			continue
+		}
+
+		memberName := toCamelCase(part.value)
+		if member := findDataMember(serializerInfo.classes[method.requestType], part.value); member != nil {
+			memberName = t.memberName(member)
+		}
+
+		result += fmt.Sprintf("${encodeURIComponent(String(request.%s))}", memberName)
+	}
+
+	parameters := make([]string, 0)
+	for _, member := range queryMembers(method, serializerInfo.classes) {
+		parameters = append(parameters, fmt.Sprintf("\"%s\": String(request.%s)",
+			toCamelCase(member.name), t.memberName(member)))
+	}
+
+	if len(parameters) > 0 {
+		result += fmt.Sprintf("?${new URLSearchParams({ %s })}", strings.Join(parameters, ", "))
+	}
+
+	return result + "`"
+}
+
+const typescriptServiceErrorTemplate = `export class %[1]sError extends Error {
+	constructor(readonly status: number, message: string) {
+		super(message);
+	}
+}
+
+`
+
+const typescriptServiceClientTemplate = `export class %[1]sClient implements %[1]s {
+	constructor(private readonly baseUrl: string, private readonly fetchFn: typeof fetch = (input, init) => fetch(input, init)) {
+	}
+%[2]s
+	private async send<T>(method: string, path: string, body: unknown): Promise<T> {
+		const response = await this.fetchFn(this.baseUrl + path, {
+			method,
+			headers: { "Content-Type": "application/json", "Accept": "application/json" },
+			body: body === undefined ? undefined : JSON.stringify(body),
+		});
+
+		if (!response.ok) {
+			throw new %[1]sError(response.status, await response.text());
+		}
+
+		return await response.json() as T;
+	}
+}`
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_typescriptLanguageSerializer_serializeService(t *testing.T) {
+	g := newTypescriptLanguageSerializer()
+	userService, info := getTestService()
+
+	got, err := g.serializeService(userService, info)
+	if err != nil {
+		t.Errorf("serializeService() error = %v", err)
+		return
+	}
+
+	if got.fileName != "userService.ts" {
+		t.Errorf("serializeService() fileName = %v, want %v", got.fileName, "userService.ts")
+	}
+
+	expectedParts := []string{
+		"import { GetUserRequest } from \"./getUserRequest\";\n" +
+			"import { User } from \"./user\";\n" +
+			"import { CreateUserRequest } from \"./createUserRequest\";\n",
+		"export interface UserService {\n" +
+			"\tgetUser(request: GetUserRequest): Promise<User>;\n" +
+			"\tcreateUser(request: CreateUserRequest): Promise<User>;\n" +
+			"\tping(): Promise<User>;\n}",
+		"export class UserServiceError extends Error {",
+		"export class UserServiceClient implements UserService {",
+		"return this.send<User>(\"GET\", `/users/${encodeURIComponent(String(request.id))}" +
+			"?${new URLSearchParams({ \"verbose\": String(request.verbose) })}`, undefined);",
+		"return this.send<User>(\"POST\", `/users`, request);",
+		"private async send<T>(method: string, path: string, body: unknown): Promise<T> {",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(got.code, part) {
+			t.Errorf("serializeService() code doesn't contain %v.\ncode: %v", part, got.code)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var genPrimitiveTypes = []string{"bool", "int", "string", "double", "float", "char", "byte", "date"}
+
+/**
+Validate the parsed middlewares as a whole.
+Every type name must be declared once, and every type a data member uses must be
+a primitive, a list or a map of known types, or a declared class, enum or extern type.
+Services must use declared classes for their requests and responses, and channels for their payloads.
+*/
+func validateMiddlewares(objects []middleware) error {
+	declaredTypes := make(map[string]bool)
+	declaredTopics := make(map[string]bool)
+
+	for _, object := range objects {
+		if c, ok := object.(*channel); ok {
+			if declaredTopics[c.topic] {
+				return errors.New(fmt.Sprintf("channel %s is declared more than once", c.topic))
+			}
+
+			declaredTopics[c.topic] = true
+			continue
+		}
+
+		name := middlewareName(object)
+		if declaredTypes[name] {
+			return errors.New(fmt.Sprintf("type %s is declared more than once", name))
+		}
+
+		declaredTypes[name] = true
+	}
+
+	classes := collectClasses(objects)
+
+	for _, object := range objects {
+		if s, ok := object.(*service); ok {
+			if err := validateService(s, classes); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if c, ok := object.(*channel); ok {
+			if err := validateChannel(c, classes); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		class, ok := object.(*class)
+		if !ok {
+			continue
+		}
+
+		for _, member := range class.dataMembers {
+			for _, typeName := range memberReferencedTypes(member.memberType) {
+				if !isResolvedType(typeName, declaredTypes) {
+					return errors.New(fmt.Sprintf(
+						"data member %s of class %s uses unknown type %s", member.name, class.name, typeName))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+/**
+Validate the service methods use declared classes as request and response,
+every path parameter is a data member of the request, and the path and query parameters are primitives
+which can be written as text - so dates, lists, maps, classes, enums and extern types aren't allowed.
+*/
+func validateService(s *service, classes map[string]*class) error {
+	for _, method := range s.methods {
+		if _, ok := classes[method.responseType]; !ok {
+			return errors.New(fmt.Sprintf(
+				"method %s of service %s returns %s, which isn't a declared class", method.name, s.name, method.responseType))
+		}
+
+		if method.requestType != "" {
+			if _, ok := classes[method.requestType]; !ok {
+				return errors.New(fmt.Sprintf(
+					"method %s of service %s gets %s, which isn't a declared class", method.name, s.name, method.requestType))
+			}
+		}
+
+		for _, parameter := range pathParameters(method.path) {
+			member := findDataMember(classes[method.requestType], parameter)
+			if member == nil {
+				return errors.New(fmt.Sprintf(
+					"path parameter %s of method %s in service %s isn't a data member of the request",
+					parameter, method.name, s.name))
+			}
+
+			if !isTextType(member.memberType) {
+				return errors.New(fmt.Sprintf(
+					"path parameter %s of method %s in service %s should be a primitive which isn't a date, but got %s",
+					parameter, method.name, s.name, member.memberType))
+			}
+		}
+
+		for _, member := range queryMembers(method, classes) {
+			if !isTextType(member.memberType) {
+				return errors.New(fmt.Sprintf(
+					"data member %s of method %s in service %s is sent as a query parameter, "+
+						"so it should be a primitive which isn't a date, but got %s",
+					member.name, method.name, s.name, member.memberType))
+			}
+		}
+	}
+
+	return nil
+}
+
+/**
+Check a type is a primitive which can be written as text in a path or a query parameter.
+*/
+func isTextType(typeName string) bool {
+	return typeName != "date" && isResolvedType(typeName, map[string]bool{})
+}
+
+/**
+Validate the channel payload is a declared class, the key is one of its data members,
+and the headers are primitives.
+*/
+func validateChannel(c *channel, classes map[string]*class) error {
+	payload, ok := classes[c.payloadType]
+	if !ok {
+		return errors.New(fmt.Sprintf(
+			"channel %s carries %s, which isn't a declared class", c.topic, c.payloadType))
+	}
+
+	if c.keyField != "" && findDataMember(payload, c.keyField) == nil {
+		return errors.New(fmt.Sprintf(
+			"key %s of channel %s isn't a data member of %s", c.keyField, c.topic, c.payloadType))
+	}
+
+	for _, header := range c.headers {
+		if !isResolvedType(header.headerType, map[string]bool{}) {
+			return errors.New(fmt.Sprintf(
+				"header %s of channel %s should be a primitive, but got %s", header.name, c.topic, header.headerType))
+		}
+	}
+
+	return nil
+}
+
+func middlewareName(object middleware) string {
+	switch m := object.(type) {
+	case *class:
+		return m.name
+	case *enum:
+		return m.name
+	case *externType:
+		return m.name
+	case *service:
+		return m.name
+	}
+
+	return ""
+}
+
+/**
+Get the types a data member type is built from.
+For lists it's the list type, and for maps both the key and the value types.
+*/
+func memberReferencedTypes(memberType string) []string {
+	if isList, listType := isList(memberType); isList {
+		return []string{listType}
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(memberType); isMap {
+		return []string{mapKeyType, mapValueType}
+	}
+
+	return []string{memberType}
+}
+
+func isResolvedType(typeName string, declaredTypes map[string]bool) bool {
+	for _, primitive := range genPrimitiveTypes {
+		if primitive == typeName {
+			return true
+		}
+	}
+
+	return declaredTypes[typeName]
+}
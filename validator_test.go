@@ -0,0 +1,116 @@
+package main
+
+import "testing"
+
+func Test_validateMiddlewares(t *testing.T) {
+	money := newExternType("Money")
+	money.languageNames[LanguageTypeGo] = "github.com/acme/money.Money"
+
+	status := newEnum("status")
+	_ = status.addValue("active", "1", nil)
+
+	order := newClass("order")
+	_ = order.addValue("price", "Money", nil)
+	_ = order.addValue("status", "status", nil)
+	_ = order.addValue("tags", "list<string>", nil)
+	_ = order.addValue("prices", "map<string,Money>", nil)
+
+	unknownMember := newClass("unknownMember")
+	_ = unknownMember.addValue("price", "Money", nil)
+
+	unknownListMember := newClass("unknownListMember")
+	_ = unknownListMember.addValue("items", "list<item>", nil)
+
+	validService, info := getTestService()
+	serviceObjects := []middleware{validService}
+	for _, c := range info.classes {
+		serviceObjects = append(serviceObjects, c)
+	}
+
+	unknownResponseService := newService("unknownResponseService")
+	_ = unknownResponseService.addValue("getUser()", "user",
+		[]*annotation{{name: "http", arguments: []string{"GET", "/users"}}})
+
+	unknownParameterService := newService("unknownParameterService")
+	_ = unknownParameterService.addValue("getOrder(order)", "order",
+		[]*annotation{{name: "http", arguments: []string{"GET", "/orders/{orderId}"}}})
+
+	listQueryRequest := newClass("listQueryRequest")
+	_ = listQueryRequest.addValue("ids", "list<int>", nil)
+	listQueryService := newService("listQueryService")
+	_ = listQueryService.addValue("getOrders(listQueryRequest)", "order",
+		[]*annotation{{name: "http", arguments: []string{"GET", "/orders"}}})
+
+	listPathService := newService("listPathService")
+	_ = listPathService.addValue("updateOrders(listQueryRequest)", "order",
+		[]*annotation{{name: "http", arguments: []string{"PUT", "/orders/{ids}"}}})
+
+	validChannel := newChannel("orders.created", "order", []*annotation{{name: "key", arguments: []string{"status"}}})
+	_ = validChannel.addValue("traceId", "string", nil)
+
+	unknownKeyChannel := newChannel("orders.created", "order", []*annotation{{name: "key", arguments: []string{"id"}}})
+	unknownPayloadChannel := newChannel("orders.created", "status", nil)
+
+	classHeaderChannel := newChannel("orders.created", "order", nil)
+	_ = classHeaderChannel.addValue("origin", "order", nil)
+
+	type args struct {
+		objects []middleware
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "Valid types", args: args{objects: []middleware{money, status, order}}, wantErr: false},
+		{name: "Extern type is not declared", args: args{objects: []middleware{unknownMember}}, wantErr: true},
+		{name: "Unknown list type", args: args{objects: []middleware{unknownListMember}}, wantErr: true},
+		{name: "Duplicate type", args: args{objects: []middleware{money, money}}, wantErr: true},
+		{name: "Empty", args: args{objects: []middleware{}}, wantErr: false},
+		{name: "Valid service", args: args{objects: serviceObjects}, wantErr: false},
+		{name: "Service with unknown response", args: args{objects: []middleware{unknownResponseService}}, wantErr: true},
+		{
+			name:    "Service with unknown path parameter",
+			args:    args{objects: []middleware{money, status, order, unknownParameterService}},
+			wantErr: true,
+		},
+		{
+			name:    "Service with list query parameter",
+			args:    args{objects: []middleware{money, status, order, listQueryRequest, listQueryService}},
+			wantErr: true,
+		},
+		{
+			name:    "Service with list path parameter",
+			args:    args{objects: []middleware{money, status, order, listQueryRequest, listPathService}},
+			wantErr: true,
+		},
+		{name: "Valid channel", args: args{objects: []middleware{money, status, order, validChannel}}, wantErr: false},
+		{
+			name:    "Channel with unknown key",
+			args:    args{objects: []middleware{money, status, order, unknownKeyChannel}},
+			wantErr: true,
+		},
+		{
+			name:    "Channel with enum payload",
+			args:    args{objects: []middleware{money, status, order, unknownPayloadChannel}},
+			wantErr: true,
+		},
+		{
+			name:    "Channel with class header",
+			args:    args{objects: []middleware{money, status, order, classHeaderChannel}},
+			wantErr: true,
+		},
+		{
+			name:    "Duplicate topic",
+			args:    args{objects: []middleware{money, status, order, validChannel, validChannel}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateMiddlewares(tt.args.objects); (err != nil) != tt.wantErr {
+				t.Errorf("validateMiddlewares() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/**
+Generate Zod schemas, which validate JSON at runtime, and export the Typescript types they infer.
+Every class and enum is generated into its own file, like the Typescript serializer.
+*/
+type zodLanguageSerializer struct {
+	typesMap        map[string]string
+	typescriptTypes map[string]string
+}
+
+func newZodLanguageSerializer() *zodLanguageSerializer {
+	result := &zodLanguageSerializer{
+		typesMap:        make(map[string]string, 0),
+		typescriptTypes: newTypescriptLanguageSerializer().typesMap,
+	}
+
+	result.typesMap["bool"] = "z.boolean()"
+	result.typesMap["int"] = "z.number().int()"
+	result.typesMap["string"] = "z.string()"
+	result.typesMap["double"] = "z.number()"
+	result.typesMap["float"] = "z.number()"
+	result.typesMap["char"] = "z.string().length(1)"
+	result.typesMap["byte"] = "z.number().int().min(0).max(255)"
+	result.typesMap["date"] = "z.coerce.date()"
+
+	return result
+}
+
+func (z *zodLanguageSerializer) getType() languageType {
+	return LanguageTypeZod
+}
+
+func (z *zodLanguageSerializer) getTypeName() string {
+	return "zod"
+}
+
+func (z *zodLanguageSerializer) generateCode(objects []middleware, serializerInfo *serializerInfo) ([]*generatedCode, error) {
+	result := make([]*generatedCode, 0)
+
+	serializerInfo.externTypes = collectExternTypes(objects)
+	serializerInfo.classes = collectClasses(objects)
+	serializerInfo.enums = collectEnums(objects)
+
+	for _, object := range objects {
+		// Extern types are hand-written, and services and channels aren't generated for Zod
+		if object.getType() != middlewareTypeClass && object.getType() != middlewareTypeEnum {
+			continue
+		}
+
+		serialized, err := z.serializeMiddleware(object, serializerInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result, nil
+}
+
+func (z *zodLanguageSerializer) serializeMiddleware(middleware middleware, serializerInfo *serializerInfo) (*generatedCode, error) {
+	if class, ok := middleware.(*class); ok {
+		return z.serializeClass(class, serializerInfo)
+	}
+
+	if enum, ok := middleware.(*enum); ok {
+		return z.serializeEnum(enum)
+	}
+
+	return nil, errors.New("tried to serialize unknown middleware type")
+}
+
+/**
+Serialize the imports and the generated mark. Imports are written like "module#Symbol",
+and the symbols of the same module are imported together.
+*/
+func (z *zodLanguageSerializer) serializeDeclaration(imports []string) string {
+	modules := make([]string, 0)
+	symbols := make(map[string][]string)
+
+	for _, imp := range imports {
+		module, symbol, _ := strings.Cut(imp, "#")
+		modules = appendUnique(modules, module)
+		symbols[module] = appendUnique(symbols[module], symbol)
+	}
+
+	result := "import { z } from \"zod\";\n"
+	for _, module := range modules {
+		result += fmt.Sprintf("import { %s } from \"%s\";\n", strings.Join(symbols[module], ", "), module)
+	}
+
+	return result + "\n// **********************************\n" +
+		"//\tGenerated by ModelsGenerator\n//\t" +
+		time.Now().Format(time.RFC3339) +
+		"\n// **********************************\n\n"
+}
+
+/**
+Get the name of a class or an enum, taking the name annotation into account.
+The schema of the type is the name with a Schema suffix, like OrderSchema.
+*/
+func (z *zodLanguageSerializer) typeName(typeName string, serializerInfo *serializerInfo) string {
+	if name, ok := findLanguageName(typeName, LanguageTypeZod, serializerInfo); ok {
+		return name
+	}
+
+	return toFirstCharUpper(typeName)
+}
+
+/**
+Get the field name of a data member, taking the name annotation into account.
+*/
+func (z *zodLanguageSerializer) memberName(member *dataMember) string {
+	if name, ok := findLanguageAnnotation(member.annotations, LanguageTypeZod, "name"); ok {
+		return name
+	}
+
+	return toCamelCase(member.name)
+}
+
+/**
+Build the Zod schema of a gen file type, like z.array(OrderItemSchema).
+Return the imports the schema needs. The schema of the current file isn't imported.
+*/
+func (z *zodLanguageSerializer) typeSchema(typeName string, currentType string, serializerInfo *serializerInfo) (string, []string) {
+	if isList, listType := isList(typeName); isList {
+		itemSchema, imports := z.typeSchema(listType, currentType, serializerInfo)
+
+		return fmt.Sprintf("z.array(%s)", itemSchema), imports
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		valueSchema, imports := z.typeSchema(mapValueType, currentType, serializerInfo)
+
+		// JSON object keys are always strings, so number keys are converted
+		keySchema := "z.string()"
+		if z.typescriptTypes[mapKeyType] == "number" {
+			keySchema = "z.coerce.number()"
+		}
+
+		return fmt.Sprintf("z.record(%s, %s)", keySchema, valueSchema), imports
+	}
+
+	if primitiveSchema, isPrimitive := z.typesMap[typeName]; isPrimitive {
+		return primitiveSchema, nil
+	}
+
+	// "@acme/money#MoneySchema" is imported from "@acme/money" and used as "MoneySchema"
+	if externName, isExtern := findExternType(serializerInfo, typeName, LanguageTypeZod); isExtern {
+		if _, symbol, ok := strings.Cut(externName, "#"); ok {
+			return symbol, []string{externName}
+		}
+
+		return externName, nil
+	}
+
+	schemaName := z.typeName(typeName, serializerInfo) + "Schema"
+	if typeName == currentType {
+		return schemaName, nil
+	}
+
+	return schemaName, []string{fmt.Sprintf("./%s#%s", toCamelCase(typeName), schemaName)}
+}
+
+/**
+Get the Typescript type of a gen file type, for the interfaces of recursive classes.
+Classes, enums and extern types are referenced by the types their schemas infer.
+*/
+func (z *zodLanguageSerializer) typescriptType(typeName string, currentType string, serializerInfo *serializerInfo) string {
+	if isList, listType := isList(typeName); isList {
+		return z.typescriptType(listType, currentType, serializerInfo) + "[]"
+	}
+
+	if isMap, mapKeyType, mapValueType := isMap(typeName); isMap {
+		keyType := "string"
+		if z.typescriptTypes[mapKeyType] == "number" {
+			keyType = "number"
+		}
+
+		return fmt.Sprintf("Record<%s, %s>", keyType, z.typescriptType(mapValueType, currentType, serializerInfo))
+	}
+
+	if primitiveType, isPrimitive := z.typescriptTypes[typeName]; isPrimitive {
+		return primitiveType
+	}
+
+	schema, _ := z.typeSchema(typeName, currentType, serializerInfo)
+
+	return fmt.Sprintf("z.infer<typeof %s>", schema)
+}
+
+/**
+Serialize the schema of a class and the type it infers.
+A recursive class, like a tree node, can't infer its own type, so it gets an interface,
+and its schema is lazy, so it can reference itself.
+*/
+func (z *zodLanguageSerializer) serializeClass(class *class, serializerInfo *serializerInfo) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.ts", toCamelCase(class.name))
+	typeName := z.typeName(class.name, serializerInfo)
+	isCyclic := isCyclicClass(class, serializerInfo.classes)
+
+	imports := findLanguageImports(class.annotations, LanguageTypeZod)
+	fields := ""
+	interfaceFields := ""
+
+	for _, member := range class.dataMembers {
+		for _, imp := range findLanguageImports(member.annotations, LanguageTypeZod) {
+			imports = appendUnique(imports, imp)
+		}
+
+		memberName := z.memberName(member)
+
+		if overrideSchema, ok := findLanguageAnnotation(member.annotations, LanguageTypeZod, "type"); ok {
+			fields += fmt.Sprintf("\t%s: %s,\n", memberName, overrideSchema)
+			interfaceFields += fmt.Sprintf("\t%s: unknown;\n", memberName)
+
+			continue
+		}
+
+		schema, schemaImports := z.typeSchema(member.memberType, class.name, serializerInfo)
+		for _, imp := range schemaImports {
+			imports = appendUnique(imports, imp)
+		}
+
+		fields += fmt.Sprintf("\t%s: %s,\n", memberName, schema)
+		interfaceFields += fmt.Sprintf("\t%s: %s;\n", memberName, z.typescriptType(member.memberType, class.name, serializerInfo))
+	}
+
+	if !isCyclic {
+		serializedCode := fmt.Sprintf("export const %sSchema = z.object({\n%s});\n\n", typeName, fields) +
+			fmt.Sprintf("export type %s = z.infer<typeof %sSchema>;", typeName, typeName)
+
+		return newGeneratedCode(fileName, z.serializeDeclaration(imports)+serializedCode), nil
+	}
+
+	serializedCode := fmt.Sprintf("export interface %s {\n%s}\n\n", typeName, interfaceFields) +
+		fmt.Sprintf("export const %sSchema: z.ZodType<%s> = z.lazy(() => z.object({\n%s}));", typeName, typeName, fields)
+
+	return newGeneratedCode(fileName, z.serializeDeclaration(imports)+serializedCode), nil
+}
+
+/**
+Serialize a Typescript enum and its z.nativeEnum schema.
+*/
+func (z *zodLanguageSerializer) serializeEnum(enum *enum) (*generatedCode, error) {
+	fileName := fmt.Sprintf("%s.ts", toCamelCase(enum.name))
+
+	enumName := toFirstCharUpper(enum.name)
+	if name, ok := findLanguageAnnotation(enum.annotations, LanguageTypeZod, "name"); ok {
+		enumName = name
+	}
+
+	serializedCode := fmt.Sprintf("export enum %s {\n", enumName)
+
+	for _, value := range enum.enumValues {
+		valueName := toFirstCharUpper(value.name)
+		if name, ok := findLanguageAnnotation(value.annotations, LanguageTypeZod, "name"); ok {
+			valueName = name
+		}
+
+		serializedCode += fmt.Sprintf("\t%s = %v,\n", valueName, value.value)
+	}
+
+	serializedCode += fmt.Sprintf("}\n\nexport const %sSchema = z.nativeEnum(%s);", enumName, enumName)
+
+	return newGeneratedCode(fileName, z.serializeDeclaration(nil)+serializedCode), nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_zodLanguageSerializer_getType(t *testing.T) {
+	if got := newZodLanguageSerializer().getType(); got != LanguageTypeZod {
+		t.Errorf("getType() = %v, want %v", got, LanguageTypeZod)
+	}
+}
+
+func Test_zodLanguageSerializer_getTypeName(t *testing.T) {
+	if got := newZodLanguageSerializer().getTypeName(); got != "zod" {
+		t.Errorf("getTypeName() = %v, want %v", got, "zod")
+	}
+}
+
+func Test_zodLanguageSerializer_generateCode(t *testing.T) {
+	got, err := newZodLanguageSerializer().generateCode(getTestSchemaObjects(), &serializerInfo{})
+	if err != nil {
+		t.Errorf("generateCode() error = %v", err)
+		return
+	}
+
+	if len(got) != 3 {
+		t.Errorf("generateCode() generated %v files. expected 3", len(got))
+		return
+	}
+
+	fileNames := []string{got[0].fileName, got[1].fileName, got[2].fileName}
+	if !reflect.DeepEqual(fileNames, []string{"order.ts", "orderItem.ts", "orderStatus.ts"}) {
+		t.Errorf("generateCode() file names = %v", fileNames)
+	}
+}
+
+func Test_zodLanguageSerializer_serializeClass(t *testing.T) {
+	s := newZodLanguageSerializer()
+
+	node := &class{
+		name: "node",
+		dataMembers: []*dataMember{
+			{memberType: "string", name: "name"},
+			{memberType: "list<node>", name: "children"},
+			{memberType: "map<int,orderItem>", name: "items"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		class *class
+		want  *generatedCode
+	}{
+		{
+			name: "Class serialize",
+			class: &class{
+				name: "order",
+				dataMembers: []*dataMember{
+					{memberType: "int", name: "id"},
+					{memberType: "list<orderItem>", name: "items"},
+					{memberType: "map<string,double>", name: "prices"},
+					{memberType: "date", name: "createdAt"},
+					{memberType: "orderStatus", name: "status"},
+					{memberType: "byte", name: "count"},
+				},
+			},
+			want: &generatedCode{
+				fileName: "order.ts",
+				code: "import { z } from \"zod\";\n" +
+					"import { OrderItemSchema } from \"./orderItem\";\n" +
+					"import { OrderStatusSchema } from \"./orderStatus\";\n" +
+					"\n" +
+					"export const OrderSchema = z.object({\n" +
+					"\tid: z.number().int(),\n" +
+					"\titems: z.array(OrderItemSchema),\n" +
+					"\tprices: z.record(z.string(), z.number()),\n" +
+					"\tcreatedAt: z.coerce.date(),\n" +
+					"\tstatus: OrderStatusSchema,\n" +
+					"\tcount: z.number().int().min(0).max(255),\n" +
+					"});\n\n" +
+					"export type Order = z.infer<typeof OrderSchema>;",
+			},
+		},
+		{
+			name:  "Recursive class",
+			class: node,
+			want: &generatedCode{
+				fileName: "node.ts",
+				code: "import { z } from \"zod\";\n" +
+					"import { OrderItemSchema } from \"./orderItem\";\n" +
+					"\n" +
+					"export interface Node {\n" +
+					"\tname: string;\n" +
+					"\tchildren: z.infer<typeof NodeSchema>[];\n" +
+					"\titems: Record<number, z.infer<typeof OrderItemSchema>>;\n" +
+					"}\n\n" +
+					"export const NodeSchema: z.ZodType<Node> = z.lazy(() => z.object({\n" +
+					"\tname: z.string(),\n" +
+					"\tchildren: z.array(NodeSchema),\n" +
+					"\titems: z.record(z.coerce.number(), OrderItemSchema),\n" +
+					"}));",
+			},
+		},
+		{
+			name: "Class with language overrides",
+			class: &class{
+				name:        "event",
+				annotations: []*annotation{{namespace: "zod", name: "name", arguments: []string{"UserEvent"}}},
+				dataMembers: []*dataMember{
+					{
+						memberType: "string",
+						name:       "email",
+						annotations: []*annotation{
+							{namespace: "zod", name: "type", arguments: []string{"z.string().email()"}},
+							{namespace: "zod", name: "name", arguments: []string{"mail"}},
+						},
+					},
+				},
+			},
+			want: &generatedCode{
+				fileName: "event.ts",
+				code: "import { z } from \"zod\";\n" +
+					"\n" +
+					"export const UserEventSchema = z.object({\n" +
+					"\tmail: z.string().email(),\n" +
+					"});\n\n" +
+					"export type UserEvent = z.infer<typeof UserEventSchema>;",
+			},
+		},
+		{
+			name: "Class with extern type",
+			class: &class{
+				name:        "price",
+				dataMembers: []*dataMember{{memberType: "Money", name: "amount"}},
+			},
+			want: &generatedCode{
+				fileName: "price.ts",
+				code: "import { z } from \"zod\";\n" +
+					"import { MoneySchema } from \"@acme/money\";\n" +
+					"\n" +
+					"export const PriceSchema = z.object({\n" +
+					"\tamount: MoneySchema,\n" +
+					"});\n\n" +
+					"export type Price = z.infer<typeof PriceSchema>;",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &serializerInfo{
+				externTypes: getTestExternTypes(),
+				classes:     collectClasses([]middleware{tt.class, newClass("orderItem")}),
+			}
+			info.externTypes["Money"].languageNames[LanguageTypeZod] = "@acme/money#MoneySchema"
+
+			got, err := s.serializeClass(tt.class, info)
+			if err != nil {
+				t.Errorf("serializeClass() error = %v", err)
+				return
+			}
+
+			got.code = strings.Replace(got.code, s.serializeDeclaration(nil)[len("import { z } from \"zod\";\n"):], "\n", -1)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serializeClass() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_zodLanguageSerializer_serializeEnum(t *testing.T) {
+	s := newZodLanguageSerializer()
+
+	status := &enum{
+		name:       "orderStatus",
+		enumValues: []*enumValue{{name: "active", value: 1}, {name: "closed", value: 5}},
+	}
+
+	want := &generatedCode{
+		fileName: "orderStatus.ts",
+		code: "export enum OrderStatus {\n" +
+			"\tActive = 1,\n" +
+			"\tClosed = 5,\n" +
+			"}\n\n" +
+			"export const OrderStatusSchema = z.nativeEnum(OrderStatus);",
+	}
+
+	got, err := s.serializeEnum(status)
+	if err != nil {
+		t.Errorf("serializeEnum() error = %v", err)
+		return
+	}
+
+	got.code = strings.Replace(got.code, s.serializeDeclaration(nil), "", -1)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("serializeEnum() got = %v, want %v", got, want)
+	}
+}